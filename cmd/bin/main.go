@@ -17,6 +17,9 @@ func main() {
 
 	serverCmd := flag.NewFlagSet("server", flag.ExitOnError)
 	seedCmd := flag.NewFlagSet("seed", flag.ExitOnError)
+	ratingsCmd := flag.NewFlagSet("ratings", flag.ExitOnError)
+	purgeCmd := flag.NewFlagSet("purge", flag.ExitOnError)
+	importCmd := flag.NewFlagSet("import", flag.ExitOnError)
 	// wsCmd := flag.NewFlagSet("ws", flag.ExitOnError)
 
 	if len(os.Args) < 2 {
@@ -28,6 +31,12 @@ func main() {
 	switch os.Args[1] {
 	case "seed":
 		cmd.RunSeed(seedCmd, os.Args[2:])
+	case "ratings":
+		cmd.RunRatings(ratingsCmd, os.Args[2:])
+	case "purge":
+		cmd.RunPurge(purgeCmd, os.Args[2:])
+	case "import":
+		cmd.RunImport(importCmd, os.Args[2:])
 	case "server":
 		cmd.RunServer(serverCmd, os.Args[2:])
 	default:
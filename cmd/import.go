@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"codebase-app/internal/adapter"
+	integration "codebase-app/internal/integration/filestorage"
+	"codebase-app/internal/module/product/entity"
+	"codebase-app/internal/module/product/repository"
+	"codebase-app/internal/module/product/service"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/types"
+	"codebase-app/pkg/validator"
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+
+	"github.com/rs/zerolog/log"
+)
+
+// RunImport creates the products of a CSV or JSON Lines file on behalf of
+// the shop owner and prints the import report.
+func RunImport(cmd *flag.FlagSet, args []string) {
+	var (
+		file      = cmd.String("file", "", "path to the CSV or JSON Lines file")
+		format    = cmd.String("format", "", "csv or jsonl, guessed from the file extension when empty")
+		shopId    = cmd.String("shop_id", "", "shop the products are created in")
+		userId    = cmd.String("user_id", "", "owner of the shop")
+		dryRun    = cmd.Bool("dry_run", false, "only validate the file, nothing is written")
+		batchSize = cmd.Int("batch_size", 0, "rows committed per transaction, 0 imports the whole file in one")
+	)
+
+	if err := cmd.Parse(args); err != nil {
+		log.Fatal().Err(err).Msg("Error while parsing flags")
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error while opening import file")
+	}
+	defer f.Close()
+
+	adapter.Adapters.Sync(
+		adapter.WithShopeefunPostgres(),
+		adapter.WithValidator(validator.NewValidator()),
+	)
+	defer func() {
+		if err := adapter.Adapters.Unsync(); err != nil {
+			log.Fatal().Err(err).Msg("Error while closing database connection")
+		}
+	}()
+
+	var (
+		ctx  = context.Background()
+		repo = repository.NewProductRepository(adapter.Adapters.ShopeefunPostgres)
+		svc  = service.NewProductService(repo, adapter.Adapters.Validator, integration.NewFileStorageIntegration())
+		req  = &entity.ImportProductsRequest{
+			UserId:    *userId,
+			ShopId:    *shopId,
+			Format:    *format,
+			DryRun:    *dryRun,
+			BatchSize: *batchSize,
+			File:      f,
+		}
+	)
+
+	if req.Format == "" {
+		req.Format = types.DetectFormat(*file, "")
+	}
+
+	if err := adapter.Adapters.Validator.Validate(req); err != nil {
+		_, errs := errmsg.Errors(err, req)
+		log.Error().Any("errors", errs).Msg("Invalid import flags")
+		return
+	}
+
+	resp, err := svc.ImportProducts(ctx, req)
+	if resp != nil {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(resp); err != nil {
+			log.Error().Err(err).Msg("Error while printing import report")
+		}
+	}
+
+	if err != nil {
+		_, errs := errmsg.Errors[error](err)
+		log.Error().Err(err).Any("errors", errs).Msg("Error while importing products")
+		return
+	}
+
+	log.Info().Int("total", resp.Total).Int("invalid", resp.Invalid).Int("created", resp.Created).Bool("dry_run", resp.DryRun).Msg("products import finished")
+}
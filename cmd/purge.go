@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"codebase-app/internal/adapter"
+	"codebase-app/internal/infrastructure/config"
+	productRepository "codebase-app/internal/module/product/repository"
+	shopRepository "codebase-app/internal/module/shop/repository"
+	"context"
+	"flag"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// RunPurge hard deletes the products and shops kept in the trash longer than the retention period.
+func RunPurge(cmd *flag.FlagSet, args []string) {
+	var (
+		retentionDays = cmd.Int("retention_days", config.Envs.Trash.RetentionDays, "days trashed rows are kept, 0 purges the whole trash")
+	)
+
+	if err := cmd.Parse(args); err != nil {
+		log.Fatal().Err(err).Msg("Error while parsing flags")
+	}
+
+	adapter.Adapters.Sync(
+		adapter.WithShopeefunPostgres(),
+	)
+	defer func() {
+		if err := adapter.Adapters.Unsync(); err != nil {
+			log.Fatal().Err(err).Msg("Error while closing database connection")
+		}
+	}()
+
+	var (
+		ctx      = context.Background()
+		before   = time.Now().AddDate(0, 0, -*retentionDays)
+		products = productRepository.NewProductRepository(adapter.Adapters.ShopeefunPostgres)
+		shops    = shopRepository.NewShopRepository(adapter.Adapters.ShopeefunPostgres)
+	)
+
+	// products first, a shop is only purged once none of its products are left behind
+	totalProducts, err := products.PurgeProducts(ctx, before)
+	if err != nil {
+		log.Error().Err(err).Msg("Error while purging products")
+		return
+	}
+
+	totalShops, err := shops.PurgeShops(ctx, before)
+	if err != nil {
+		log.Error().Err(err).Msg("Error while purging shops")
+		return
+	}
+
+	log.Info().Int64("products", totalProducts).Int64("shops", totalShops).Time("before", before).Msg("trash purged successfully")
+}
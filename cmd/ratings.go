@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"codebase-app/internal/adapter"
+	"codebase-app/internal/module/product/repository"
+	"context"
+	"flag"
+
+	"github.com/rs/zerolog/log"
+)
+
+// RunRatings rebuilds the denormalized product rating aggregates from the reviews table.
+func RunRatings(cmd *flag.FlagSet, args []string) {
+	var (
+		productId = cmd.String("product_id", "", "rebuild a single product, all products when empty")
+	)
+
+	if err := cmd.Parse(args); err != nil {
+		log.Fatal().Err(err).Msg("Error while parsing flags")
+	}
+
+	adapter.Adapters.Sync(
+		adapter.WithShopeefunPostgres(),
+	)
+	defer func() {
+		if err := adapter.Adapters.Unsync(); err != nil {
+			log.Fatal().Err(err).Msg("Error while closing database connection")
+		}
+	}()
+
+	repo := repository.NewProductRepository(adapter.Adapters.ShopeefunPostgres)
+
+	total, err := repo.RebuildRatings(context.Background(), *productId)
+	if err != nil {
+		log.Error().Err(err).Msg("Error while rebuilding ratings")
+		return
+	}
+
+	log.Info().Int64("products", total).Msg("product ratings rebuilt successfully")
+}
@@ -5,6 +5,7 @@ import (
 	"codebase-app/internal/infrastructure"
 	"codebase-app/internal/infrastructure/config"
 	"codebase-app/internal/route"
+	"codebase-app/pkg/scheduler"
 	"codebase-app/pkg/validator"
 	"flag"
 	"os"
@@ -54,9 +55,10 @@ func RunServer(cmd *flag.FlagSet, args []string) {
 	}
 
 	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowMethods: "GET,POST,PUT,DELETE,PATCH,OPTIONS,HEAD",
-		AllowHeaders: "Origin,Content-Type,Accept,Content-Length,Accept-Language,Accept-Encoding,Connection,Access-Control-Allow-Origin,Authorization",
+		AllowOrigins:  "*",
+		AllowMethods:  "GET,POST,PUT,DELETE,PATCH,OPTIONS,HEAD",
+		AllowHeaders:  "Origin,Content-Type,Accept,Content-Length,Accept-Language,Accept-Encoding,Connection,Access-Control-Allow-Origin,Authorization,If-Match,If-None-Match",
+		ExposeHeaders: "ETag",
 	}))
 	// End Application Middlewares
 
@@ -66,10 +68,20 @@ func RunServer(cmd *flag.FlagSet, args []string) {
 		adapter.WithValidator(validator.NewValidator()),
 	)
 
+	if envs.ShopeefunStorage.Driver == "s3" {
+		adapter.Adapters.Sync(adapter.WithDigihubStorage())
+	}
+
 	infrastructure.InitializeLogger(envs.App.Environtment, envs.App.LogFile, logLevel)
 	app.Get("/metrics", monitor.New(monitor.Config{Title: config.Envs.App.Name + config.Envs.App.Environtment + " Metrics"}))
 	route.SetupRoutes(app)
 
+	jobs := scheduler.New()
+	if envs.Scheduler.Enabled {
+		route.SetupJobs(jobs)
+		jobs.Start()
+	}
+
 	// print all routes that are registered
 	// for _, route := range app.Stack() {
 	// 	for _, handler := range route {
@@ -98,6 +110,8 @@ func RunServer(cmd *flag.FlagSet, args []string) {
 	<-quit
 	log.Info().Msg("Server is shutting down ...")
 
+	jobs.Stop()
+
 	err = adapter.Adapters.Unsync()
 	if err != nil {
 		log.Error().Msgf("Error while closing adapters: %v", err)
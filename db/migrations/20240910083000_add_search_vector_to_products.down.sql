@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS products_search_vector_idx;
+
+DROP TRIGGER IF EXISTS categories_search_vector_trigger ON categories;
+DROP FUNCTION IF EXISTS categories_search_vector_refresh();
+
+DROP TRIGGER IF EXISTS brands_search_vector_trigger ON brands;
+DROP FUNCTION IF EXISTS brands_search_vector_refresh();
+
+DROP TRIGGER IF EXISTS products_search_vector_trigger ON products;
+DROP FUNCTION IF EXISTS products_search_vector_refresh();
+
+ALTER TABLE products DROP COLUMN IF EXISTS search_vector;
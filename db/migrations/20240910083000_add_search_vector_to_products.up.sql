@@ -0,0 +1,53 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS search_vector TSVECTOR;
+
+CREATE OR REPLACE FUNCTION products_search_vector_refresh() RETURNS TRIGGER AS $$
+DECLARE
+  brand_name VARCHAR(255);
+  category_name VARCHAR(255);
+BEGIN
+  SELECT name INTO brand_name FROM brands WHERE id = NEW.brand_id;
+  SELECT name INTO category_name FROM categories WHERE id = NEW.category_id;
+
+  NEW.search_vector :=
+    setweight(to_tsvector('simple', COALESCE(NEW.name, '')), 'A') ||
+    setweight(to_tsvector('simple', COALESCE(brand_name, '')), 'B') ||
+    setweight(to_tsvector('simple', COALESCE(category_name, '')), 'C') ||
+    setweight(to_tsvector('simple', COALESCE(NEW.description, '')), 'D');
+
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER products_search_vector_trigger
+  BEFORE INSERT OR UPDATE OF name, description, brand_id, category_id ON products
+  FOR EACH ROW EXECUTE FUNCTION products_search_vector_refresh();
+
+-- brand and category names are part of the vector, so renaming them has to
+-- re-run the products trigger for every product that references them
+CREATE OR REPLACE FUNCTION brands_search_vector_refresh() RETURNS TRIGGER AS $$
+BEGIN
+  UPDATE products SET brand_id = brand_id WHERE brand_id = NEW.id;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER brands_search_vector_trigger
+  AFTER UPDATE OF name ON brands
+  FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name)
+  EXECUTE FUNCTION brands_search_vector_refresh();
+
+CREATE OR REPLACE FUNCTION categories_search_vector_refresh() RETURNS TRIGGER AS $$
+BEGIN
+  UPDATE products SET category_id = category_id WHERE category_id = NEW.id;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER categories_search_vector_trigger
+  AFTER UPDATE OF name ON categories
+  FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name)
+  EXECUTE FUNCTION categories_search_vector_refresh();
+
+UPDATE products SET name = name;
+
+CREATE INDEX IF NOT EXISTS products_search_vector_idx ON products USING GIN (search_vector);
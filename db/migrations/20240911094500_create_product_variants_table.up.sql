@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS product_variants (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+  sku VARCHAR(64) NOT NULL,
+  options JSONB NOT NULL DEFAULT '{}',
+  price NUMERIC(10, 2) CHECK (price >= 0),
+  stock INTEGER NOT NULL DEFAULT 0 CHECK (stock >= 0),
+  barcode VARCHAR(64),
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+  deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS product_variants_sku_key ON product_variants (sku) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS product_variants_product_id_idx ON product_variants (product_id) WHERE deleted_at IS NULL;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS product_images (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+  file_name VARCHAR(255) NOT NULL,
+  url TEXT NOT NULL,
+  alt_text VARCHAR(255) NOT NULL DEFAULT '',
+  position INTEGER NOT NULL DEFAULT 0,
+  is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS product_images_primary_key ON product_images (product_id) WHERE is_primary;
+CREATE INDEX IF NOT EXISTS product_images_product_id_idx ON product_images (product_id, position);
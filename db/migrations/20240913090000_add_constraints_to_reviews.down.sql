@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS reviews_user_id_idx;
+DROP INDEX IF EXISTS reviews_product_id_created_at_idx;
+
+ALTER TABLE reviews DROP CONSTRAINT IF EXISTS reviews_product_id_user_id_key;
+ALTER TABLE reviews DROP CONSTRAINT IF EXISTS reviews_rating_check;
@@ -0,0 +1,12 @@
+-- keep only the latest review of each user per product
+DELETE FROM reviews r
+USING reviews newer
+WHERE r.product_id = newer.product_id
+  AND r.user_id = newer.user_id
+  AND (r.created_at, r.id) < (newer.created_at, newer.id);
+
+ALTER TABLE reviews ADD CONSTRAINT reviews_rating_check CHECK (rating BETWEEN 1 AND 5);
+ALTER TABLE reviews ADD CONSTRAINT reviews_product_id_user_id_key UNIQUE (product_id, user_id);
+
+CREATE INDEX IF NOT EXISTS reviews_product_id_created_at_idx ON reviews (product_id, created_at);
+CREATE INDEX IF NOT EXISTS reviews_user_id_idx ON reviews (user_id, created_at);
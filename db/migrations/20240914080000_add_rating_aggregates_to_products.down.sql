@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS products_rating_count_idx;
+DROP INDEX IF EXISTS products_rating_avg_idx;
+
+DROP TRIGGER IF EXISTS reviews_rating_trigger ON reviews;
+DROP FUNCTION IF EXISTS reviews_rating_refresh();
+DROP FUNCTION IF EXISTS products_rating_apply(UUID, INTEGER, INTEGER);
+
+ALTER TABLE products
+  DROP COLUMN IF EXISTS rating_5_count,
+  DROP COLUMN IF EXISTS rating_4_count,
+  DROP COLUMN IF EXISTS rating_3_count,
+  DROP COLUMN IF EXISTS rating_2_count,
+  DROP COLUMN IF EXISTS rating_1_count,
+  DROP COLUMN IF EXISTS rating_count,
+  DROP COLUMN IF EXISTS rating_avg;
@@ -0,0 +1,76 @@
+ALTER TABLE products
+  ADD COLUMN IF NOT EXISTS rating_avg NUMERIC(3, 2) NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS rating_count INTEGER NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS rating_1_count INTEGER NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS rating_2_count INTEGER NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS rating_3_count INTEGER NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS rating_4_count INTEGER NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS rating_5_count INTEGER NOT NULL DEFAULT 0;
+
+-- products_rating_apply adds (sign = 1) or removes (sign = -1) one rating
+-- from the product aggregates, the average is derived from the histogram
+CREATE OR REPLACE FUNCTION products_rating_apply(target UUID, star INTEGER, sign INTEGER) RETURNS VOID AS $$
+BEGIN
+  UPDATE products SET
+    rating_count = rating_count + sign,
+    rating_1_count = rating_1_count + CASE WHEN star = 1 THEN sign ELSE 0 END,
+    rating_2_count = rating_2_count + CASE WHEN star = 2 THEN sign ELSE 0 END,
+    rating_3_count = rating_3_count + CASE WHEN star = 3 THEN sign ELSE 0 END,
+    rating_4_count = rating_4_count + CASE WHEN star = 4 THEN sign ELSE 0 END,
+    rating_5_count = rating_5_count + CASE WHEN star = 5 THEN sign ELSE 0 END
+  WHERE id = target;
+
+  UPDATE products SET
+    rating_avg = CASE
+      WHEN rating_count = 0 THEN 0
+      ELSE ROUND((rating_1_count + rating_2_count * 2 + rating_3_count * 3 + rating_4_count * 4 + rating_5_count * 5)::NUMERIC / rating_count, 2)
+    END
+  WHERE id = target;
+END;
+$$ LANGUAGE plpgsql;
+
+-- runs in the same transaction as the review write, so the aggregates
+-- never drift from the reviews table
+CREATE OR REPLACE FUNCTION reviews_rating_refresh() RETURNS TRIGGER AS $$
+BEGIN
+  IF TG_OP IN ('UPDATE', 'DELETE') THEN
+    PERFORM products_rating_apply(OLD.product_id, OLD.rating, -1);
+  END IF;
+
+  IF TG_OP IN ('INSERT', 'UPDATE') THEN
+    PERFORM products_rating_apply(NEW.product_id, NEW.rating, 1);
+  END IF;
+
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER reviews_rating_trigger
+  AFTER INSERT OR DELETE OR UPDATE OF product_id, rating ON reviews
+  FOR EACH ROW EXECUTE FUNCTION reviews_rating_refresh();
+
+UPDATE products p SET
+  rating_count = a.total,
+  rating_1_count = a.star_1,
+  rating_2_count = a.star_2,
+  rating_3_count = a.star_3,
+  rating_4_count = a.star_4,
+  rating_5_count = a.star_5,
+  rating_avg = ROUND(a.average, 2)
+FROM (
+  SELECT
+    product_id,
+    COUNT(id) AS total,
+    COUNT(id) FILTER (WHERE rating = 1) AS star_1,
+    COUNT(id) FILTER (WHERE rating = 2) AS star_2,
+    COUNT(id) FILTER (WHERE rating = 3) AS star_3,
+    COUNT(id) FILTER (WHERE rating = 4) AS star_4,
+    COUNT(id) FILTER (WHERE rating = 5) AS star_5,
+    AVG(rating) AS average
+  FROM reviews
+  GROUP BY product_id
+) a
+WHERE p.id = a.product_id;
+
+CREATE INDEX IF NOT EXISTS products_rating_avg_idx ON products (rating_avg DESC, id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS products_rating_count_idx ON products (rating_count DESC, id DESC) WHERE deleted_at IS NULL;
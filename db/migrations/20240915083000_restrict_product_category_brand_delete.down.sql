@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS products_brand_id_idx;
+DROP INDEX IF EXISTS products_category_id_idx;
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_brand_id_fkey;
+ALTER TABLE products ADD CONSTRAINT products_brand_id_fkey
+  FOREIGN KEY (brand_id) REFERENCES brands(id) ON DELETE SET NULL;
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_category_id_fkey;
+ALTER TABLE products ADD CONSTRAINT products_category_id_fkey
+  FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE SET NULL;
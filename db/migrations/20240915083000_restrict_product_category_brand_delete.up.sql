@@ -0,0 +1,12 @@
+-- deleting a category or brand that products still use must fail loudly
+-- instead of silently detaching the products
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_category_id_fkey;
+ALTER TABLE products ADD CONSTRAINT products_category_id_fkey
+  FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE RESTRICT;
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_brand_id_fkey;
+ALTER TABLE products ADD CONSTRAINT products_brand_id_fkey
+  FOREIGN KEY (brand_id) REFERENCES brands(id) ON DELETE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS products_category_id_idx ON products (category_id);
+CREATE INDEX IF NOT EXISTS products_brand_id_idx ON products (brand_id);
@@ -0,0 +1,4 @@
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES categories(id) ON DELETE RESTRICT;
+ALTER TABLE categories ADD CONSTRAINT categories_parent_id_check CHECK (parent_id IS DISTINCT FROM id);
+
+CREATE INDEX IF NOT EXISTS categories_parent_id_idx ON categories (parent_id);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS category_attributes (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  category_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+  key VARCHAR(64) NOT NULL,
+  label VARCHAR(255) NOT NULL,
+  type VARCHAR(16) NOT NULL CHECK (type IN ('string', 'number', 'enum', 'boolean')),
+  options TEXT[] NOT NULL DEFAULT '{}',
+  is_required BOOLEAN NOT NULL DEFAULT FALSE,
+  position INTEGER NOT NULL DEFAULT 0,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+  UNIQUE (category_id, key)
+);
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS attributes JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS products_attributes_idx ON products USING GIN (attributes);
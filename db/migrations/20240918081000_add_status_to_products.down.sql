@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS products_unpublish_at_idx;
+DROP INDEX IF EXISTS products_publish_at_idx;
+DROP INDEX IF EXISTS products_status_idx;
+
+ALTER TABLE products
+  DROP COLUMN IF EXISTS unpublish_at,
+  DROP COLUMN IF EXISTS publish_at,
+  DROP COLUMN IF EXISTS published_at,
+  DROP COLUMN IF EXISTS status;
@@ -0,0 +1,15 @@
+-- products created before statuses existed were live, keep them published
+ALTER TABLE products ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'published'
+  CHECK (status IN ('draft', 'published', 'archived'));
+ALTER TABLE products ALTER COLUMN status SET DEFAULT 'draft';
+
+ALTER TABLE products
+  ADD COLUMN IF NOT EXISTS published_at TIMESTAMP WITH TIME ZONE,
+  ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP WITH TIME ZONE,
+  ADD COLUMN IF NOT EXISTS unpublish_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE products SET published_at = created_at WHERE status = 'published';
+
+CREATE INDEX IF NOT EXISTS products_status_idx ON products (status) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS products_publish_at_idx ON products (publish_at) WHERE status = 'draft' AND publish_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS products_unpublish_at_idx ON products (unpublish_at) WHERE status = 'published' AND unpublish_at IS NOT NULL;
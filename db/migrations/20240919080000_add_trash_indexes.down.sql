@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS shops_deleted_at_idx;
+DROP INDEX IF EXISTS products_deleted_at_idx;
@@ -0,0 +1,3 @@
+-- the trash listings and the purge job only look at soft-deleted rows
+CREATE INDEX IF NOT EXISTS products_deleted_at_idx ON products (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS shops_deleted_at_idx ON shops (deleted_at) WHERE deleted_at IS NOT NULL;
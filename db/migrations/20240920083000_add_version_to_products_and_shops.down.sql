@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS product_images_version_trigger ON product_images;
+DROP TRIGGER IF EXISTS product_variants_version_trigger ON product_variants;
+DROP FUNCTION IF EXISTS products_version_bump();
+
+ALTER TABLE shops DROP COLUMN IF EXISTS version;
+ALTER TABLE products DROP COLUMN IF EXISTS version;
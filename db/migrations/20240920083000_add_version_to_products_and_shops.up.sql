@@ -0,0 +1,29 @@
+-- version backs the ETag / If-Match headers, the application bumps it on
+-- every write to the row itself. Rating aggregates are left out on purpose so
+-- a new review doesn't invalidate a seller's pending edit.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE shops ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+
+-- variants and images are part of the product detail, changing them has to
+-- change its ETag as well
+CREATE OR REPLACE FUNCTION products_version_bump() RETURNS TRIGGER AS $$
+BEGIN
+  IF TG_OP IN ('UPDATE', 'DELETE') THEN
+    UPDATE products SET version = version + 1 WHERE id = OLD.product_id;
+  END IF;
+
+  IF TG_OP = 'INSERT' OR (TG_OP = 'UPDATE' AND NEW.product_id IS DISTINCT FROM OLD.product_id) THEN
+    UPDATE products SET version = version + 1 WHERE id = NEW.product_id;
+  END IF;
+
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER product_variants_version_trigger
+  AFTER INSERT OR UPDATE OR DELETE ON product_variants
+  FOR EACH ROW EXECUTE FUNCTION products_version_bump();
+
+CREATE TRIGGER product_images_version_trigger
+  AFTER INSERT OR UPDATE OR DELETE ON product_images
+  FOR EACH ROW EXECUTE FUNCTION products_version_bump();
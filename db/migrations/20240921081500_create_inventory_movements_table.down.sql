@@ -0,0 +1,6 @@
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_stock_check;
+
+DROP TRIGGER IF EXISTS inventory_movements_append_only_trigger ON inventory_movements;
+DROP FUNCTION IF EXISTS inventory_movements_append_only();
+
+DROP TABLE IF EXISTS inventory_movements;
@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS inventory_movements (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+  delta INTEGER NOT NULL CHECK (delta <> 0),
+  reason VARCHAR(16) NOT NULL CHECK (reason IN ('restock', 'sale', 'return', 'correction')),
+  actor_id UUID NOT NULL,
+  reference_id VARCHAR(128),
+  note VARCHAR(255),
+  stock_after INTEGER NOT NULL CHECK (stock_after >= 0),
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS inventory_movements_product_id_idx ON inventory_movements (product_id, created_at DESC, id DESC);
+
+-- the same order or return can't be applied twice to a product
+CREATE UNIQUE INDEX IF NOT EXISTS inventory_movements_reference_key
+  ON inventory_movements (product_id, reason, reference_id) WHERE reference_id IS NOT NULL;
+
+-- the ledger is append-only, rows only go away together with their product
+CREATE OR REPLACE FUNCTION inventory_movements_append_only() RETURNS TRIGGER AS $$
+BEGIN
+  RAISE EXCEPTION 'inventory_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER inventory_movements_append_only_trigger
+  BEFORE UPDATE ON inventory_movements
+  FOR EACH ROW EXECUTE FUNCTION inventory_movements_append_only();
+
+-- opening balance, so the ledger sums up to the current stock
+INSERT INTO inventory_movements (product_id, delta, reason, actor_id, reference_id, stock_after, created_at)
+SELECT id, stock, 'correction', user_id, 'opening-balance', stock, created_at
+FROM products
+WHERE stock > 0;
+
+UPDATE products SET stock = 0 WHERE stock < 0;
+
+ALTER TABLE products ADD CONSTRAINT products_stock_check CHECK (stock >= 0);
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS stock_reservations (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL,
+  reference_id VARCHAR(128),
+  status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'committed', 'released', 'expired')),
+  expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+-- a checkout can only hold one reservation
+CREATE UNIQUE INDEX IF NOT EXISTS stock_reservations_reference_key
+  ON stock_reservations (user_id, reference_id) WHERE reference_id IS NOT NULL;
+
+-- used by the expiry sweeper
+CREATE INDEX IF NOT EXISTS stock_reservations_pending_idx ON stock_reservations (expires_at) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS stock_reservation_items (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  reservation_id UUID NOT NULL REFERENCES stock_reservations(id) ON DELETE CASCADE,
+  product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+  variant_id UUID REFERENCES product_variants(id) ON DELETE CASCADE,
+  quantity INTEGER NOT NULL CHECK (quantity > 0)
+);
+
+CREATE INDEX IF NOT EXISTS stock_reservation_items_reservation_id_idx ON stock_reservation_items (reservation_id);
+CREATE INDEX IF NOT EXISTS stock_reservation_items_product_id_idx ON stock_reservation_items (product_id, variant_id);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS product_price_schedules;
+
+DROP TRIGGER IF EXISTS products_price_history_trigger ON products;
+DROP FUNCTION IF EXISTS products_price_history();
+
+DROP TABLE IF EXISTS product_price_history;
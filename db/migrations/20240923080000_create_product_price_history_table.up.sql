@@ -0,0 +1,48 @@
+CREATE TABLE IF NOT EXISTS product_price_history (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+  price NUMERIC(10, 2) NOT NULL,
+  previous_price NUMERIC(10, 2),
+  changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS product_price_history_product_id_idx ON product_price_history (product_id, changed_at DESC);
+
+-- every write path changes prices through products, so the trigger catches them all
+CREATE OR REPLACE FUNCTION products_price_history() RETURNS TRIGGER AS $$
+BEGIN
+  IF TG_OP = 'INSERT' THEN
+    INSERT INTO product_price_history (product_id, price) VALUES (NEW.id, NEW.price);
+  ELSIF NEW.price IS DISTINCT FROM OLD.price THEN
+    INSERT INTO product_price_history (product_id, price, previous_price) VALUES (NEW.id, NEW.price, OLD.price);
+  END IF;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER products_price_history_trigger
+  AFTER INSERT OR UPDATE OF price ON products
+  FOR EACH ROW EXECUTE FUNCTION products_price_history();
+
+-- the current price is the only one known for existing products
+INSERT INTO product_price_history (product_id, price)
+SELECT id, price FROM products;
+
+CREATE TABLE IF NOT EXISTS product_price_schedules (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+  price NUMERIC(10, 2) NOT NULL CHECK (price > 0),
+  previous_price NUMERIC(10, 2), -- price replaced when the schedule started, restored when it ends
+  starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  ends_at TIMESTAMP WITH TIME ZONE CHECK (ends_at > starts_at),
+  status VARCHAR(16) NOT NULL DEFAULT 'scheduled' CHECK (status IN ('scheduled', 'active', 'completed', 'cancelled')),
+  created_by UUID NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS product_price_schedules_product_id_idx ON product_price_schedules (product_id, starts_at);
+
+-- used by the scheduler
+CREATE INDEX IF NOT EXISTS product_price_schedules_due_idx
+  ON product_price_schedules (starts_at, ends_at) WHERE status IN ('scheduled', 'active');
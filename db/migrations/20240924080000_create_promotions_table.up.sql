@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS promotions (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  shop_id UUID NOT NULL REFERENCES shops(id) ON DELETE CASCADE,
+  name VARCHAR(255) NOT NULL,
+  scope VARCHAR(16) NOT NULL CHECK (scope IN ('shop', 'category', 'brand', 'products')),
+  category_id UUID REFERENCES categories(id) ON DELETE CASCADE,
+  brand_id UUID REFERENCES brands(id) ON DELETE CASCADE,
+  discount_type VARCHAR(16) NOT NULL CHECK (discount_type IN ('percent', 'fixed')),
+  discount_value NUMERIC(10, 2) NOT NULL CHECK (discount_value > 0),
+  priority INTEGER NOT NULL DEFAULT 0,
+  starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  created_by UUID NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+  CHECK (ends_at > starts_at),
+  CHECK (discount_type <> 'percent' OR discount_value <= 100),
+  CHECK ((scope = 'category') = (category_id IS NOT NULL)),
+  CHECK ((scope = 'brand') = (brand_id IS NOT NULL))
+);
+
+CREATE INDEX IF NOT EXISTS promotions_shop_id_idx ON promotions (shop_id, ends_at);
+
+CREATE TABLE IF NOT EXISTS promotion_products (
+  promotion_id UUID NOT NULL REFERENCES promotions(id) ON DELETE CASCADE,
+  product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+  PRIMARY KEY (promotion_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS promotion_products_product_id_idx ON promotion_products (product_id);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS product_exports (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL,
+  format VARCHAR(8) NOT NULL CHECK (format IN ('csv', 'jsonl')),
+  filters JSONB NOT NULL DEFAULT '{}', -- the listing filters the export was requested with
+  status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+  row_count INTEGER,
+  file_name TEXT, -- path inside the private storage once completed
+  error TEXT,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+  started_at TIMESTAMP WITH TIME ZONE,
+  completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS product_exports_user_id_idx ON product_exports (user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS product_exports_pending_idx ON product_exports (created_at) WHERE status IN ('pending', 'running');
@@ -0,0 +1,23 @@
+-- product_feed_items holds the published products as they appear in the
+-- product feeds, rewritten by the feed job only when an item changed. Items
+-- that left the feed are kept with removed_at so updated_at still tells
+-- when a feed last changed.
+CREATE TABLE IF NOT EXISTS product_feed_items (
+  product_id UUID PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+  shop_id UUID NOT NULL REFERENCES shops(id) ON DELETE CASCADE,
+  title VARCHAR(255) NOT NULL,
+  description TEXT NOT NULL,
+  image_link TEXT,
+  price NUMERIC(10, 2) NOT NULL,
+  sale_price NUMERIC(10, 2), -- set while a promotion applies
+  sale_starts_at TIMESTAMP WITH TIME ZONE,
+  sale_ends_at TIMESTAMP WITH TIME ZONE,
+  availability VARCHAR(16) NOT NULL CHECK (availability IN ('in_stock', 'out_of_stock')),
+  brand VARCHAR(255) NOT NULL,
+  product_type TEXT NOT NULL, -- category path, e.g. "Elektronik > Laptop"
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+  removed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS product_feed_items_shop_id_idx ON product_feed_items (shop_id, product_id) WHERE removed_at IS NULL;
+CREATE INDEX IF NOT EXISTS product_feed_items_updated_at_idx ON product_feed_items (shop_id, updated_at);
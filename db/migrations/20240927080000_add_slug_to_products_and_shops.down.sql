@@ -0,0 +1,7 @@
+ALTER TABLE product_feed_items DROP COLUMN IF EXISTS slug;
+DROP TABLE IF EXISTS shop_slug_history;
+DROP TABLE IF EXISTS product_slug_history;
+DROP INDEX IF EXISTS shops_slug_key;
+DROP INDEX IF EXISTS products_slug_key;
+ALTER TABLE shops DROP COLUMN IF EXISTS slug;
+ALTER TABLE products DROP COLUMN IF EXISTS slug;
@@ -0,0 +1,67 @@
+-- slugify mirrors slug.Make for the rows that exist already: latin letters
+-- lose their accents and the rest collapses into dashes. It is shorter
+-- than slug.MaxLength to leave room for the id suffix below.
+CREATE FUNCTION pg_temp.slugify(name TEXT, fallback TEXT) RETURNS TEXT AS $$
+  SELECT COALESCE(NULLIF(RTRIM(LEFT(TRIM(BOTH '-' FROM REGEXP_REPLACE(
+    TRANSLATE(
+      REPLACE(REPLACE(REPLACE(REPLACE(LOWER(name), 'ß', 'ss'), 'æ', 'ae'), 'œ', 'oe'), 'þ', 'th'),
+      'àáâãäåçèéêëìíîïñòóôõöùúûüýÿāăąćĉċčďēĕėęěĝğġģĥĩīĭįĵķĺļľńņňōŏőŕŗřśŝşšţťũūŭůűųŵŷźżžſøđðłıħŧ',
+      'aaaaaaceeeeiiiinooooouuuuyyaaaccccdeeeeegggghiiiijklllnnnooorrrssssttuuuuuuwyzzzsoddliht'
+    ),
+    '[^a-z0-9]+', '-', 'g')), 70), '-'), ''), fallback)
+$$ LANGUAGE SQL IMMUTABLE;
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS slug VARCHAR(80);
+ALTER TABLE shops ADD COLUMN IF NOT EXISTS slug VARCHAR(80);
+
+-- the oldest row keeps a repeated name as is, the others get the start of their id appended
+WITH numbered AS (
+  SELECT id, pg_temp.slugify(name, 'product') AS slug,
+    ROW_NUMBER() OVER (PARTITION BY pg_temp.slugify(name, 'product') ORDER BY created_at, id) AS n
+  FROM products
+)
+UPDATE products p
+SET slug = CASE WHEN n = 1 THEN numbered.slug ELSE numbered.slug || '-' || LEFT(p.id::TEXT, 8) END
+FROM numbered
+WHERE p.id = numbered.id;
+
+WITH numbered AS (
+  SELECT id, pg_temp.slugify(name, 'shop') AS slug,
+    ROW_NUMBER() OVER (PARTITION BY pg_temp.slugify(name, 'shop') ORDER BY created_at, id) AS n
+  FROM shops
+)
+UPDATE shops s
+SET slug = CASE WHEN n = 1 THEN numbered.slug ELSE numbered.slug || '-' || LEFT(s.id::TEXT, 8) END
+FROM numbered
+WHERE s.id = numbered.id;
+
+ALTER TABLE products ALTER COLUMN slug SET NOT NULL;
+ALTER TABLE shops ALTER COLUMN slug SET NOT NULL;
+
+-- pattern ops so the prefix lookups of new slugs can use the index too
+CREATE UNIQUE INDEX IF NOT EXISTS products_slug_key ON products (slug varchar_pattern_ops);
+CREATE UNIQUE INDEX IF NOT EXISTS shops_slug_key ON shops (slug varchar_pattern_ops);
+
+-- the slugs products and shops had before a rename, they redirect to the current one
+CREATE TABLE IF NOT EXISTS product_slug_history (
+  slug VARCHAR(80) PRIMARY KEY,
+  product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS product_slug_history_product_id_idx ON product_slug_history (product_id);
+CREATE INDEX IF NOT EXISTS product_slug_history_slug_pattern_idx ON product_slug_history (slug varchar_pattern_ops);
+
+CREATE TABLE IF NOT EXISTS shop_slug_history (
+  slug VARCHAR(80) PRIMARY KEY,
+  shop_id UUID NOT NULL REFERENCES shops(id) ON DELETE CASCADE,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS shop_slug_history_shop_id_idx ON shop_slug_history (shop_id);
+CREATE INDEX IF NOT EXISTS shop_slug_history_slug_pattern_idx ON shop_slug_history (slug varchar_pattern_ops);
+
+-- feed items link to the product slug
+ALTER TABLE product_feed_items ADD COLUMN IF NOT EXISTS slug VARCHAR(80);
+UPDATE product_feed_items fi SET slug = p.slug FROM products p WHERE p.id = fi.product_id;
+ALTER TABLE product_feed_items ALTER COLUMN slug SET NOT NULL;
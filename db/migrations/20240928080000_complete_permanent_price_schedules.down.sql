@@ -0,0 +1 @@
+-- applied permanent schedules can't be told apart from other completed ones
@@ -0,0 +1,5 @@
+-- a permanent price change is done once applied, it used to stay active and
+-- block every later schedule of the product
+UPDATE product_price_schedules
+SET status = 'completed', updated_at = now()
+WHERE status = 'active' AND ends_at IS NULL;
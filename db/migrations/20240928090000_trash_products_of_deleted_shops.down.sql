@@ -0,0 +1 @@
+-- the trashed products can't be told apart from the ones deleted with their shop
@@ -0,0 +1,6 @@
+-- products now go to the trash with their shop, the ones of shops deleted
+-- before were left live and kept the shops from being purged
+UPDATE products p
+SET deleted_at = s.deleted_at, updated_at = now(), version = p.version + 1
+FROM shops s
+WHERE p.shop_id = s.id AND s.deleted_at IS NOT NULL AND p.deleted_at IS NULL;
@@ -0,0 +1 @@
+-- the reserved slugs stay renamed, they couldn't be fetched anyway
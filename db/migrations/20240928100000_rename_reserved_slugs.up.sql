@@ -0,0 +1,11 @@
+-- slugs equal to a static route segment, e.g. /products/trash, or shaped like
+-- an id can't be fetched, the backfill could have given them out
+UPDATE products
+SET slug = slug || '-' || LEFT(id::TEXT, 8)
+WHERE slug IN ('trash', 'export', 'exports', 'import')
+  OR slug ~ '^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$';
+
+UPDATE shops
+SET slug = slug || '-' || LEFT(id::TEXT, 8)
+WHERE slug IN ('trash', 'export', 'exports', 'import')
+  OR slug ~ '^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$';
@@ -1,7 +1,9 @@
 package seeds
 
 import (
+	"cmp"
 	"codebase-app/internal/adapter"
+	"codebase-app/pkg/slug"
 	"context"
 	"os"
 
@@ -181,10 +183,18 @@ func (s *Seed) brandsSeed() {
 func (s *Seed) shopsSeed() {
 	shopMaps := make([]map[string]interface{}, 5)
 
+	slugs, err := s.takenSlugs("shops")
+	if err != nil {
+		log.Error().Err(err).Msg("Error fetching shop slugs")
+		return
+	}
+
 	for i := 0; i < 5; i++ {
+		name := gofakeit.Company()
 		shopMaps[i] = map[string]interface{}{
 			"user_id":     userIDs[i],
-			"name":        gofakeit.Company(),
+			"name":        name,
+			"slug":        uniqueSlug(&slugs, name, "shop"),
 			"description": gofakeit.Sentence(10),
 			"terms":       gofakeit.Paragraph(2, 2, 10, "\n"),
 		}
@@ -208,8 +218,8 @@ func (s *Seed) shopsSeed() {
 	}()
 
 	_, err = tx.NamedExec(`
-		INSERT INTO shops (user_id, name, description, terms)
-		VALUES (:user_id, :name, :description, :terms)
+		INSERT INTO shops (user_id, name, slug, description, terms)
+		VALUES (:user_id, :name, :slug, :description, :terms)
 	`, shopMaps)
 	if err != nil {
 		log.Error().Err(err).Msg("Error creating shops")
@@ -242,15 +252,23 @@ func (s *Seed) productsSeed() {
 		return
 	}
 
+	slugs, err := s.takenSlugs("products")
+	if err != nil {
+		log.Error().Err(err).Msg("Error fetching product slugs")
+		return
+	}
+
 	productMaps := make([]map[string]interface{}, 0, len(shops)*5)
 
 	for _, shop := range shops {
 		for i := 0; i < 5; i++ {
+			name := gofakeit.ProductName()
 			product := map[string]interface{}{
 				"shop_id":     shop.ID,
 				"category_id": categories[gofakeit.Number(0, len(categories)-1)].ID,
 				"brand_id":    brands[gofakeit.Number(0, len(brands)-1)].ID,
-				"name":        gofakeit.ProductName(),
+				"name":        name,
+				"slug":        uniqueSlug(&slugs, name, "product"),
 				"description": gofakeit.ProductDescription(),
 				"price":       gofakeit.Price(10, 1000),
 				"stock":       gofakeit.Number(0, 100),
@@ -278,8 +296,8 @@ func (s *Seed) productsSeed() {
 	}()
 
 	_, err = tx.NamedExec(`
-		INSERT INTO products (shop_id, category_id, brand_id, name, description, price, stock, user_id)
-		VALUES (:shop_id, :category_id, :brand_id, :name, :description, :price, :stock, :user_id)
+		INSERT INTO products (shop_id, category_id, brand_id, name, slug, description, price, stock, user_id)
+		VALUES (:shop_id, :category_id, :brand_id, :name, :slug, :description, :price, :stock, :user_id)
 	`, productMaps)
 	if err != nil {
 		log.Error().Err(err).Msg("Error creating products")
@@ -341,3 +359,22 @@ func (s *Seed) reviewsSeed(total int) {
 
 	log.Info().Msg("reviews table seeded successfully")
 }
+
+// takenSlugs returns the slugs of table that are in use, current or old.
+func (s *Seed) takenSlugs(table string) ([]string, error) {
+	var slugs []string
+
+	history := map[string]string{"shops": "shop_slug_history", "products": "product_slug_history"}[table]
+	if err := s.db.Select(&slugs, "SELECT slug FROM "+table+" UNION ALL SELECT slug FROM "+history); err != nil {
+		return nil, err
+	}
+
+	return slugs, nil
+}
+
+// uniqueSlug picks a slug for name that isn't taken yet and adds it to taken.
+func uniqueSlug(taken *[]string, name, fallback string) string {
+	s := slug.Unique(cmp.Or(slug.Make(name), fallback), *taken)
+	*taken = append(*taken, s)
+	return s
+}
@@ -31,6 +31,30 @@ type Config struct {
 		MaxIdleCons       int `env:"DB_MAX_IdLE_CONS" env-default:"20" env-description:"database max idle conn in seconds"`
 		ConnMaxLifetime   int `env:"DB_CONN_MAX_LIFETIME" env-default:"0" env-description:"database conn max lifetime in seconds"`
 	}
+	Scheduler struct {
+		Enabled  bool `env:"SCHEDULER_ENABLED" env-default:"true" env-description:"run background jobs in the server process"`
+		Interval int  `env:"SCHEDULER_INTERVAL" env-default:"60" env-description:"background job interval in seconds"`
+	}
+	Trash struct {
+		RetentionDays int `env:"TRASH_RETENTION_DAYS" env-default:"30" env-description:"days soft-deleted products and shops are kept before purge"`
+		PurgeInterval int `env:"TRASH_PURGE_INTERVAL" env-default:"3600" env-description:"trash purge job interval in seconds"`
+	}
+	Reservation struct {
+		Ttl           int `env:"RESERVATION_TTL" env-default:"900" env-description:"default stock reservation ttl in seconds"`
+		SweepInterval int `env:"RESERVATION_SWEEP_INTERVAL" env-default:"60" env-description:"expired reservation sweep interval in seconds"`
+	}
+	Export struct {
+		SyncLimit int `env:"EXPORT_SYNC_LIMIT" env-default:"5000" env-description:"exports with more products run in the background"`
+		Interval  int `env:"EXPORT_INTERVAL" env-default:"30" env-description:"background export job interval in seconds"`
+		UrlTtl    int `env:"EXPORT_URL_TTL" env-default:"3600" env-description:"lifetime of export download urls in seconds"`
+	}
+	Feed struct {
+		Title    string `env:"FEED_TITLE" env-description:"title of the site-wide product feed, APP_NAME when empty"`
+		LinkURL  string `env:"FEED_LINK_URL" env-default:"http://localhost:5000" env-description:"storefront url the feed items link to"`
+		Currency string `env:"FEED_CURRENCY" env-default:"IDR" env-description:"ISO 4217 currency of the feed prices"`
+		Interval int    `env:"FEED_INTERVAL" env-default:"300" env-description:"feed regeneration job interval in seconds"`
+		MaxAge   int    `env:"FEED_MAX_AGE" env-default:"900" env-description:"seconds clients may cache a feed"`
+	}
 	Guard struct {
 		JwtPrivateKey   string `env:"JWT_PRIVATE_KEY"`
 		JwtPrivateKeyWs string `env:"JWT_PRIVATE_KEY_WS"`
@@ -45,6 +69,7 @@ type Config struct {
 		SslMode  string `env:"SHOPEEFUN_POSTGRES_SSL_MODE" env-default:"disable"`
 	}
 	ShopeefunStorage struct {
+		Driver   string `env:"SHOPEEFUN_STORAGE_DRIVER" env-default:"local" env-description:"local or s3"`
 		Key      string `env:"SHOPEEFUN_STORAGE_KEY"`
 		Secret   string `env:"SHOPEEFUN_STORAGE_SECRET"`
 		Endpoint string `env:"SHOPEEFUN_STORAGE_ENDPOINT"`
@@ -1,12 +1,14 @@
 package integration
 
 import (
+	"bytes"
 	"codebase-app/internal/adapter"
 	"codebase-app/internal/infrastructure/config"
 	"codebase-app/internal/integration/digitaloceanspace/entity"
 	"codebase-app/pkg"
 	"codebase-app/pkg/errmsg"
 	"context"
+	"io"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
@@ -17,6 +19,9 @@ import (
 
 type DigitaloceanSpaceContract interface {
 	UploadFile(ctx context.Context, req *entity.UploadFileRequest) (entity.UploadFileResponse, error)
+	PutObject(ctx context.Context, req *entity.PutObjectRequest) (entity.UploadFileResponse, error)
+	UploadObject(ctx context.Context, req *entity.UploadObjectRequest) (entity.UploadFileResponse, error)
+	GetObject(ctx context.Context, req *entity.GetObjectRequest) (io.ReadCloser, error)
 	DeleteFile(ctx context.Context, req *entity.DeleteFileRequest) error
 	ListFiles(ctx context.Context) ([]types.Object, error)
 }
@@ -67,6 +72,57 @@ func (d *dospace) UploadFile(ctx context.Context, req *entity.UploadFileRequest)
 	return res, nil
 }
 
+func (d *dospace) PutObject(ctx context.Context, req *entity.PutObjectRequest) (entity.UploadFileResponse, error) {
+	return d.UploadObject(ctx, &entity.UploadObjectRequest{
+		FileName:    req.FileName,
+		ContentType: req.ContentType,
+		Body:        bytes.NewReader(req.Body),
+		Private:     req.Private,
+	})
+}
+
+func (d *dospace) UploadObject(ctx context.Context, req *entity.UploadObjectRequest) (entity.UploadFileResponse, error) {
+	var (
+		res      = entity.UploadFileResponse{}
+		uploader = manager.NewUploader(d.storage)
+		acl      = types.ObjectCannedACLPublicRead
+	)
+
+	if req.Private {
+		acl = types.ObjectCannedACLPrivate
+	}
+
+	result, err := uploader.Upload(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(config.Envs.ShopeefunStorage.Bucket),
+		Key:         aws.String(req.FileName),
+		Body:        req.Body,
+		ContentType: aws.String(req.ContentType),
+		ACL:         acl,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("filename", req.FileName).Msg("integration::dospace-UploadObject Error while uploading object")
+		return res, err
+	}
+
+	res.FileName = req.FileName
+	res.Url = result.Location
+
+	return res, nil
+}
+
+func (d *dospace) GetObject(ctx context.Context, req *entity.GetObjectRequest) (io.ReadCloser, error) {
+	result, err := d.storage.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(config.Envs.ShopeefunStorage.Bucket),
+		Key:    aws.String(req.FileName),
+	})
+	if err != nil {
+		log.Error().Err(err).Str("filename", req.FileName).Msg("integration::dospace-GetObject Error while getting object")
+		return nil, err
+	}
+
+	return result.Body, nil
+}
+
 func (d *dospace) DeleteFile(ctx context.Context, req *entity.DeleteFileRequest) error {
 	_, err := d.storage.DeleteObject(ctx, &s3.DeleteObjectInput{
 		Bucket: aws.String(config.Envs.ShopeefunStorage.Bucket),
@@ -1,6 +1,9 @@
 package entity
 
-import "mime/multipart"
+import (
+	"io"
+	"mime/multipart"
+)
 
 type XxxRequest struct {
 }
@@ -17,6 +20,25 @@ type UploadFileResponse struct {
 	Url      string `json:"url" validate:"required"`
 }
 
+type PutObjectRequest struct {
+	FileName    string `json:"filename" validate:"required"`
+	ContentType string `json:"content_type" validate:"required"`
+	Body        []byte `json:"-" validate:"required"`
+	Private     bool   `json:"private"`
+}
+
+// UploadObjectRequest is PutObjectRequest for content too large to hold in memory.
+type UploadObjectRequest struct {
+	FileName    string    `json:"filename" validate:"required"`
+	ContentType string    `json:"content_type" validate:"required"`
+	Body        io.Reader `json:"-" validate:"required"`
+	Private     bool      `json:"private"`
+}
+
+type GetObjectRequest struct {
+	FileName string `json:"filename" validate:"required"`
+}
+
 type DeleteFileRequest struct {
 	FileName string `json:"filename" validate:"required"`
 }
@@ -0,0 +1,29 @@
+package entity
+
+import "io"
+
+type PutFileRequest struct {
+	Data []byte `json:"-" validate:"required"`
+	Dir  string `json:"dir" validate:"required"`
+}
+
+type PutFileResponse struct {
+	FileName string `json:"filename"`
+	Url      string `json:"url"`
+}
+
+type DeleteFileRequest struct {
+	FileName string `json:"filename" validate:"required"`
+}
+
+// PutPrivateFileRequest stores a file reachable through signed urls only,
+// FileName is the path of the file inside the private storage.
+type PutPrivateFileRequest struct {
+	FileName    string    `json:"filename" validate:"required"`
+	ContentType string    `json:"content_type" validate:"required"`
+	Body        io.Reader `json:"-" validate:"required"`
+}
+
+type GetPrivateFileRequest struct {
+	FileName string `json:"filename" validate:"required"`
+}
@@ -0,0 +1,181 @@
+package integration
+
+import (
+	"codebase-app/internal/infrastructure/config"
+	dospace "codebase-app/internal/integration/digitaloceanspace"
+	dospaceEntity "codebase-app/internal/integration/digitaloceanspace/entity"
+	"codebase-app/internal/integration/filestorage/entity"
+	localstorage "codebase-app/internal/integration/localstorage"
+	"codebase-app/pkg/errmsg"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/oklog/ulid/v2"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+// LocalPublicRoute is where the rest server exposes the local public storage.
+const LocalPublicRoute = "/products/storage/public"
+
+var (
+	ErrImageTypeNotSupported = errmsg.NewCustomErrors(400, errmsg.WithErrors("file", "tipe file harus jpeg, png, atau webp."))
+	ErrFileNotFound          = errmsg.NewCustomErrors(404, errmsg.WithMessage("File not found"))
+)
+
+// FileStorageContract stores public images and private files on the storage
+// backend selected by SHOPEEFUN_STORAGE_DRIVER: the local disk or an S3
+// compatible bucket. Private files are handed out with storage.GenerateSignedURL.
+type FileStorageContract interface {
+	Put(ctx context.Context, req *entity.PutFileRequest) (entity.PutFileResponse, error)
+	Delete(ctx context.Context, req *entity.DeleteFileRequest) error
+	PutPrivate(ctx context.Context, req *entity.PutPrivateFileRequest) (entity.PutFileResponse, error)
+	GetPrivate(ctx context.Context, req *entity.GetPrivateFileRequest) (io.ReadCloser, error)
+}
+
+// privateName keeps a private file name inside the private storage.
+func privateName(filename string) string {
+	return strings.TrimPrefix(path.Clean("/"+filename), "/")
+}
+
+func NewFileStorageIntegration() FileStorageContract {
+	if config.Envs.ShopeefunStorage.Driver == DriverS3 {
+		return &s3storage{storage: dospace.NewDigitalOceanSpaceIntegration()}
+	}
+
+	return &diskstorage{storage: localstorage.NewLocalStorageIntegration()}
+}
+
+type diskstorage struct {
+	storage localstorage.LocalStorageContract
+}
+
+func (d *diskstorage) Put(ctx context.Context, req *entity.PutFileRequest) (entity.PutFileResponse, error) {
+	var (
+		res  = entity.PutFileResponse{}
+		root = config.Envs.App.LocalStoragePublicPath
+	)
+
+	fullpath, err := d.storage.Write(req.Data, path.Join(root, req.Dir))
+	if err != nil {
+		if errors.Is(err, localstorage.ErrFileTypeNotSupported) {
+			return res, ErrImageTypeNotSupported
+		}
+		log.Error().Err(err).Str("dir", req.Dir).Msg("integration::filestorage-Put Error while writing file")
+		return res, err
+	}
+
+	res.FileName = path.Join(req.Dir, path.Base(fullpath))
+	res.Url = strings.TrimRight(config.Envs.App.BaseURL, "/") + LocalPublicRoute + "/" + res.FileName
+
+	return res, nil
+}
+
+func (d *diskstorage) Delete(ctx context.Context, req *entity.DeleteFileRequest) error {
+	return d.storage.Delete(path.Join(config.Envs.App.LocalStoragePublicPath, req.FileName))
+}
+
+func (d *diskstorage) PutPrivate(ctx context.Context, req *entity.PutPrivateFileRequest) (entity.PutFileResponse, error) {
+	var res = entity.PutFileResponse{FileName: privateName(req.FileName)}
+
+	if err := d.storage.Create(path.Join(config.Envs.App.LocalStoragePrivatePath, res.FileName), req.Body); err != nil {
+		log.Error().Err(err).Str("filename", res.FileName).Msg("integration::filestorage-PutPrivate Error while writing file")
+		return res, err
+	}
+
+	return res, nil
+}
+
+func (d *diskstorage) GetPrivate(ctx context.Context, req *entity.GetPrivateFileRequest) (io.ReadCloser, error) {
+	file, err := d.storage.Open(path.Join(config.Envs.App.LocalStoragePrivatePath, privateName(req.FileName)))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrFileNotFound
+		}
+		log.Error().Err(err).Str("filename", req.FileName).Msg("integration::filestorage-GetPrivate Error while opening file")
+		return nil, err
+	}
+
+	return file, nil
+}
+
+type s3storage struct {
+	storage dospace.DigitaloceanSpaceContract
+}
+
+func (s *s3storage) Put(ctx context.Context, req *entity.PutFileRequest) (entity.PutFileResponse, error) {
+	var res = entity.PutFileResponse{}
+
+	contentType := http.DetectContentType(req.Data)
+	ext, ok := imageExtensions[contentType]
+	if !ok {
+		log.Warn().Str("content_type", contentType).Msg("integration::filestorage-Put Image type not supported")
+		return res, ErrImageTypeNotSupported
+	}
+
+	result, err := s.storage.PutObject(ctx, &dospaceEntity.PutObjectRequest{
+		FileName:    path.Join(req.Dir, ulid.Make().String()+"."+ext),
+		ContentType: contentType,
+		Body:        req.Data,
+	})
+	if err != nil {
+		return res, err
+	}
+
+	res.FileName = result.FileName
+	res.Url = result.Url
+
+	return res, nil
+}
+
+func (s *s3storage) Delete(ctx context.Context, req *entity.DeleteFileRequest) error {
+	return s.storage.DeleteFile(ctx, &dospaceEntity.DeleteFileRequest{FileName: req.FileName})
+}
+
+// private files live under their own prefix of the bucket
+const s3PrivatePrefix = "private"
+
+func (s *s3storage) PutPrivate(ctx context.Context, req *entity.PutPrivateFileRequest) (entity.PutFileResponse, error) {
+	var res = entity.PutFileResponse{FileName: privateName(req.FileName)}
+
+	_, err := s.storage.UploadObject(ctx, &dospaceEntity.UploadObjectRequest{
+		FileName:    path.Join(s3PrivatePrefix, res.FileName),
+		ContentType: req.ContentType,
+		Body:        req.Body,
+		Private:     true,
+	})
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+func (s *s3storage) GetPrivate(ctx context.Context, req *entity.GetPrivateFileRequest) (io.ReadCloser, error) {
+	body, err := s.storage.GetObject(ctx, &dospaceEntity.GetObjectRequest{FileName: path.Join(s3PrivatePrefix, privateName(req.FileName))})
+	if err != nil {
+		var noSuchKey *s3types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, ErrFileNotFound
+		}
+		return nil, err
+	}
+
+	return body, nil
+}
+
+var imageExtensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/webp": "webp",
+}
@@ -4,8 +4,10 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 
@@ -15,6 +17,11 @@ import (
 
 type LocalStorageContract interface {
 	Save(base64String, path string) (fullpath string, err error)
+	Write(data []byte, path string) (fullpath string, err error)
+	Delete(fullpath string) error
+	// Create writes any content to fullpath, unlike Write it keeps the given name.
+	Create(fullpath string, r io.Reader) error
+	Open(fullpath string) (io.ReadCloser, error)
 }
 
 var (
@@ -31,9 +38,6 @@ func NewLocalStorageIntegration() LocalStorageContract {
 }
 
 func (l *localstorage) Save(base64String, path string) (fullpath string, err error) {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-
 	// Trim base64 prefix if present (e.g., "data:image/png;base64,")
 	if idx := strings.Index(base64String, ","); idx != -1 {
 		base64String = base64String[idx+1:]
@@ -46,6 +50,13 @@ func (l *localstorage) Save(base64String, path string) (fullpath string, err err
 		return "", fmt.Errorf("localstorage: %w", err)
 	}
 
+	return l.Write(fileContent, path)
+}
+
+func (l *localstorage) Write(fileContent []byte, path string) (fullpath string, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
 	// Get MIME type of the file
 	mimeType := l.getMimeType(fileContent)
 	if !l.isAcceptableMimeType(mimeType) {
@@ -66,6 +77,48 @@ func (l *localstorage) Save(base64String, path string) (fullpath string, err err
 	return fullpath, nil
 }
 
+func (l *localstorage) Delete(fullpath string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.Remove(fullpath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Error().Err(err).Msg("localstorage: failed to delete file")
+		return fmt.Errorf("localstorage: %w", err)
+	}
+
+	return nil
+}
+
+func (l *localstorage) Create(fullpath string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(fullpath), os.ModePerm); err != nil {
+		log.Error().Err(err).Msg("localstorage: failed to create directory")
+		return fmt.Errorf("localstorage: %w", err)
+	}
+
+	file, err := os.Create(fullpath)
+	if err != nil {
+		log.Error().Err(err).Msg("localstorage: failed to create file")
+		return fmt.Errorf("localstorage: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, r); err != nil {
+		log.Error().Err(err).Msg("localstorage: failed to write data to file")
+		return fmt.Errorf("localstorage: %w", err)
+	}
+
+	return nil
+}
+
+func (l *localstorage) Open(fullpath string) (io.ReadCloser, error) {
+	file, err := os.Open(fullpath)
+	if err != nil {
+		return nil, fmt.Errorf("localstorage: %w", err)
+	}
+
+	return file, nil
+}
+
 func (l *localstorage) saveFile(fullpath string, data []byte) error {
 	path := strings.Split(fullpath, "/")         // Split path by "/"
 	dir := strings.Join(path[:len(path)-1], "/") // Join path except the last element
@@ -103,6 +156,8 @@ func (l *localstorage) extensionFromMimeType(mimeType string) string {
 		return "jpg"
 	case "image/png":
 		return "png"
+	case "image/webp":
+		return "webp"
 	default:
 		return ""
 	}
@@ -110,7 +165,7 @@ func (l *localstorage) extensionFromMimeType(mimeType string) string {
 
 func (l *localstorage) isAcceptableMimeType(mimeType string) bool {
 	switch mimeType {
-	case "image/jpeg", "image/png":
+	case "image/jpeg", "image/png", "image/webp":
 		return true
 	default:
 		return false
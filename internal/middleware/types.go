@@ -5,6 +5,9 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// RoleAdmin is the role allowed to manage shared catalog data.
+const RoleAdmin = "admin"
+
 type Locals struct {
 	UserId string
 	Role   string
@@ -19,6 +22,10 @@ func GetLocals(c *fiber.Ctx) *Locals {
 		log.Warn().Msg("middleware::Locals-GetLocals failed to get user_id from locals")
 	}
 
+	if role, ok := c.Locals("role").(string); ok {
+		l.Role = role
+	}
+
 	return &l
 }
 
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"codebase-app/pkg/types"
+	"time"
+)
+
+type BrandItem struct {
+	Id           string    `json:"id" db:"id"`
+	Name         string    `json:"name" db:"name"`
+	ProductCount int       `json:"product_count" db:"product_count"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type BrandsRequest struct {
+	Page     int    `query:"page" validate:"required"`
+	Paginate int    `query:"paginate" validate:"required"`
+	Search   string `query:"search" validate:"omitempty,max=255"`
+}
+
+func (r *BrandsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type BrandsResponse struct {
+	Items []BrandItem `json:"items"`
+	Meta  types.Meta  `json:"meta"`
+}
+
+type CreateBrandRequest struct {
+	Name string `json:"name" validate:"required,max=255" db:"name"`
+}
+
+type UpdateBrandRequest struct {
+	Id   string `params:"id" validate:"uuid" db:"id"`
+	Name string `json:"name" validate:"required,max=255" db:"name"`
+}
+
+type DeleteBrandRequest struct {
+	Id string `params:"id" validate:"uuid" db:"id"`
+}
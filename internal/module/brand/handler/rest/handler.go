@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"codebase-app/internal/adapter"
+	"codebase-app/internal/middleware"
+	"codebase-app/internal/module/brand/entity"
+	"codebase-app/internal/module/brand/ports"
+	"codebase-app/internal/module/brand/repository"
+	"codebase-app/internal/module/brand/service"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+type brandHandler struct {
+	service ports.BrandService
+}
+
+func NewBrandHandler() *brandHandler {
+	var (
+		handler = new(brandHandler)
+		repo    = repository.NewBrandRepository(adapter.Adapters.ShopeefunPostgres)
+		service = service.NewBrandService(repo)
+	)
+	handler.service = service
+
+	return handler
+}
+
+func (h *brandHandler) Register(router fiber.Router) {
+	var admin = middleware.AuthRole([]string{middleware.RoleAdmin})
+
+	router.Get("/brands", h.GetBrands)
+	router.Post("/brands", middleware.AuthBearer, admin, h.CreateBrand)
+	router.Patch("/brands/:id", middleware.AuthBearer, admin, h.UpdateBrand)
+	router.Delete("/brands/:id", middleware.AuthBearer, admin, h.DeleteBrand)
+}
+
+func (h *brandHandler) GetBrands(c *fiber.Ctx) error {
+	var (
+		req = new(entity.BrandsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetBrands - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.SetDefault()
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetBrands - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetBrands(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *brandHandler) CreateBrand(c *fiber.Ctx) error {
+	var (
+		req = new(entity.CreateBrandRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::CreateBrand - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::CreateBrand - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.CreateBrand(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Success(resp, ""))
+}
+
+func (h *brandHandler) UpdateBrand(c *fiber.Ctx) error {
+	var (
+		req = new(entity.UpdateBrandRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::UpdateBrand - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::UpdateBrand - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.UpdateBrand(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *brandHandler) DeleteBrand(c *fiber.Ctx) error {
+	var (
+		req = new(entity.DeleteBrandRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::DeleteBrand - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err := h.service.DeleteBrand(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, ""))
+}
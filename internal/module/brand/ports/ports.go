@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"codebase-app/internal/module/brand/entity"
+	"context"
+)
+
+type BrandRepository interface {
+	GetBrands(ctx context.Context, req *entity.BrandsRequest) (*entity.BrandsResponse, error)
+	CreateBrand(ctx context.Context, req *entity.CreateBrandRequest) (*entity.BrandItem, error)
+	UpdateBrand(ctx context.Context, req *entity.UpdateBrandRequest) (*entity.BrandItem, error)
+	DeleteBrand(ctx context.Context, req *entity.DeleteBrandRequest) error
+}
+
+type BrandService interface {
+	GetBrands(ctx context.Context, req *entity.BrandsRequest) (*entity.BrandsResponse, error)
+	CreateBrand(ctx context.Context, req *entity.CreateBrandRequest) (*entity.BrandItem, error)
+	UpdateBrand(ctx context.Context, req *entity.UpdateBrandRequest) (*entity.BrandItem, error)
+	DeleteBrand(ctx context.Context, req *entity.DeleteBrandRequest) error
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"codebase-app/internal/module/brand/entity"
+	"codebase-app/internal/module/brand/ports"
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.BrandRepository = &brandRepository{}
+
+type brandRepository struct {
+	db *sqlx.DB
+}
+
+func NewBrandRepository(db *sqlx.DB) *brandRepository {
+	return &brandRepository{
+		db: db,
+	}
+}
+
+// brandProductCountExpr counts the live products of brand b.
+const brandProductCountExpr = "(SELECT COUNT(p.id) FROM products p WHERE p.brand_id = b.id AND p.deleted_at IS NULL)"
+
+func (r *brandRepository) GetBrands(ctx context.Context, req *entity.BrandsRequest) (*entity.BrandsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.BrandItem
+	}
+
+	var (
+		resp = new(entity.BrandsResponse)
+		data = make([]dao, 0, req.Paginate)
+		args = make([]any, 0, 3)
+	)
+	resp.Items = make([]entity.BrandItem, 0, req.Paginate)
+
+	query := `
+		SELECT
+			COUNT(b.id) OVER() as total_data,
+			b.id,
+			b.name,
+			` + brandProductCountExpr + ` AS product_count,
+			b.created_at,
+			b.updated_at
+		FROM brands b
+		WHERE 1 = 1
+	`
+
+	if req.Search != "" {
+		query += " AND b.name ILIKE ?"
+		args = append(args, "%"+req.Search+"%")
+	}
+
+	query += " ORDER BY b.name ASC, b.id ASC LIMIT ? OFFSET ?"
+	args = append(args, req.Paginate, req.Paginate*(req.Page-1))
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query), args...)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetBrands - Failed to get brands")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.BrandItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+func (r *brandRepository) CreateBrand(ctx context.Context, req *entity.CreateBrandRequest) (*entity.BrandItem, error) {
+	var resp = new(entity.BrandItem)
+
+	query := `
+		INSERT INTO brands (name)
+		VALUES (?)
+		RETURNING id, name, 0 AS product_count, created_at, updated_at
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.Name).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateBrand - Failed to create brand")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *brandRepository) UpdateBrand(ctx context.Context, req *entity.UpdateBrandRequest) (*entity.BrandItem, error) {
+	var resp = new(entity.BrandItem)
+
+	query := `
+		UPDATE brands b
+		SET name = ?, updated_at = NOW()
+		WHERE b.id = ?
+		RETURNING b.id, b.name, ` + brandProductCountExpr + ` AS product_count, b.created_at, b.updated_at
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.Name, req.Id).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateBrand - Failed to update brand")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *brandRepository) DeleteBrand(ctx context.Context, req *entity.DeleteBrandRequest) error {
+	var id string
+
+	query := `
+		DELETE FROM brands
+		WHERE id = ?
+		RETURNING id
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.Id).Scan(&id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteBrand - Failed to delete brand")
+		return err
+	}
+
+	return nil
+}
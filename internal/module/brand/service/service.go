@@ -0,0 +1,62 @@
+package service
+
+import (
+	"codebase-app/internal/module/brand/entity"
+	"codebase-app/internal/module/brand/ports"
+	"codebase-app/pkg/errmsg"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+var _ ports.BrandService = &brandService{}
+
+type brandService struct {
+	repo ports.BrandRepository
+}
+
+func NewBrandService(repo ports.BrandRepository) *brandService {
+	return &brandService{
+		repo: repo,
+	}
+}
+
+func (s *brandService) GetBrands(ctx context.Context, req *entity.BrandsRequest) (*entity.BrandsResponse, error) {
+	return s.repo.GetBrands(ctx, req)
+}
+
+func (s *brandService) CreateBrand(ctx context.Context, req *entity.CreateBrandRequest) (*entity.BrandItem, error) {
+	return s.repo.CreateBrand(ctx, req)
+}
+
+func (s *brandService) UpdateBrand(ctx context.Context, req *entity.UpdateBrandRequest) (*entity.BrandItem, error) {
+	res, err := s.repo.UpdateBrand(ctx, req)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Brand not found"))
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *brandService) DeleteBrand(ctx context.Context, req *entity.DeleteBrandRequest) error {
+	err := s.repo.DeleteBrand(ctx, req)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errmsg.NewCustomErrors(404, errmsg.WithMessage("Brand not found"))
+		}
+
+		// products reference brands with ON DELETE RESTRICT
+		var errPq *pq.Error
+		if errors.As(err, &errPq) && errPq.Code.Name() == "foreign_key_violation" {
+			return errmsg.NewCustomErrors(409, errmsg.WithMessage("Brand is still used by products"))
+		}
+		return err
+	}
+
+	return nil
+}
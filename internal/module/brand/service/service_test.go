@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"codebase-app/internal/module/brand/entity"
+	"codebase-app/internal/module/brand/ports"
+	mockPort "codebase-app/mock/module/brand/ports"
+	"codebase-app/pkg/errmsg"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServiceList struct {
+	suite.Suite
+	mockBrandRepo *mockPort.MockBrandRepo
+	service       ports.BrandService
+}
+
+func (suite *ServiceList) SetupTest() {
+	suite.mockBrandRepo = new(mockPort.MockBrandRepo)
+	suite.service = NewBrandService(suite.mockBrandRepo)
+}
+
+func (suite *ServiceList) TestUpdateBrand_NotFound() {
+	ctx := context.Background()
+	req := &entity.UpdateBrandRequest{Id: "1", Name: "Acme"}
+	errNotFound := errmsg.NewCustomErrors(404, errmsg.WithMessage("Brand not found"))
+
+	suite.mockBrandRepo.On("UpdateBrand", ctx, req).Return(nil, sql.ErrNoRows)
+	_, err := suite.service.UpdateBrand(ctx, req)
+
+	suite.Equal(errNotFound, err)
+}
+
+func (suite *ServiceList) TestDeleteBrand_Success() {
+	ctx := context.Background()
+	req := &entity.DeleteBrandRequest{Id: "1"}
+
+	suite.mockBrandRepo.On("DeleteBrand", ctx, req).Return(nil)
+	err := suite.service.DeleteBrand(ctx, req)
+
+	suite.Equal(nil, err)
+}
+
+func (suite *ServiceList) TestDeleteBrand_StillReferenced() {
+	ctx := context.Background()
+	req := &entity.DeleteBrandRequest{Id: "1"}
+	errConflict := errmsg.NewCustomErrors(409, errmsg.WithMessage("Brand is still used by products"))
+
+	suite.mockBrandRepo.On("DeleteBrand", ctx, req).Return(&pq.Error{Code: "23503"})
+	err := suite.service.DeleteBrand(ctx, req)
+
+	suite.Equal(errConflict, err)
+}
+
+func TestService(t *testing.T) {
+	suite.Run(t, new(ServiceList))
+}
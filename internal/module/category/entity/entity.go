@@ -0,0 +1,108 @@
+package entity
+
+import (
+	"codebase-app/pkg/types"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type CategoryItem struct {
+	Id           string    `json:"id" db:"id"`
+	Name         string    `json:"name" db:"name"`
+	ParentId     *string   `json:"parent_id" db:"parent_id"`
+	ProductCount int       `json:"product_count" db:"product_count"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type CategoriesRequest struct {
+	Page     int    `query:"page" validate:"required"`
+	Paginate int    `query:"paginate" validate:"required"`
+	Search   string `query:"search" validate:"omitempty,max=255"`
+}
+
+func (r *CategoriesRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type CategoriesResponse struct {
+	Items []CategoryItem `json:"items"`
+	Meta  types.Meta     `json:"meta"`
+}
+
+// CategoryNode is a category of the tree with its subcategories,
+// TotalProductCount includes the products of every descendant.
+type CategoryNode struct {
+	CategoryItem
+	TotalProductCount int             `json:"total_product_count"`
+	Children          []*CategoryNode `json:"children"`
+}
+
+type CategoryTreeResponse struct {
+	Items []*CategoryNode `json:"items"`
+}
+
+type CreateCategoryRequest struct {
+	Name     string  `json:"name" validate:"required,max=255" db:"name"`
+	ParentId *string `json:"parent_id" validate:"omitempty,uuid" db:"parent_id"`
+}
+
+type UpdateCategoryRequest struct {
+	Id       string  `params:"id" validate:"uuid" db:"id"`
+	Name     string  `json:"name" validate:"required,max=255" db:"name"`
+	ParentId *string `json:"parent_id" validate:"omitempty,uuid" db:"parent_id"` // an explicit null moves the category to the root
+
+	Fields types.PatchFields `json:"-" validate:"-"` // keys the body carries, parent_id is kept when absent
+}
+
+type DeleteCategoryRequest struct {
+	Id string `params:"id" validate:"uuid" db:"id"`
+}
+
+const (
+	AttributeTypeString  = "string"
+	AttributeTypeNumber  = "number"
+	AttributeTypeEnum    = "enum"
+	AttributeTypeBoolean = "boolean"
+)
+
+// AttributeItem is a typed product attribute of a category schema,
+// subcategories inherit the attributes of their ancestors.
+type AttributeItem struct {
+	Id         string         `json:"id" db:"id"`
+	CategoryId string         `json:"category_id" db:"category_id"`
+	Key        string         `json:"key" db:"key"`
+	Label      string         `json:"label" db:"label"`
+	Type       string         `json:"type" db:"type"`
+	Options    pq.StringArray `json:"options" db:"options"`
+	IsRequired bool           `json:"is_required" db:"is_required"`
+}
+
+type AttributesRequest struct {
+	CategoryId string `params:"id" validate:"uuid"`
+}
+
+type AttributesResponse struct {
+	Items []AttributeItem `json:"items"`
+}
+
+type SetAttributesRequest struct {
+	CategoryId string `params:"id" validate:"uuid"`
+
+	Attributes []AttributeRequest `json:"attributes" validate:"omitempty,dive"`
+}
+
+type AttributeRequest struct {
+	Key        string   `json:"key" validate:"required,max=64" db:"key"`
+	Label      string   `json:"label" validate:"required,max=255" db:"label"`
+	Type       string   `json:"type" validate:"required,oneof=string number enum boolean" db:"type"`
+	Options    []string `json:"options" validate:"required_if=Type enum,omitempty,unique_in_slice,dive,required" db:"options"`
+	IsRequired bool     `json:"is_required" db:"is_required"`
+}
@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"codebase-app/internal/adapter"
+	"codebase-app/internal/middleware"
+	"codebase-app/internal/module/category/entity"
+	"codebase-app/internal/module/category/ports"
+	"codebase-app/internal/module/category/repository"
+	"codebase-app/internal/module/category/service"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/response"
+	"codebase-app/pkg/types"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+type categoryHandler struct {
+	service ports.CategoryService
+}
+
+func NewCategoryHandler() *categoryHandler {
+	var (
+		handler = new(categoryHandler)
+		repo    = repository.NewCategoryRepository(adapter.Adapters.ShopeefunPostgres)
+		service = service.NewCategoryService(repo)
+	)
+	handler.service = service
+
+	return handler
+}
+
+func (h *categoryHandler) Register(router fiber.Router) {
+	var admin = middleware.AuthRole([]string{middleware.RoleAdmin})
+
+	router.Get("/categories", h.GetCategories)
+	router.Get("/categories/tree", h.GetCategoryTree)
+	router.Post("/categories", middleware.AuthBearer, admin, h.CreateCategory)
+	router.Patch("/categories/:id", middleware.AuthBearer, admin, h.UpdateCategory)
+	router.Delete("/categories/:id", middleware.AuthBearer, admin, h.DeleteCategory)
+	router.Get("/categories/:id/attributes", h.GetAttributes)
+	router.Put("/categories/:id/attributes", middleware.AuthBearer, admin, h.SetAttributes)
+}
+
+func (h *categoryHandler) GetCategories(c *fiber.Ctx) error {
+	var (
+		req = new(entity.CategoriesRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetCategories - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.SetDefault()
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetCategories - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetCategories(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *categoryHandler) GetCategoryTree(c *fiber.Ctx) error {
+	resp, err := h.service.GetCategoryTree(c.Context())
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *categoryHandler) CreateCategory(c *fiber.Ctx) error {
+	var (
+		req = new(entity.CreateCategoryRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::CreateCategory - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::CreateCategory - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.CreateCategory(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Success(resp, ""))
+}
+
+func (h *categoryHandler) UpdateCategory(c *fiber.Ctx) error {
+	var (
+		req = new(entity.UpdateCategoryRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		err error
+	)
+
+	req.Fields, err = types.ParsePatchFields(c.Body())
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::UpdateCategory - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::UpdateCategory - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::UpdateCategory - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.UpdateCategory(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *categoryHandler) DeleteCategory(c *fiber.Ctx) error {
+	var (
+		req = new(entity.DeleteCategoryRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::DeleteCategory - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err := h.service.DeleteCategory(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, ""))
+}
+
+func (h *categoryHandler) GetAttributes(c *fiber.Ctx) error {
+	var (
+		req = new(entity.AttributesRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+	req.CategoryId = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetAttributes - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetAttributes(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *categoryHandler) SetAttributes(c *fiber.Ctx) error {
+	var (
+		req = new(entity.SetAttributesRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::SetAttributes - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.CategoryId = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::SetAttributes - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.SetAttributes(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
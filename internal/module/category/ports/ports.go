@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"codebase-app/internal/module/category/entity"
+	"context"
+)
+
+type CategoryRepository interface {
+	GetCategories(ctx context.Context, req *entity.CategoriesRequest) (*entity.CategoriesResponse, error)
+	// GetAllCategories returns every category ordered by name, used to build the tree.
+	GetAllCategories(ctx context.Context) ([]entity.CategoryItem, error)
+	CreateCategory(ctx context.Context, req *entity.CreateCategoryRequest) (*entity.CategoryItem, error)
+	UpdateCategory(ctx context.Context, req *entity.UpdateCategoryRequest) (*entity.CategoryItem, error)
+	DeleteCategory(ctx context.Context, req *entity.DeleteCategoryRequest) error
+
+	GetAttributes(ctx context.Context, req *entity.AttributesRequest) (*entity.AttributesResponse, error)
+	SetAttributes(ctx context.Context, req *entity.SetAttributesRequest) (*entity.AttributesResponse, error)
+}
+
+type CategoryService interface {
+	GetCategories(ctx context.Context, req *entity.CategoriesRequest) (*entity.CategoriesResponse, error)
+	GetCategoryTree(ctx context.Context) (*entity.CategoryTreeResponse, error)
+	CreateCategory(ctx context.Context, req *entity.CreateCategoryRequest) (*entity.CategoryItem, error)
+	UpdateCategory(ctx context.Context, req *entity.UpdateCategoryRequest) (*entity.CategoryItem, error)
+	DeleteCategory(ctx context.Context, req *entity.DeleteCategoryRequest) error
+
+	GetAttributes(ctx context.Context, req *entity.AttributesRequest) (*entity.AttributesResponse, error)
+	SetAttributes(ctx context.Context, req *entity.SetAttributesRequest) (*entity.AttributesResponse, error)
+}
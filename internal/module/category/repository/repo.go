@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"codebase-app/internal/module/category/entity"
+	"codebase-app/internal/module/category/ports"
+	"codebase-app/pkg/errmsg"
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.CategoryRepository = &categoryRepository{}
+
+type categoryRepository struct {
+	db *sqlx.DB
+}
+
+func NewCategoryRepository(db *sqlx.DB) *categoryRepository {
+	return &categoryRepository{
+		db: db,
+	}
+}
+
+// categoryProductCountExpr counts the live products of category c.
+const categoryProductCountExpr = "(SELECT COUNT(p.id) FROM products p WHERE p.category_id = c.id AND p.deleted_at IS NULL)"
+
+func (r *categoryRepository) GetCategories(ctx context.Context, req *entity.CategoriesRequest) (*entity.CategoriesResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.CategoryItem
+	}
+
+	var (
+		resp = new(entity.CategoriesResponse)
+		data = make([]dao, 0, req.Paginate)
+		args = make([]any, 0, 3)
+	)
+	resp.Items = make([]entity.CategoryItem, 0, req.Paginate)
+
+	query := `
+		SELECT
+			COUNT(c.id) OVER() as total_data,
+			c.id,
+			c.name,
+			c.parent_id,
+			` + categoryProductCountExpr + ` AS product_count,
+			c.created_at,
+			c.updated_at
+		FROM categories c
+		WHERE 1 = 1
+	`
+
+	if req.Search != "" {
+		query += " AND c.name ILIKE ?"
+		args = append(args, "%"+req.Search+"%")
+	}
+
+	query += " ORDER BY c.name ASC, c.id ASC LIMIT ? OFFSET ?"
+	args = append(args, req.Paginate, req.Paginate*(req.Page-1))
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query), args...)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetCategories - Failed to get categories")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.CategoryItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+func (r *categoryRepository) GetAllCategories(ctx context.Context) ([]entity.CategoryItem, error) {
+	var resp = make([]entity.CategoryItem, 0)
+
+	query := `
+		SELECT
+			c.id,
+			c.name,
+			c.parent_id,
+			` + categoryProductCountExpr + ` AS product_count,
+			c.created_at,
+			c.updated_at
+		FROM categories c
+		ORDER BY c.name ASC, c.id ASC
+	`
+
+	err := r.db.SelectContext(ctx, &resp, query)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::GetAllCategories - Failed to get categories")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *categoryRepository) CreateCategory(ctx context.Context, req *entity.CreateCategoryRequest) (*entity.CategoryItem, error) {
+	var resp = new(entity.CategoryItem)
+
+	query := `
+		INSERT INTO categories (name, parent_id)
+		VALUES (?, ?)
+		RETURNING id, name, parent_id, 0 AS product_count, created_at, updated_at
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.Name, req.ParentId).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateCategory - Failed to create category")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *categoryRepository) UpdateCategory(ctx context.Context, req *entity.UpdateCategoryRequest) (*entity.CategoryItem, error) {
+	var resp = new(entity.CategoryItem)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateCategory - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if req.ParentId != nil {
+		// concurrent moves could otherwise build a cycle between two checks
+		if _, err := tx.ExecContext(ctx, "LOCK TABLE categories IN SHARE ROW EXCLUSIVE MODE"); err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::UpdateCategory - Failed to lock categories")
+			return nil, err
+		}
+
+		var isCycle bool
+
+		query := `
+			WITH RECURSIVE subtree AS (
+				SELECT id FROM categories WHERE id = ?
+				UNION
+				SELECT c.id FROM categories c INNER JOIN subtree s ON c.parent_id = s.id
+			)
+			SELECT EXISTS (SELECT 1 FROM subtree WHERE id = ?)
+		`
+
+		err = tx.GetContext(ctx, &isCycle, tx.Rebind(query), req.Id, *req.ParentId)
+		if err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::UpdateCategory - Failed to check category cycle")
+			return nil, err
+		}
+
+		if isCycle {
+			log.Warn().Any("payload", req).Msg("repository::UpdateCategory - Parent is the category or one of its descendants")
+			return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("parent_id", "parent id tidak boleh kategori itu sendiri atau turunannya."))
+		}
+	}
+
+	// the parent only changes when the body carries parent_id
+	query := `
+		UPDATE categories c
+		SET
+			name = ?,
+			parent_id = CASE WHEN ? THEN ?::UUID ELSE c.parent_id END,
+			updated_at = NOW()
+		WHERE c.id = ?
+		RETURNING c.id, c.name, c.parent_id, ` + categoryProductCountExpr + ` AS product_count, c.created_at, c.updated_at
+	`
+
+	err = tx.QueryRowxContext(ctx, tx.Rebind(query), req.Name, req.Fields.Has("parent_id"), req.ParentId, req.Id).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateCategory - Failed to update category")
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateCategory - Failed to commit transaction")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *categoryRepository) DeleteCategory(ctx context.Context, req *entity.DeleteCategoryRequest) error {
+	var id string
+
+	query := `
+		DELETE FROM categories
+		WHERE id = ?
+		RETURNING id
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.Id).Scan(&id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteCategory - Failed to delete category")
+		return err
+	}
+
+	return nil
+}
+
+func (r *categoryRepository) GetAttributes(ctx context.Context, req *entity.AttributesRequest) (*entity.AttributesResponse, error) {
+	var resp = new(entity.AttributesResponse)
+	resp.Items = make([]entity.AttributeItem, 0)
+
+	// an attribute redefined by a subcategory overrides the inherited one
+	query := `
+		WITH RECURSIVE path AS (
+			SELECT id, parent_id, 0 AS depth
+			FROM categories
+			WHERE id = ?
+			UNION ALL
+			SELECT c.id, c.parent_id, path.depth + 1
+			FROM categories c
+			INNER JOIN path ON c.id = path.parent_id
+		)
+		SELECT id, category_id, key, label, type, options, is_required
+		FROM (
+			SELECT DISTINCT ON (a.key) a.*, path.depth
+			FROM category_attributes a
+			INNER JOIN path ON a.category_id = path.id
+			ORDER BY a.key, path.depth ASC
+		) a
+		ORDER BY depth DESC, position ASC, key ASC
+	`
+
+	err := r.db.SelectContext(ctx, &resp.Items, r.db.Rebind(query), req.CategoryId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetAttributes - Failed to get attributes")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *categoryRepository) SetAttributes(ctx context.Context, req *entity.SetAttributesRequest) (*entity.AttributesResponse, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::SetAttributes - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var id string
+	err = tx.GetContext(ctx, &id, tx.Rebind("SELECT id FROM categories WHERE id = ? FOR UPDATE"), req.CategoryId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::SetAttributes - Failed to get category")
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, tx.Rebind("DELETE FROM category_attributes WHERE category_id = ?"), req.CategoryId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::SetAttributes - Failed to delete attributes")
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO category_attributes (category_id, key, label, type, options, is_required, position)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	for position, attribute := range req.Attributes {
+		_, err = tx.ExecContext(ctx, tx.Rebind(query),
+			req.CategoryId,
+			attribute.Key,
+			attribute.Label,
+			attribute.Type,
+			pq.StringArray(attribute.Options),
+			attribute.IsRequired,
+			position)
+		if err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::SetAttributes - Failed to create attribute")
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::SetAttributes - Failed to commit transaction")
+		return nil, err
+	}
+
+	return r.GetAttributes(ctx, &entity.AttributesRequest{CategoryId: req.CategoryId})
+}
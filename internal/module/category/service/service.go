@@ -0,0 +1,189 @@
+package service
+
+import (
+	"codebase-app/internal/module/category/entity"
+	"codebase-app/internal/module/category/ports"
+	"codebase-app/pkg/errmsg"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.CategoryService = &categoryService{}
+
+type categoryService struct {
+	repo ports.CategoryRepository
+}
+
+func NewCategoryService(repo ports.CategoryRepository) *categoryService {
+	return &categoryService{
+		repo: repo,
+	}
+}
+
+func (s *categoryService) GetCategories(ctx context.Context, req *entity.CategoriesRequest) (*entity.CategoriesResponse, error) {
+	return s.repo.GetCategories(ctx, req)
+}
+
+func (s *categoryService) GetCategoryTree(ctx context.Context) (*entity.CategoryTreeResponse, error) {
+	categories, err := s.repo.GetAllCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		resp  = &entity.CategoryTreeResponse{Items: make([]*entity.CategoryNode, 0)}
+		nodes = make(map[string]*entity.CategoryNode, len(categories))
+	)
+
+	for _, category := range categories {
+		nodes[category.Id] = &entity.CategoryNode{
+			CategoryItem: category,
+			Children:     make([]*entity.CategoryNode, 0),
+		}
+	}
+
+	// categories are sorted by name, so siblings keep that order
+	for _, category := range categories {
+		node := nodes[category.Id]
+		if parent, ok := nodes[pointerValue(category.ParentId)]; ok {
+			parent.Children = append(parent.Children, node)
+			continue
+		}
+		resp.Items = append(resp.Items, node)
+	}
+
+	for _, node := range resp.Items {
+		countSubtree(node)
+	}
+
+	return resp, nil
+}
+
+func (s *categoryService) CreateCategory(ctx context.Context, req *entity.CreateCategoryRequest) (*entity.CategoryItem, error) {
+	res, err := s.repo.CreateCategory(ctx, req)
+	if err != nil {
+		if isForeignKeyViolation(err, "categories_parent_id_fkey") {
+			return nil, errParentNotFound
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *categoryService) UpdateCategory(ctx context.Context, req *entity.UpdateCategoryRequest) (*entity.CategoryItem, error) {
+	res, err := s.repo.UpdateCategory(ctx, req)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Category not found"))
+		}
+		if isForeignKeyViolation(err, "categories_parent_id_fkey") {
+			return nil, errParentNotFound
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *categoryService) DeleteCategory(ctx context.Context, req *entity.DeleteCategoryRequest) error {
+	err := s.repo.DeleteCategory(ctx, req)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errmsg.NewCustomErrors(404, errmsg.WithMessage("Category not found"))
+		}
+
+		// products and subcategories reference categories with ON DELETE RESTRICT
+		if isForeignKeyViolation(err, "categories_parent_id_fkey") {
+			return errmsg.NewCustomErrors(409, errmsg.WithMessage("Category still has subcategories"))
+		}
+		if isForeignKeyViolation(err, "") {
+			return errmsg.NewCustomErrors(409, errmsg.WithMessage("Category is still used by products"))
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *categoryService) GetAttributes(ctx context.Context, req *entity.AttributesRequest) (*entity.AttributesResponse, error) {
+	return s.repo.GetAttributes(ctx, req)
+}
+
+func (s *categoryService) SetAttributes(ctx context.Context, req *entity.SetAttributesRequest) (*entity.AttributesResponse, error) {
+	var (
+		errs = errmsg.NewCustomErrors(400)
+		keys = make(map[string]bool, len(req.Attributes))
+	)
+
+	for i, attribute := range req.Attributes {
+		field := fmt.Sprintf("attributes[%d].key", i)
+
+		if !attributeKeyPattern.MatchString(attribute.Key) {
+			errs.Add(field, "key hanya boleh berisi huruf kecil, angka, dan garis bawah.")
+		}
+
+		if keys[attribute.Key] {
+			errs.Add(field, "key harus unik.")
+		}
+		keys[attribute.Key] = true
+
+		if attribute.Type != entity.AttributeTypeEnum && len(attribute.Options) > 0 {
+			errs.Add(fmt.Sprintf("attributes[%d].options", i), "options hanya untuk tipe enum.")
+		}
+	}
+
+	if errs.HasErrors() {
+		log.Warn().Any("payload", req).Msg("service::SetAttributes - Invalid attribute schema")
+		return nil, errs
+	}
+
+	res, err := s.repo.SetAttributes(ctx, req)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Category not found"))
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// attributeKeyPattern keeps attribute keys usable as query filter keys.
+var attributeKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+var errParentNotFound = errmsg.NewCustomErrors(400, errmsg.WithErrors("parent_id", "parent id tidak ditemukan."))
+
+// isForeignKeyViolation reports whether err violates the given foreign key,
+// any foreign key when constraint is empty.
+func isForeignKeyViolation(err error, constraint string) bool {
+	var errPq *pq.Error
+	if !errors.As(err, &errPq) || errPq.Code.Name() != "foreign_key_violation" {
+		return false
+	}
+
+	return constraint == "" || errPq.Constraint == constraint
+}
+
+func countSubtree(node *entity.CategoryNode) int {
+	node.TotalProductCount = node.ProductCount
+	for _, child := range node.Children {
+		node.TotalProductCount += countSubtree(child)
+	}
+
+	return node.TotalProductCount
+}
+
+func pointerValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
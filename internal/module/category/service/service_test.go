@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"codebase-app/internal/module/category/entity"
+	"codebase-app/internal/module/category/ports"
+	mockPort "codebase-app/mock/module/category/ports"
+	"codebase-app/pkg/errmsg"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServiceList struct {
+	suite.Suite
+	mockCategoryRepo *mockPort.MockCategoryRepo
+	service          ports.CategoryService
+}
+
+func (suite *ServiceList) SetupTest() {
+	suite.mockCategoryRepo = new(mockPort.MockCategoryRepo)
+	suite.service = NewCategoryService(suite.mockCategoryRepo)
+}
+
+func (suite *ServiceList) TestUpdateCategory_NotFound() {
+	ctx := context.Background()
+	req := &entity.UpdateCategoryRequest{Id: "1", Name: "Phones"}
+	errNotFound := errmsg.NewCustomErrors(404, errmsg.WithMessage("Category not found"))
+
+	suite.mockCategoryRepo.On("UpdateCategory", ctx, req).Return(nil, sql.ErrNoRows)
+	_, err := suite.service.UpdateCategory(ctx, req)
+
+	suite.Equal(errNotFound, err)
+}
+
+func (suite *ServiceList) TestDeleteCategory_Success() {
+	ctx := context.Background()
+	req := &entity.DeleteCategoryRequest{Id: "1"}
+
+	suite.mockCategoryRepo.On("DeleteCategory", ctx, req).Return(nil)
+	err := suite.service.DeleteCategory(ctx, req)
+
+	suite.Equal(nil, err)
+}
+
+func (suite *ServiceList) TestDeleteCategory_StillReferenced() {
+	ctx := context.Background()
+	req := &entity.DeleteCategoryRequest{Id: "1"}
+	errConflict := errmsg.NewCustomErrors(409, errmsg.WithMessage("Category is still used by products"))
+
+	suite.mockCategoryRepo.On("DeleteCategory", ctx, req).Return(&pq.Error{Code: "23503"})
+	err := suite.service.DeleteCategory(ctx, req)
+
+	suite.Equal(errConflict, err)
+}
+
+func (suite *ServiceList) TestDeleteCategory_HasSubcategories() {
+	ctx := context.Background()
+	req := &entity.DeleteCategoryRequest{Id: "1"}
+	errConflict := errmsg.NewCustomErrors(409, errmsg.WithMessage("Category still has subcategories"))
+
+	suite.mockCategoryRepo.On("DeleteCategory", ctx, req).Return(&pq.Error{Code: "23503", Constraint: "categories_parent_id_fkey"})
+	err := suite.service.DeleteCategory(ctx, req)
+
+	suite.Equal(errConflict, err)
+}
+
+func (suite *ServiceList) TestGetCategoryTree_Success() {
+	ctx := context.Background()
+	electronics, phones := "1", "2"
+
+	suite.mockCategoryRepo.On("GetAllCategories", ctx).Return([]entity.CategoryItem{
+		{Id: "3", Name: "Accessories", ParentId: &phones, ProductCount: 4},
+		{Id: electronics, Name: "Electronics", ProductCount: 1},
+		{Id: phones, Name: "Phones", ParentId: &electronics, ProductCount: 2},
+	}, nil)
+	res, err := suite.service.GetCategoryTree(ctx)
+
+	suite.Equal(nil, err)
+	suite.Len(res.Items, 1)
+	suite.Equal("Electronics", res.Items[0].Name)
+	suite.Equal(7, res.Items[0].TotalProductCount)
+	suite.Equal("Phones", res.Items[0].Children[0].Name)
+	suite.Equal("Accessories", res.Items[0].Children[0].Children[0].Name)
+}
+
+func (suite *ServiceList) TestSetAttributes_InvalidSchema() {
+	ctx := context.Background()
+	req := &entity.SetAttributesRequest{
+		CategoryId: "1",
+		Attributes: []entity.AttributeRequest{
+			{Key: "ram", Label: "RAM", Type: entity.AttributeTypeNumber},
+			{Key: "ram", Label: "RAM", Type: entity.AttributeTypeString, Options: []string{"8GB"}},
+		},
+	}
+	errBadRequest := errmsg.NewCustomErrors(400,
+		errmsg.WithErrors("attributes[1].key", "key harus unik."),
+		errmsg.WithErrors("attributes[1].options", "options hanya untuk tipe enum."),
+	)
+
+	_, err := suite.service.SetAttributes(ctx, req)
+
+	suite.Equal(errBadRequest, err)
+	suite.mockCategoryRepo.AssertNotCalled(suite.T(), "SetAttributes", ctx, req)
+}
+
+func TestService(t *testing.T) {
+	suite.Run(t, new(ServiceList))
+}
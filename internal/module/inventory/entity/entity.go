@@ -0,0 +1,100 @@
+package entity
+
+import (
+	"codebase-app/pkg/types"
+	"time"
+)
+
+const (
+	ReasonRestock    = "restock"
+	ReasonSale       = "sale"
+	ReasonReturn     = "return"
+	ReasonCorrection = "correction"
+)
+
+type MovementItem struct {
+	Id          string    `json:"id" db:"id"`
+	ProductId   string    `json:"product_id" db:"product_id"`
+	Delta       int       `json:"delta" db:"delta"`
+	Reason      string    `json:"reason" db:"reason"`
+	ActorId     string    `json:"actor_id" db:"actor_id"`
+	ReferenceId *string   `json:"reference_id" db:"reference_id"`
+	Note        *string   `json:"note" db:"note"`
+	StockAfter  int       `json:"stock_after" db:"stock_after"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+type MovementsRequest struct {
+	UserId    string `prop:"user_id" validate:"uuid"`
+	ProductId string `params:"id" validate:"uuid"`
+	Page      int    `query:"page" validate:"required"`
+	Paginate  int    `query:"paginate" validate:"required"`
+	Reason    string `query:"reason" validate:"omitempty,oneof=restock sale return correction"`
+}
+
+func (r *MovementsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type MovementsResponse struct {
+	Items []MovementItem `json:"items"`
+	Meta  types.Meta     `json:"meta"`
+}
+
+// AdjustStockRequest applies Delta to the product stock and records it in
+// the ledger. Restock and return add stock, sale removes it, correction goes
+// either way.
+type AdjustStockRequest struct {
+	UserId    string `prop:"user_id" validate:"uuid" db:"actor_id"`
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+
+	Delta       int     `json:"delta" validate:"required" db:"delta"`
+	Reason      string  `json:"reason" validate:"required,oneof=restock sale return correction" db:"reason"`
+	ReferenceId *string `json:"reference_id" validate:"omitempty,max=128" db:"reference_id"` // e.g. an order id, unique per product and reason
+	Note        *string `json:"note" validate:"omitempty,max=255" db:"note"`
+}
+
+const (
+	ReservationPending   = "pending"
+	ReservationCommitted = "committed"
+	ReservationReleased  = "released"
+	ReservationExpired   = "expired"
+)
+
+// Reservation holds stock for a checkout until it is committed, released or
+// expires. Held stock is not available to other reservations.
+type Reservation struct {
+	Id          string            `json:"id" db:"id"`
+	UserId      string            `json:"user_id" db:"user_id"`
+	ReferenceId *string           `json:"reference_id" db:"reference_id"`
+	Status      string            `json:"status" db:"status"`
+	ExpiresAt   time.Time         `json:"expires_at" db:"expires_at"`
+	CreatedAt   time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at" db:"updated_at"`
+	Items       []ReservationItem `json:"items"`
+}
+
+type ReservationItem struct {
+	ProductId string  `json:"product_id" db:"product_id" validate:"required,uuid"`
+	VariantId *string `json:"variant_id" db:"variant_id" validate:"omitempty,uuid"` // holds the variant stock instead of the product stock
+	Quantity  int     `json:"quantity" db:"quantity" validate:"required,min=1"`
+}
+
+type ReserveStockRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	ReferenceId *string           `json:"reference_id" validate:"omitempty,max=128"` // e.g. a checkout id, unique per user
+	Ttl         int               `json:"ttl" validate:"min=60,max=86400"`           // seconds, defaults to RESERVATION_TTL
+	Items       []ReservationItem `json:"items" validate:"required,min=1,max=100,dive"`
+}
+
+type ReservationRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+	Id     string `params:"id" validate:"uuid"`
+}
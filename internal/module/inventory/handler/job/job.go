@@ -0,0 +1,41 @@
+package job
+
+import (
+	"codebase-app/internal/adapter"
+	"codebase-app/internal/infrastructure/config"
+	"codebase-app/internal/module/inventory/ports"
+	"codebase-app/internal/module/inventory/repository"
+	"codebase-app/internal/module/inventory/service"
+	"codebase-app/pkg/scheduler"
+	"context"
+	"time"
+)
+
+type inventoryJob struct {
+	service ports.InventoryService
+}
+
+func NewInventoryJob() *inventoryJob {
+	var (
+		job     = new(inventoryJob)
+		repo    = repository.NewInventoryRepository(adapter.Adapters.ShopeefunPostgres)
+		service = service.NewInventoryService(repo)
+	)
+	job.service = service
+
+	return job
+}
+
+func (j *inventoryJob) Register(s *scheduler.Scheduler) {
+	s.Register(scheduler.Job{
+		Name:     "inventory.expire_reservations",
+		Interval: time.Duration(config.Envs.Reservation.SweepInterval) * time.Second,
+		Run:      j.ExpireReservations,
+	})
+}
+
+// ExpireReservations marks the reservations past their ttl as expired.
+func (j *inventoryJob) ExpireReservations(ctx context.Context) error {
+	_, err := j.service.ExpireReservations(ctx)
+	return err
+}
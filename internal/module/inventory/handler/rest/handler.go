@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"codebase-app/internal/adapter"
+	"codebase-app/internal/infrastructure/config"
+	"codebase-app/internal/middleware"
+	"codebase-app/internal/module/inventory/entity"
+	"codebase-app/internal/module/inventory/ports"
+	"codebase-app/internal/module/inventory/repository"
+	"codebase-app/internal/module/inventory/service"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/response"
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+type inventoryHandler struct {
+	service ports.InventoryService
+}
+
+func NewInventoryHandler() *inventoryHandler {
+	var (
+		handler = new(inventoryHandler)
+		repo    = repository.NewInventoryRepository(adapter.Adapters.ShopeefunPostgres)
+		service = service.NewInventoryService(repo)
+	)
+	handler.service = service
+
+	return handler
+}
+
+func (h *inventoryHandler) Register(router fiber.Router) {
+	router.Get("/products/:id/inventory/movements", middleware.UserIdHeader, h.GetMovements)
+	router.Post("/products/:id/inventory/adjustments", middleware.UserIdHeader, h.AdjustStock)
+
+	router.Post("/reservations", middleware.UserIdHeader, h.ReserveStock)
+	router.Get("/reservations/:id", middleware.UserIdHeader, h.GetReservation)
+	router.Post("/reservations/:id/commit", middleware.UserIdHeader, h.CommitReservation)
+	router.Post("/reservations/:id/release", middleware.UserIdHeader, h.ReleaseReservation)
+}
+
+func (h *inventoryHandler) GetMovements(c *fiber.Ctx) error {
+	var (
+		req = new(entity.MovementsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetMovements - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.ProductId = c.Params("id")
+	req.SetDefault()
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetMovements - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetMovements(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *inventoryHandler) AdjustStock(c *fiber.Ctx) error {
+	var (
+		req = new(entity.AdjustStockRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::AdjustStock - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.ProductId = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::AdjustStock - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.AdjustStock(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Success(resp, ""))
+}
+
+func (h *inventoryHandler) ReserveStock(c *fiber.Ctx) error {
+	var (
+		req = new(entity.ReserveStockRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::ReserveStock - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	if req.Ttl == 0 {
+		req.Ttl = config.Envs.Reservation.Ttl
+	}
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::ReserveStock - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.ReserveStock(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Success(resp, ""))
+}
+
+func (h *inventoryHandler) GetReservation(c *fiber.Ctx) error {
+	return h.handleReservation(c, "GetReservation", h.service.GetReservation)
+}
+
+func (h *inventoryHandler) CommitReservation(c *fiber.Ctx) error {
+	return h.handleReservation(c, "CommitReservation", h.service.CommitReservation)
+}
+
+func (h *inventoryHandler) ReleaseReservation(c *fiber.Ctx) error {
+	return h.handleReservation(c, "ReleaseReservation", h.service.ReleaseReservation)
+}
+
+// handleReservation runs an action on the reservation in the path.
+func (h *inventoryHandler) handleReservation(c *fiber.Ctx, name string, action func(context.Context, *entity.ReservationRequest) (*entity.Reservation, error)) error {
+	var (
+		req = new(entity.ReservationRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::" + name + " - Validate request")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := action(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
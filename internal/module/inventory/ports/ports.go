@@ -0,0 +1,32 @@
+package ports
+
+import (
+	"codebase-app/internal/module/inventory/entity"
+	"context"
+)
+
+type InventoryRepository interface {
+	GetMovements(ctx context.Context, req *entity.MovementsRequest) (*entity.MovementsResponse, error)
+	AdjustStock(ctx context.Context, req *entity.AdjustStockRequest) (*entity.MovementItem, error)
+	GetStock(ctx context.Context, productId string) (int, error)
+
+	ReserveStock(ctx context.Context, req *entity.ReserveStockRequest) (*entity.Reservation, error)
+	GetReservation(ctx context.Context, id string) (*entity.Reservation, error)
+	CommitReservation(ctx context.Context, req *entity.ReservationRequest) (*entity.Reservation, error)
+	ReleaseReservation(ctx context.Context, req *entity.ReservationRequest) (*entity.Reservation, error)
+	ExpireReservations(ctx context.Context) (int, error)
+	GetAvailableStock(ctx context.Context, productId string, variantId *string) (int, error)
+
+	IsProductOwner(ctx context.Context, userId, productId string) (bool, error)
+}
+
+type InventoryService interface {
+	GetMovements(ctx context.Context, req *entity.MovementsRequest) (*entity.MovementsResponse, error)
+	AdjustStock(ctx context.Context, req *entity.AdjustStockRequest) (*entity.MovementItem, error)
+
+	ReserveStock(ctx context.Context, req *entity.ReserveStockRequest) (*entity.Reservation, error)
+	GetReservation(ctx context.Context, req *entity.ReservationRequest) (*entity.Reservation, error)
+	CommitReservation(ctx context.Context, req *entity.ReservationRequest) (*entity.Reservation, error)
+	ReleaseReservation(ctx context.Context, req *entity.ReservationRequest) (*entity.Reservation, error)
+	ExpireReservations(ctx context.Context) (int, error)
+}
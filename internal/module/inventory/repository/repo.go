@@ -0,0 +1,463 @@
+package repository
+
+import (
+	"codebase-app/internal/module/inventory/entity"
+	"codebase-app/internal/module/inventory/ports"
+	"codebase-app/pkg/types"
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.InventoryRepository = &inventoryRepository{}
+
+type inventoryRepository struct {
+	db *sqlx.DB
+}
+
+func NewInventoryRepository(db *sqlx.DB) *inventoryRepository {
+	return &inventoryRepository{
+		db: db,
+	}
+}
+
+func (r *inventoryRepository) GetMovements(ctx context.Context, req *entity.MovementsRequest) (*entity.MovementsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.MovementItem
+	}
+
+	var (
+		resp = new(entity.MovementsResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.MovementItem, 0, req.Paginate)
+
+	query := `
+		SELECT
+			COUNT(m.id) OVER() as total_data,
+			m.id,
+			m.product_id,
+			m.delta,
+			m.reason,
+			m.actor_id,
+			m.reference_id,
+			m.note,
+			m.stock_after,
+			m.created_at
+		FROM inventory_movements m
+		WHERE
+			m.product_id = ?
+			AND (?::TEXT = '' OR m.reason = ?)
+		ORDER BY m.created_at DESC, m.id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query),
+		req.ProductId,
+		req.Reason,
+		req.Reason,
+		req.Paginate,
+		req.Paginate*(req.Page-1))
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetMovements - Failed to get inventory movements")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.MovementItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+// AdjustStock moves the stock and appends the ledger row in one statement.
+// It returns sql.ErrNoRows when the product is gone or the stock would go
+// negative.
+func (r *inventoryRepository) AdjustStock(ctx context.Context, req *entity.AdjustStockRequest) (*entity.MovementItem, error) {
+	var resp = new(entity.MovementItem)
+
+	query := `
+		WITH p AS (
+			UPDATE products
+			SET stock = stock + ?, updated_at = NOW(), version = version + 1
+			WHERE id = ? AND deleted_at IS NULL AND stock + ? >= 0
+			RETURNING id, stock
+		)
+		INSERT INTO inventory_movements (product_id, delta, reason, actor_id, reference_id, note, stock_after)
+		SELECT p.id, ?, ?, ?, ?, ?, p.stock FROM p
+		RETURNING id, product_id, delta, reason, actor_id, reference_id, note, stock_after, created_at
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query),
+		req.Delta,
+		req.ProductId,
+		req.Delta,
+		req.Delta,
+		req.Reason,
+		req.UserId,
+		req.ReferenceId,
+		req.Note).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::AdjustStock - Failed to adjust stock")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *inventoryRepository) GetStock(ctx context.Context, productId string) (int, error) {
+	var stock int
+
+	query := `SELECT stock FROM products WHERE id = ? AND deleted_at IS NULL`
+
+	err := r.db.GetContext(ctx, &stock, r.db.Rebind(query), productId)
+	if err != nil {
+		log.Error().Err(err).Str("product_id", productId).Msg("repository::GetStock - Failed to get stock")
+		return 0, err
+	}
+
+	return stock, nil
+}
+
+const reservationColumns = `id, user_id, reference_id, status, expires_at, created_at, updated_at`
+
+// ReserveStock holds the stock of every item in one transaction. It returns
+// sql.ErrNoRows when any item is unknown or short of stock, in which case
+// nothing is held.
+func (r *inventoryRepository) ReserveStock(ctx context.Context, req *entity.ReserveStockRequest) (*entity.Reservation, error) {
+	var (
+		resp       = new(entity.Reservation)
+		productIds = make([]string, 0, len(req.Items))
+		variantIds = make([]string, 0, len(req.Items))
+	)
+
+	for _, item := range req.Items {
+		productIds = append(productIds, item.ProductId)
+		if item.VariantId != nil {
+			variantIds = append(variantIds, *item.VariantId)
+		}
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::ReserveStock - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// rows are locked in id order so concurrent reservations can't deadlock
+	query := `SELECT id FROM products WHERE id = ANY(?::UUID[]) ORDER BY id FOR UPDATE`
+
+	if _, err := tx.ExecContext(ctx, tx.Rebind(query), pq.Array(productIds)); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::ReserveStock - Failed to lock products")
+		return nil, err
+	}
+
+	query = `SELECT id FROM product_variants WHERE id = ANY(?::UUID[]) ORDER BY id FOR UPDATE`
+
+	if _, err := tx.ExecContext(ctx, tx.Rebind(query), pq.Array(variantIds)); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::ReserveStock - Failed to lock variants")
+		return nil, err
+	}
+
+	query = `
+		INSERT INTO stock_reservations (user_id, reference_id, expires_at)
+		VALUES (?, ?, NOW() + make_interval(secs => ?))
+		RETURNING ` + reservationColumns
+
+	err = tx.QueryRowxContext(ctx, tx.Rebind(query), req.UserId, req.ReferenceId, req.Ttl).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::ReserveStock - Failed to create reservation")
+		return nil, err
+	}
+
+	// items already inserted count as reserved, so repeated items add up
+	query = `
+		INSERT INTO stock_reservation_items (reservation_id, product_id, variant_id, quantity)
+		SELECT ?, p.id, v.id, ?
+		FROM products p
+		LEFT JOIN product_variants v ON v.id = ?::UUID AND v.product_id = p.id AND v.deleted_at IS NULL
+		WHERE
+			p.id = ?
+			AND p.deleted_at IS NULL
+			AND p.status = 'published'
+			AND (?::UUID IS NULL OR v.id IS NOT NULL)
+			AND COALESCE(v.stock, p.stock) - ` + types.ReservedStockExpr("p.id", "v.id") + ` >= ?
+	`
+
+	for _, item := range req.Items {
+		res, err := tx.ExecContext(ctx, tx.Rebind(query),
+			resp.Id,
+			item.Quantity,
+			item.VariantId,
+			item.ProductId,
+			item.VariantId,
+			item.Quantity)
+		if err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::ReserveStock - Failed to reserve item")
+			return nil, err
+		}
+
+		if n, _ := res.RowsAffected(); n == 0 {
+			log.Warn().Any("payload", req).Any("item", item).Msg("repository::ReserveStock - Item can't be reserved")
+			return nil, sql.ErrNoRows
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::ReserveStock - Failed to commit transaction")
+		return nil, err
+	}
+
+	resp.Items = req.Items
+
+	return resp, nil
+}
+
+func (r *inventoryRepository) GetReservation(ctx context.Context, id string) (*entity.Reservation, error) {
+	var resp = new(entity.Reservation)
+
+	query := `SELECT ` + reservationColumns + ` FROM stock_reservations WHERE id = ?`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), id).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::GetReservation - Failed to get reservation")
+		return nil, err
+	}
+
+	if resp.Items, err = getReservationItems(ctx, r.db, id); err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::GetReservation - Failed to get reservation items")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// CommitReservation turns the held stock into a sale: the stock is taken
+// off the products and variants, and product stock is recorded in the
+// inventory ledger with the reservation id as reference. It returns
+// sql.ErrNoRows when the reservation is no longer pending or has expired.
+func (r *inventoryRepository) CommitReservation(ctx context.Context, req *entity.ReservationRequest) (*entity.Reservation, error) {
+	var resp = new(entity.Reservation)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CommitReservation - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE stock_reservations
+		SET status = 'committed', updated_at = NOW()
+		WHERE id = ? AND user_id = ? AND status = 'pending' AND expires_at > NOW()
+		RETURNING ` + reservationColumns
+
+	err = tx.QueryRowxContext(ctx, tx.Rebind(query), req.Id, req.UserId).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CommitReservation - Failed to commit reservation")
+		return nil, err
+	}
+
+	// same lock order as ReserveStock
+	query = `
+		SELECT id FROM products
+		WHERE id IN (SELECT product_id FROM stock_reservation_items WHERE reservation_id = ?)
+		ORDER BY id FOR UPDATE
+	`
+
+	if _, err := tx.ExecContext(ctx, tx.Rebind(query), req.Id); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CommitReservation - Failed to lock products")
+		return nil, err
+	}
+
+	query = `
+		SELECT id FROM product_variants
+		WHERE id IN (SELECT variant_id FROM stock_reservation_items WHERE reservation_id = ?)
+		ORDER BY id FOR UPDATE
+	`
+
+	if _, err := tx.ExecContext(ctx, tx.Rebind(query), req.Id); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CommitReservation - Failed to lock variants")
+		return nil, err
+	}
+
+	query = `
+		WITH items AS (
+			SELECT product_id, SUM(quantity) AS quantity
+			FROM stock_reservation_items
+			WHERE reservation_id = ? AND variant_id IS NULL
+			GROUP BY product_id
+		), p AS (
+			UPDATE products
+			SET stock = products.stock - items.quantity, updated_at = NOW(), version = products.version + 1
+			FROM items
+			WHERE products.id = items.product_id
+			RETURNING products.id, products.stock, items.quantity
+		)
+		INSERT INTO inventory_movements (product_id, delta, reason, actor_id, reference_id, note, stock_after)
+		SELECT p.id, -p.quantity, 'sale', ?, ?, 'reservation commit', p.stock FROM p
+	`
+
+	if _, err := tx.ExecContext(ctx, tx.Rebind(query), req.Id, req.UserId, req.Id); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CommitReservation - Failed to take product stock")
+		return nil, err
+	}
+
+	query = `
+		UPDATE product_variants
+		SET stock = product_variants.stock - items.quantity, updated_at = NOW()
+		FROM (
+			SELECT variant_id, SUM(quantity) AS quantity
+			FROM stock_reservation_items
+			WHERE reservation_id = ? AND variant_id IS NOT NULL
+			GROUP BY variant_id
+		) items
+		WHERE product_variants.id = items.variant_id
+	`
+
+	if _, err := tx.ExecContext(ctx, tx.Rebind(query), req.Id); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CommitReservation - Failed to take variant stock")
+		return nil, err
+	}
+
+	if resp.Items, err = getReservationItems(ctx, tx, req.Id); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CommitReservation - Failed to get reservation items")
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CommitReservation - Failed to commit transaction")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// ReleaseReservation gives the held stock back. It returns sql.ErrNoRows
+// when the reservation is no longer pending.
+func (r *inventoryRepository) ReleaseReservation(ctx context.Context, req *entity.ReservationRequest) (*entity.Reservation, error) {
+	var resp = new(entity.Reservation)
+
+	query := `
+		UPDATE stock_reservations
+		SET status = 'released', updated_at = NOW()
+		WHERE id = ? AND user_id = ? AND status = 'pending'
+		RETURNING ` + reservationColumns
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.Id, req.UserId).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::ReleaseReservation - Failed to release reservation")
+		return nil, err
+	}
+
+	if resp.Items, err = getReservationItems(ctx, r.db, req.Id); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::ReleaseReservation - Failed to get reservation items")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// ExpireReservations marks the pending reservations past their expiry.
+func (r *inventoryRepository) ExpireReservations(ctx context.Context) (int, error) {
+	query := `
+		UPDATE stock_reservations
+		SET status = 'expired', updated_at = NOW()
+		WHERE status = 'pending' AND expires_at <= NOW()
+	`
+
+	res, err := r.db.ExecContext(ctx, query)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::ExpireReservations - Failed to expire reservations")
+		return 0, err
+	}
+
+	n, _ := res.RowsAffected()
+
+	return int(n), nil
+}
+
+// GetAvailableStock returns the stock not held by reservations, of the
+// variant when variantId is set. Unknown and unpublished products yield
+// sql.ErrNoRows.
+func (r *inventoryRepository) GetAvailableStock(ctx context.Context, productId string, variantId *string) (int, error) {
+	var available int
+
+	query := `
+		SELECT COALESCE(v.stock, p.stock) - ` + types.ReservedStockExpr("p.id", "v.id") + `
+		FROM products p
+		LEFT JOIN product_variants v ON v.id = ?::UUID AND v.product_id = p.id AND v.deleted_at IS NULL
+		WHERE
+			p.id = ?
+			AND p.deleted_at IS NULL
+			AND p.status = 'published'
+			AND (?::UUID IS NULL OR v.id IS NOT NULL)
+	`
+
+	err := r.db.GetContext(ctx, &available, r.db.Rebind(query), variantId, productId, variantId)
+	if err != nil {
+		log.Error().Err(err).Str("product_id", productId).Any("variant_id", variantId).Msg("repository::GetAvailableStock - Failed to get available stock")
+		return 0, err
+	}
+
+	return available, nil
+}
+
+func getReservationItems(ctx context.Context, db sqlx.QueryerContext, reservationId string) ([]entity.ReservationItem, error) {
+	var items = make([]entity.ReservationItem, 0)
+
+	query := `
+		SELECT product_id, variant_id, quantity
+		FROM stock_reservation_items
+		WHERE reservation_id = $1
+		ORDER BY product_id, variant_id NULLS FIRST
+	`
+
+	err := sqlx.SelectContext(ctx, db, &items, query, reservationId)
+
+	return items, err
+}
+
+func (r *inventoryRepository) IsProductOwner(ctx context.Context, userId, productId string) (bool, error) {
+	var (
+		isOwner bool
+		payload = struct {
+			UserId    string `json:"user_id"`
+			ProductId string `json:"product_id"`
+		}{userId, productId}
+	)
+
+	query := `
+		SELECT
+			EXISTS (
+				SELECT 1
+				FROM
+					products
+				LEFT JOIN
+					shops ON products.shop_id = shops.id
+				WHERE
+					shops.user_id = $1
+					AND products.id = $2
+					AND products.deleted_at IS NULL
+			)
+	`
+
+	err := r.db.GetContext(ctx, &isOwner, query, userId, productId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", payload).Msg("repository: IsProductOwner failed")
+		return isOwner, err
+	}
+
+	return isOwner, nil
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"codebase-app/internal/module/inventory/entity"
+	"codebase-app/internal/module/inventory/ports"
+	"codebase-app/pkg/errmsg"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.InventoryService = &inventoryService{}
+
+type inventoryService struct {
+	repo ports.InventoryRepository
+}
+
+func NewInventoryService(repo ports.InventoryRepository) *inventoryService {
+	return &inventoryService{
+		repo: repo,
+	}
+}
+
+func (s *inventoryService) GetMovements(ctx context.Context, req *entity.MovementsRequest) (*entity.MovementsResponse, error) {
+	if err := s.checkProductOwner(ctx, req.UserId, req.ProductId); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetMovements(ctx, req)
+}
+
+func (s *inventoryService) AdjustStock(ctx context.Context, req *entity.AdjustStockRequest) (*entity.MovementItem, error) {
+	if err := s.checkProductOwner(ctx, req.UserId, req.ProductId); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case (req.Reason == entity.ReasonRestock || req.Reason == entity.ReasonReturn) && req.Delta < 0:
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("delta", fmt.Sprintf("delta harus positif untuk reason %s.", req.Reason)))
+	case req.Reason == entity.ReasonSale && req.Delta > 0:
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("delta", "delta harus negatif untuk reason sale."))
+	}
+
+	res, err := s.repo.AdjustStock(ctx, req)
+	if err == nil {
+		return res, nil
+	}
+
+	if isUniqueViolation(err, "inventory_movements_reference_key") {
+		log.Warn().Any("payload", req).Msg("service: Inventory reference already applied")
+		return nil, errmsg.NewCustomErrors(409, errmsg.WithErrors("reference_id", fmt.Sprintf("reference id sudah digunakan untuk reason %s.", req.Reason)))
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	// nothing was written, either the product is gone or the stock is short
+	stock, err := s.repo.GetStock(ctx, req.ProductId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Product not found"))
+		}
+		return nil, err
+	}
+
+	log.Warn().Any("payload", req).Int("stock", stock).Msg("service: Insufficient stock")
+	return nil, errmsg.NewCustomErrors(409, errmsg.WithErrors("delta", fmt.Sprintf("stok tidak mencukupi, stok saat ini %d.", stock)))
+}
+
+func (s *inventoryService) ReserveStock(ctx context.Context, req *entity.ReserveStockRequest) (*entity.Reservation, error) {
+	res, err := s.repo.ReserveStock(ctx, req)
+	if err == nil {
+		return res, nil
+	}
+
+	if isUniqueViolation(err, "stock_reservations_reference_key") {
+		log.Warn().Any("payload", req).Msg("service: Reservation reference already used")
+		return nil, errmsg.NewCustomErrors(409, errmsg.WithErrors("reference_id", "reference id sudah digunakan."))
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	return nil, s.reservationErrors(ctx, req)
+}
+
+// reservationErrors tells which items kept a reservation from being held.
+func (s *inventoryService) reservationErrors(ctx context.Context, req *entity.ReserveStockRequest) error {
+	var (
+		notFound = errmsg.NewCustomErrors(400)
+		short    = errmsg.NewCustomErrors(409)
+		wanted   = make(map[string]int, len(req.Items))
+	)
+
+	for i, item := range req.Items {
+		key := item.ProductId
+		if item.VariantId != nil {
+			key += "/" + *item.VariantId
+		}
+		wanted[key] += item.Quantity
+
+		available, err := s.repo.GetAvailableStock(ctx, item.ProductId, item.VariantId)
+		if err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+			if item.VariantId != nil {
+				notFound.Add(fmt.Sprintf("items[%d].variant_id", i), "variant id tidak ditemukan.")
+			} else {
+				notFound.Add(fmt.Sprintf("items[%d].product_id", i), "product id tidak ditemukan.")
+			}
+			continue
+		}
+
+		if wanted[key] > available {
+			short.Add(fmt.Sprintf("items[%d].quantity", i), fmt.Sprintf("stok tidak mencukupi, stok tersedia %d.", available))
+		}
+	}
+
+	switch {
+	case notFound.HasErrors():
+		log.Warn().Any("payload", req).Msg("service: Reservation items not found")
+		return notFound
+	case short.HasErrors():
+		log.Warn().Any("payload", req).Msg("service: Insufficient stock for reservation")
+		return short
+	}
+
+	// the stock was freed meanwhile
+	return errmsg.NewCustomErrors(409, errmsg.WithMessage("Stock has changed, please retry"))
+}
+
+func (s *inventoryService) GetReservation(ctx context.Context, req *entity.ReservationRequest) (*entity.Reservation, error) {
+	return s.getOwnReservation(ctx, req)
+}
+
+// CommitReservation is idempotent, committing a committed reservation
+// returns it unchanged.
+func (s *inventoryService) CommitReservation(ctx context.Context, req *entity.ReservationRequest) (*entity.Reservation, error) {
+	reservation, err := s.getOwnReservation(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case reservation.Status == entity.ReservationCommitted:
+		return reservation, nil
+	case reservation.Status != entity.ReservationPending:
+		return nil, errmsg.NewCustomErrors(409, errmsg.WithMessage(fmt.Sprintf("Reservation is %s", reservation.Status)))
+	case !reservation.ExpiresAt.After(time.Now()):
+		return nil, errmsg.NewCustomErrors(409, errmsg.WithMessage("Reservation is expired"))
+	}
+
+	res, err := s.repo.CommitReservation(ctx, req)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(409, errmsg.WithMessage("Reservation has changed, please retry"))
+		}
+		// the seller took the stock away while it was held
+		if isCheckViolation(err, "products_stock_check") || isCheckViolation(err, "product_variants_stock_check") {
+			log.Warn().Any("payload", req).Msg("service: Reserved stock is no longer on hand")
+			return nil, errmsg.NewCustomErrors(409, errmsg.WithMessage("Reserved stock is no longer available"))
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ReleaseReservation is idempotent, releasing a reservation that no longer
+// holds stock returns it unchanged.
+func (s *inventoryService) ReleaseReservation(ctx context.Context, req *entity.ReservationRequest) (*entity.Reservation, error) {
+	reservation, err := s.getOwnReservation(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch reservation.Status {
+	case entity.ReservationReleased, entity.ReservationExpired:
+		return reservation, nil
+	case entity.ReservationCommitted:
+		return nil, errmsg.NewCustomErrors(409, errmsg.WithMessage("Reservation is committed"))
+	}
+
+	res, err := s.repo.ReleaseReservation(ctx, req)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(409, errmsg.WithMessage("Reservation has changed, please retry"))
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *inventoryService) ExpireReservations(ctx context.Context) (int, error) {
+	n, err := s.repo.ExpireReservations(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if n > 0 {
+		log.Info().Int("expired", n).Msg("service: Expired stock reservations")
+	}
+
+	return n, nil
+}
+
+func (s *inventoryService) getOwnReservation(ctx context.Context, req *entity.ReservationRequest) (*entity.Reservation, error) {
+	reservation, err := s.repo.GetReservation(ctx, req.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Reservation not found"))
+		}
+		return nil, err
+	}
+
+	if reservation.UserId != req.UserId {
+		log.Warn().Any("payload", req).Msg("service: User is not reservation owner")
+		return nil, errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not reservation owner"))
+	}
+
+	return reservation, nil
+}
+
+func (s *inventoryService) checkProductOwner(ctx context.Context, userId, productId string) error {
+	isProductOwner, err := s.repo.IsProductOwner(ctx, userId, productId)
+	if err != nil {
+		return err
+	}
+
+	if !isProductOwner {
+		log.Warn().Str("user_id", userId).Str("product_id", productId).Msg("service: User is not product owner")
+		return errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not product owner"))
+	}
+
+	return nil
+}
+
+func isUniqueViolation(err error, constraint string) bool {
+	var errPq *pq.Error
+	if !errors.As(err, &errPq) || errPq.Code.Name() != "unique_violation" {
+		return false
+	}
+
+	return errPq.Constraint == constraint
+}
+
+func isCheckViolation(err error, constraint string) bool {
+	var errPq *pq.Error
+	if !errors.As(err, &errPq) || errPq.Code.Name() != "check_violation" {
+		return false
+	}
+
+	return errPq.Constraint == constraint
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"codebase-app/internal/module/inventory/entity"
+	"codebase-app/internal/module/inventory/ports"
+	mockPort "codebase-app/mock/module/inventory/ports"
+	"codebase-app/pkg/errmsg"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServiceList struct {
+	suite.Suite
+	mockInventoryRepo *mockPort.MockInventoryRepo
+	service           ports.InventoryService
+
+	mockAdjustStockReq *entity.AdjustStockRequest
+}
+
+func (suite *ServiceList) SetupTest() {
+	suite.mockInventoryRepo = new(mockPort.MockInventoryRepo)
+	suite.service = NewInventoryService(suite.mockInventoryRepo)
+	suite.mockAdjustStockReq = &entity.AdjustStockRequest{
+		UserId:    "1",
+		ProductId: "2",
+		Delta:     -3,
+		Reason:    entity.ReasonSale,
+	}
+}
+
+func (suite *ServiceList) TestAdjustStock_Success() {
+	ctx := context.Background()
+	req := suite.mockAdjustStockReq
+
+	suite.mockInventoryRepo.On("IsProductOwner", ctx, req.UserId, req.ProductId).Return(true, nil)
+	suite.mockInventoryRepo.On("AdjustStock", ctx, req).Return(&entity.MovementItem{Id: "3", Delta: -3, StockAfter: 7}, nil)
+	res, err := suite.service.AdjustStock(ctx, req)
+
+	suite.Equal(nil, err)
+	suite.Equal(7, res.StockAfter)
+}
+
+func (suite *ServiceList) TestAdjustStock_UserIsNotProductOwner() {
+	ctx := context.Background()
+	req := suite.mockAdjustStockReq
+	errForbidden := errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not product owner"))
+
+	suite.mockInventoryRepo.On("IsProductOwner", ctx, req.UserId, req.ProductId).Return(false, nil)
+	_, err := suite.service.AdjustStock(ctx, req)
+
+	suite.Equal(errForbidden, err)
+	suite.mockInventoryRepo.AssertNotCalled(suite.T(), "AdjustStock", ctx, req)
+}
+
+func (suite *ServiceList) TestAdjustStock_WrongDeltaSign() {
+	ctx := context.Background()
+	req := suite.mockAdjustStockReq
+	req.Reason = entity.ReasonRestock
+	errBadRequest := errmsg.NewCustomErrors(400, errmsg.WithErrors("delta", "delta harus positif untuk reason restock."))
+
+	suite.mockInventoryRepo.On("IsProductOwner", ctx, req.UserId, req.ProductId).Return(true, nil)
+	_, err := suite.service.AdjustStock(ctx, req)
+
+	suite.Equal(errBadRequest, err)
+	suite.mockInventoryRepo.AssertNotCalled(suite.T(), "AdjustStock", ctx, req)
+}
+
+func (suite *ServiceList) TestAdjustStock_InsufficientStock() {
+	ctx := context.Background()
+	req := suite.mockAdjustStockReq
+	errConflict := errmsg.NewCustomErrors(409, errmsg.WithErrors("delta", "stok tidak mencukupi, stok saat ini 2."))
+
+	suite.mockInventoryRepo.On("IsProductOwner", ctx, req.UserId, req.ProductId).Return(true, nil)
+	suite.mockInventoryRepo.On("AdjustStock", ctx, req).Return(nil, sql.ErrNoRows)
+	suite.mockInventoryRepo.On("GetStock", ctx, req.ProductId).Return(2, nil)
+	_, err := suite.service.AdjustStock(ctx, req)
+
+	suite.Equal(errConflict, err)
+}
+
+func (suite *ServiceList) TestAdjustStock_ProductNotFound() {
+	ctx := context.Background()
+	req := suite.mockAdjustStockReq
+	errNotFound := errmsg.NewCustomErrors(404, errmsg.WithMessage("Product not found"))
+
+	suite.mockInventoryRepo.On("IsProductOwner", ctx, req.UserId, req.ProductId).Return(true, nil)
+	suite.mockInventoryRepo.On("AdjustStock", ctx, req).Return(nil, sql.ErrNoRows)
+	suite.mockInventoryRepo.On("GetStock", ctx, req.ProductId).Return(0, sql.ErrNoRows)
+	_, err := suite.service.AdjustStock(ctx, req)
+
+	suite.Equal(errNotFound, err)
+}
+
+func (suite *ServiceList) TestAdjustStock_ReferenceAlreadyUsed() {
+	ctx := context.Background()
+	req := suite.mockAdjustStockReq
+	reference := "order-1"
+	req.ReferenceId = &reference
+	errConflict := errmsg.NewCustomErrors(409, errmsg.WithErrors("reference_id", "reference id sudah digunakan untuk reason sale."))
+	errPq := &pq.Error{Code: "23505", Constraint: "inventory_movements_reference_key"}
+
+	suite.mockInventoryRepo.On("IsProductOwner", ctx, req.UserId, req.ProductId).Return(true, nil)
+	suite.mockInventoryRepo.On("AdjustStock", ctx, req).Return(nil, errPq)
+	_, err := suite.service.AdjustStock(ctx, req)
+
+	suite.Equal(errConflict, err)
+	suite.mockInventoryRepo.AssertNotCalled(suite.T(), "GetStock", mock.Anything, mock.Anything)
+}
+
+func (suite *ServiceList) TestGetMovements_UserIsNotProductOwner() {
+	ctx := context.Background()
+	req := &entity.MovementsRequest{UserId: "1", ProductId: "2"}
+	errForbidden := errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not product owner"))
+
+	suite.mockInventoryRepo.On("IsProductOwner", ctx, req.UserId, req.ProductId).Return(false, nil)
+	_, err := suite.service.GetMovements(ctx, req)
+
+	suite.Equal(errForbidden, err)
+}
+
+func (suite *ServiceList) TestReserveStock_InsufficientStock() {
+	ctx := context.Background()
+	req := &entity.ReserveStockRequest{
+		UserId: "1",
+		Ttl:    900,
+		Items: []entity.ReservationItem{
+			{ProductId: "2", Quantity: 2},
+			{ProductId: "3", Quantity: 5},
+		},
+	}
+	errConflict := errmsg.NewCustomErrors(409, errmsg.WithErrors("items[1].quantity", "stok tidak mencukupi, stok tersedia 4."))
+
+	suite.mockInventoryRepo.On("ReserveStock", ctx, req).Return(nil, sql.ErrNoRows)
+	suite.mockInventoryRepo.On("GetAvailableStock", ctx, "2", (*string)(nil)).Return(10, nil)
+	suite.mockInventoryRepo.On("GetAvailableStock", ctx, "3", (*string)(nil)).Return(4, nil)
+	_, err := suite.service.ReserveStock(ctx, req)
+
+	suite.Equal(errConflict, err)
+}
+
+func (suite *ServiceList) TestReserveStock_RepeatedItemsAddUp() {
+	ctx := context.Background()
+	req := &entity.ReserveStockRequest{
+		UserId: "1",
+		Ttl:    900,
+		Items: []entity.ReservationItem{
+			{ProductId: "2", Quantity: 3},
+			{ProductId: "2", Quantity: 3},
+		},
+	}
+	errConflict := errmsg.NewCustomErrors(409, errmsg.WithErrors("items[1].quantity", "stok tidak mencukupi, stok tersedia 5."))
+
+	suite.mockInventoryRepo.On("ReserveStock", ctx, req).Return(nil, sql.ErrNoRows)
+	suite.mockInventoryRepo.On("GetAvailableStock", ctx, "2", (*string)(nil)).Return(5, nil)
+	_, err := suite.service.ReserveStock(ctx, req)
+
+	suite.Equal(errConflict, err)
+}
+
+func (suite *ServiceList) TestReserveStock_VariantNotFound() {
+	ctx := context.Background()
+	variantId := "4"
+	req := &entity.ReserveStockRequest{
+		UserId: "1",
+		Ttl:    900,
+		Items:  []entity.ReservationItem{{ProductId: "2", VariantId: &variantId, Quantity: 1}},
+	}
+	errBadRequest := errmsg.NewCustomErrors(400, errmsg.WithErrors("items[0].variant_id", "variant id tidak ditemukan."))
+
+	suite.mockInventoryRepo.On("ReserveStock", ctx, req).Return(nil, sql.ErrNoRows)
+	suite.mockInventoryRepo.On("GetAvailableStock", ctx, "2", &variantId).Return(0, sql.ErrNoRows)
+	_, err := suite.service.ReserveStock(ctx, req)
+
+	suite.Equal(errBadRequest, err)
+}
+
+func (suite *ServiceList) TestCommitReservation_Success() {
+	ctx := context.Background()
+	req := &entity.ReservationRequest{UserId: "1", Id: "5"}
+	reservation := &entity.Reservation{Id: "5", UserId: "1", Status: entity.ReservationPending, ExpiresAt: time.Now().Add(time.Minute)}
+
+	suite.mockInventoryRepo.On("GetReservation", ctx, req.Id).Return(reservation, nil)
+	suite.mockInventoryRepo.On("CommitReservation", ctx, req).Return(&entity.Reservation{Id: "5", Status: entity.ReservationCommitted}, nil)
+	res, err := suite.service.CommitReservation(ctx, req)
+
+	suite.Equal(nil, err)
+	suite.Equal(entity.ReservationCommitted, res.Status)
+}
+
+func (suite *ServiceList) TestCommitReservation_AlreadyCommitted() {
+	ctx := context.Background()
+	req := &entity.ReservationRequest{UserId: "1", Id: "5"}
+	reservation := &entity.Reservation{Id: "5", UserId: "1", Status: entity.ReservationCommitted}
+
+	suite.mockInventoryRepo.On("GetReservation", ctx, req.Id).Return(reservation, nil)
+	res, err := suite.service.CommitReservation(ctx, req)
+
+	suite.Equal(nil, err)
+	suite.Equal(reservation, res)
+	suite.mockInventoryRepo.AssertNotCalled(suite.T(), "CommitReservation", ctx, req)
+}
+
+func (suite *ServiceList) TestCommitReservation_Expired() {
+	ctx := context.Background()
+	req := &entity.ReservationRequest{UserId: "1", Id: "5"}
+	reservation := &entity.Reservation{Id: "5", UserId: "1", Status: entity.ReservationPending, ExpiresAt: time.Now().Add(-time.Minute)}
+	errConflict := errmsg.NewCustomErrors(409, errmsg.WithMessage("Reservation is expired"))
+
+	suite.mockInventoryRepo.On("GetReservation", ctx, req.Id).Return(reservation, nil)
+	_, err := suite.service.CommitReservation(ctx, req)
+
+	suite.Equal(errConflict, err)
+	suite.mockInventoryRepo.AssertNotCalled(suite.T(), "CommitReservation", ctx, req)
+}
+
+func (suite *ServiceList) TestReleaseReservation_UserIsNotReservationOwner() {
+	ctx := context.Background()
+	req := &entity.ReservationRequest{UserId: "1", Id: "5"}
+	errForbidden := errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not reservation owner"))
+
+	suite.mockInventoryRepo.On("GetReservation", ctx, req.Id).Return(&entity.Reservation{Id: "5", UserId: "9"}, nil)
+	_, err := suite.service.ReleaseReservation(ctx, req)
+
+	suite.Equal(errForbidden, err)
+	suite.mockInventoryRepo.AssertNotCalled(suite.T(), "ReleaseReservation", ctx, req)
+}
+
+func (suite *ServiceList) TestReleaseReservation_Committed() {
+	ctx := context.Background()
+	req := &entity.ReservationRequest{UserId: "1", Id: "5"}
+	errConflict := errmsg.NewCustomErrors(409, errmsg.WithMessage("Reservation is committed"))
+
+	suite.mockInventoryRepo.On("GetReservation", ctx, req.Id).Return(&entity.Reservation{Id: "5", UserId: "1", Status: entity.ReservationCommitted}, nil)
+	_, err := suite.service.ReleaseReservation(ctx, req)
+
+	suite.Equal(errConflict, err)
+}
+
+func TestService(t *testing.T) {
+	suite.Run(t, new(ServiceList))
+}
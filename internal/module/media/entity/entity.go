@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"mime/multipart"
+	"time"
+)
+
+type ImageItem struct {
+	Id        string    `json:"id" db:"id"`
+	ProductId string    `json:"product_id" db:"product_id"`
+	Url       string    `json:"url" db:"url"`
+	AltText   string    `json:"alt_text" db:"alt_text"`
+	Position  int       `json:"position" db:"position"`
+	IsPrimary bool      `json:"is_primary" db:"is_primary"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type ImagesRequest struct {
+	ProductId string `params:"id" validate:"uuid"`
+}
+
+type ImagesResponse struct {
+	Items []ImageItem `json:"items"`
+}
+
+type UploadImageRequest struct {
+	UserId    string `prop:"user_id" validate:"uuid"`
+	ProductId string `params:"id" validate:"uuid"`
+
+	File      *multipart.FileHeader `form:"file" validate:"required"`
+	AltText   string                `form:"alt_text" validate:"omitempty,max=255"`
+	IsPrimary bool                  `form:"is_primary"`
+}
+
+type UploadBase64ImageRequest struct {
+	UserId    string `prop:"user_id" validate:"uuid"`
+	ProductId string `params:"id" validate:"uuid"`
+
+	Data      string `json:"data" validate:"required"` // base64, optionally with a data URI prefix
+	AltText   string `json:"alt_text" validate:"omitempty,max=255"`
+	IsPrimary bool   `json:"is_primary"`
+}
+
+type CreateImageRequest struct {
+	ProductId string `db:"product_id"`
+	FileName  string `db:"file_name"`
+	Url       string `db:"url"`
+	AltText   string `db:"alt_text"`
+	IsPrimary bool   `db:"is_primary"`
+}
+
+type UpdateImageRequest struct {
+	UserId    string `prop:"user_id" validate:"uuid"`
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+	Id        string `params:"image_id" validate:"uuid" db:"id"`
+
+	AltText   *string `json:"alt_text" validate:"omitempty,max=255" db:"alt_text"`
+	IsPrimary *bool   `json:"is_primary" db:"is_primary"` // only true is accepted, it moves the primary flag
+}
+
+type ReorderImagesRequest struct {
+	UserId    string `prop:"user_id" validate:"uuid"`
+	ProductId string `params:"id" validate:"uuid"`
+
+	ImageIds []string `json:"image_ids" validate:"required,min=1,unique_in_slice,dive,uuid"`
+}
+
+type DeleteImageRequest struct {
+	UserId    string `prop:"user_id" validate:"uuid"`
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+	Id        string `params:"image_id" validate:"uuid" db:"id"`
+}
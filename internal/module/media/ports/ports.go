@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"codebase-app/internal/module/media/entity"
+	"context"
+)
+
+type MediaRepository interface {
+	GetImages(ctx context.Context, req *entity.ImagesRequest) (*entity.ImagesResponse, error)
+	CreateImage(ctx context.Context, req *entity.CreateImageRequest) (*entity.ImageItem, error)
+	UpdateImage(ctx context.Context, req *entity.UpdateImageRequest) (*entity.ImageItem, error)
+	ReorderImages(ctx context.Context, req *entity.ReorderImagesRequest) (*entity.ImagesResponse, error)
+	// DeleteImage removes the image row and returns the stored file name.
+	DeleteImage(ctx context.Context, req *entity.DeleteImageRequest) (string, error)
+
+	IsProductOwner(ctx context.Context, userId, productId string) (bool, error)
+}
+
+type MediaService interface {
+	GetImages(ctx context.Context, req *entity.ImagesRequest) (*entity.ImagesResponse, error)
+	UploadImage(ctx context.Context, req *entity.UploadImageRequest) (*entity.ImageItem, error)
+	UploadBase64Image(ctx context.Context, req *entity.UploadBase64ImageRequest) (*entity.ImageItem, error)
+	UpdateImage(ctx context.Context, req *entity.UpdateImageRequest) (*entity.ImageItem, error)
+	ReorderImages(ctx context.Context, req *entity.ReorderImagesRequest) (*entity.ImagesResponse, error)
+	DeleteImage(ctx context.Context, req *entity.DeleteImageRequest) error
+}
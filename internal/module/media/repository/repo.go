@@ -0,0 +1,324 @@
+package repository
+
+import (
+	"codebase-app/internal/module/media/entity"
+	"codebase-app/internal/module/media/ports"
+	"codebase-app/pkg/errmsg"
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.MediaRepository = &mediaRepository{}
+
+type mediaRepository struct {
+	db *sqlx.DB
+}
+
+func NewMediaRepository(db *sqlx.DB) *mediaRepository {
+	return &mediaRepository{
+		db: db,
+	}
+}
+
+func (r *mediaRepository) GetImages(ctx context.Context, req *entity.ImagesRequest) (*entity.ImagesResponse, error) {
+	var resp = new(entity.ImagesResponse)
+	resp.Items = make([]entity.ImageItem, 0)
+
+	query := `
+		SELECT id, product_id, url, alt_text, position, is_primary, created_at
+		FROM product_images
+		WHERE product_id = ?
+		ORDER BY position ASC, created_at ASC
+	`
+
+	err := r.db.SelectContext(ctx, &resp.Items, r.db.Rebind(query), req.ProductId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetImages - Failed to get images")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *mediaRepository) CreateImage(ctx context.Context, req *entity.CreateImageRequest) (*entity.ImageItem, error) {
+	var resp = new(entity.ImageItem)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateImage - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// serialize gallery writes of the same product
+	if err := lockProduct(ctx, tx, req.ProductId); err != nil {
+		return nil, err
+	}
+
+	var (
+		position  int
+		hasImages bool
+	)
+
+	query := `
+		SELECT COALESCE(MAX(position) + 1, 0), COUNT(id) > 0
+		FROM product_images
+		WHERE product_id = ?
+	`
+
+	err = tx.QueryRowxContext(ctx, tx.Rebind(query), req.ProductId).Scan(&position, &hasImages)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateImage - Failed to get image position")
+		return nil, err
+	}
+
+	// the first image of a gallery is always the primary one
+	isPrimary := req.IsPrimary || !hasImages
+	if isPrimary && hasImages {
+		if err := unsetPrimary(ctx, tx, req.ProductId); err != nil {
+			return nil, err
+		}
+	}
+
+	query = `
+		INSERT INTO product_images (product_id, file_name, url, alt_text, position, is_primary)
+		VALUES (?, ?, ?, ?, ?, ?)
+		RETURNING id, product_id, url, alt_text, position, is_primary, created_at
+	`
+
+	err = tx.QueryRowxContext(ctx, tx.Rebind(query),
+		req.ProductId,
+		req.FileName,
+		req.Url,
+		req.AltText,
+		position,
+		isPrimary).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateImage - Failed to create image")
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateImage - Failed to commit transaction")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *mediaRepository) UpdateImage(ctx context.Context, req *entity.UpdateImageRequest) (*entity.ImageItem, error) {
+	var resp = new(entity.ImageItem)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateImage - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := lockProduct(ctx, tx, req.ProductId); err != nil {
+		return nil, err
+	}
+
+	isPrimary := req.IsPrimary != nil && *req.IsPrimary
+	if isPrimary {
+		if err := unsetPrimary(ctx, tx, req.ProductId); err != nil {
+			return nil, err
+		}
+	}
+
+	query := `
+		UPDATE product_images
+		SET
+			alt_text = COALESCE(?, alt_text),
+			is_primary = is_primary OR ?,
+			updated_at = NOW()
+		WHERE id = ? AND product_id = ?
+		RETURNING id, product_id, url, alt_text, position, is_primary, created_at
+	`
+
+	err = tx.QueryRowxContext(ctx, tx.Rebind(query),
+		req.AltText,
+		isPrimary,
+		req.Id,
+		req.ProductId).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateImage - Failed to update image")
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateImage - Failed to commit transaction")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *mediaRepository) ReorderImages(ctx context.Context, req *entity.ReorderImagesRequest) (*entity.ImagesResponse, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::ReorderImages - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := lockProduct(ctx, tx, req.ProductId); err != nil {
+		return nil, err
+	}
+
+	var total int
+	err = tx.GetContext(ctx, &total, tx.Rebind("SELECT COUNT(id) FROM product_images WHERE product_id = ?"), req.ProductId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::ReorderImages - Failed to count images")
+		return nil, err
+	}
+
+	if total != len(req.ImageIds) {
+		log.Warn().Any("payload", req).Msg("repository::ReorderImages - Image ids don't match the gallery")
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("image_ids", "image ids harus berisi semua gambar produk."))
+	}
+
+	query := `
+		UPDATE product_images
+		SET position = ?, updated_at = NOW()
+		WHERE id = ? AND product_id = ?
+	`
+
+	for position, id := range req.ImageIds {
+		res, err := tx.ExecContext(ctx, tx.Rebind(query), position, id, req.ProductId)
+		if err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::ReorderImages - Failed to update image position")
+			return nil, err
+		}
+
+		if affected, _ := res.RowsAffected(); affected == 0 {
+			log.Warn().Any("payload", req).Msg("repository::ReorderImages - Image doesn't belong to the product")
+			return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("image_ids", "image ids harus berisi semua gambar produk."))
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::ReorderImages - Failed to commit transaction")
+		return nil, err
+	}
+
+	return r.GetImages(ctx, &entity.ImagesRequest{ProductId: req.ProductId})
+}
+
+func (r *mediaRepository) DeleteImage(ctx context.Context, req *entity.DeleteImageRequest) (string, error) {
+	var (
+		fileName  string
+		isPrimary bool
+	)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteImage - Failed to begin transaction")
+		return "", err
+	}
+	defer tx.Rollback()
+
+	if err := lockProduct(ctx, tx, req.ProductId); err != nil {
+		return "", err
+	}
+
+	query := `
+		DELETE FROM product_images
+		WHERE id = ? AND product_id = ?
+		RETURNING file_name, is_primary
+	`
+
+	err = tx.QueryRowxContext(ctx, tx.Rebind(query), req.Id, req.ProductId).Scan(&fileName, &isPrimary)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteImage - Failed to delete image")
+		return "", err
+	}
+
+	// hand the primary flag over to the next image in the gallery
+	if isPrimary {
+		query = `
+			UPDATE product_images
+			SET is_primary = TRUE, updated_at = NOW()
+			WHERE id = (
+				SELECT id FROM product_images
+				WHERE product_id = ?
+				ORDER BY position ASC, created_at ASC
+				LIMIT 1
+			)
+		`
+
+		if _, err := tx.ExecContext(ctx, tx.Rebind(query), req.ProductId); err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::DeleteImage - Failed to promote primary image")
+			return "", err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteImage - Failed to commit transaction")
+		return "", err
+	}
+
+	return fileName, nil
+}
+
+func (r *mediaRepository) IsProductOwner(ctx context.Context, userId, productId string) (bool, error) {
+	var (
+		isOwner bool
+		payload = struct {
+			UserId    string `json:"user_id"`
+			ProductId string `json:"product_id"`
+		}{userId, productId}
+	)
+
+	query := `
+		SELECT
+			EXISTS (
+				SELECT 1
+				FROM
+					products
+				LEFT JOIN
+					shops ON products.shop_id = shops.id
+				WHERE
+					shops.user_id = $1
+					AND products.id = $2
+					AND products.deleted_at IS NULL
+			)
+	`
+
+	err := r.db.GetContext(ctx, &isOwner, query, userId, productId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", payload).Msg("repository: IsProductOwner failed")
+		return isOwner, err
+	}
+
+	return isOwner, nil
+}
+
+func lockProduct(ctx context.Context, tx *sqlx.Tx, productId string) error {
+	_, err := tx.ExecContext(ctx, tx.Rebind("SELECT id FROM products WHERE id = ? FOR UPDATE"), productId)
+	if err != nil {
+		log.Error().Err(err).Str("product_id", productId).Msg("repository::lockProduct - Failed to lock product")
+		return err
+	}
+
+	return nil
+}
+
+func unsetPrimary(ctx context.Context, tx *sqlx.Tx, productId string) error {
+	query := `
+		UPDATE product_images
+		SET is_primary = FALSE, updated_at = NOW()
+		WHERE product_id = ? AND is_primary
+	`
+
+	_, err := tx.ExecContext(ctx, tx.Rebind(query), productId)
+	if err != nil {
+		log.Error().Err(err).Str("product_id", productId).Msg("repository::unsetPrimary - Failed to unset primary image")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	integration "codebase-app/internal/integration/filestorage"
+	storageEntity "codebase-app/internal/integration/filestorage/entity"
+	"codebase-app/internal/module/media/entity"
+	"codebase-app/internal/module/media/ports"
+	"codebase-app/pkg/errmsg"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.MediaService = &mediaService{}
+
+type mediaService struct {
+	repo    ports.MediaRepository
+	storage integration.FileStorageContract
+}
+
+func NewMediaService(repo ports.MediaRepository, storage integration.FileStorageContract) *mediaService {
+	return &mediaService{
+		repo:    repo,
+		storage: storage,
+	}
+}
+
+func (s *mediaService) GetImages(ctx context.Context, req *entity.ImagesRequest) (*entity.ImagesResponse, error) {
+	return s.repo.GetImages(ctx, req)
+}
+
+func (s *mediaService) UploadImage(ctx context.Context, req *entity.UploadImageRequest) (*entity.ImageItem, error) {
+	if err := s.checkProductOwner(ctx, req.UserId, req.ProductId); err != nil {
+		return nil, err
+	}
+
+	file, err := req.File.Open()
+	if err != nil {
+		log.Error().Err(err).Str("product_id", req.ProductId).Msg("service::UploadImage - Failed to open file")
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		log.Error().Err(err).Str("product_id", req.ProductId).Msg("service::UploadImage - Failed to read file")
+		return nil, err
+	}
+
+	return s.createImage(ctx, data, &entity.CreateImageRequest{
+		ProductId: req.ProductId,
+		AltText:   req.AltText,
+		IsPrimary: req.IsPrimary,
+	})
+}
+
+func (s *mediaService) UploadBase64Image(ctx context.Context, req *entity.UploadBase64ImageRequest) (*entity.ImageItem, error) {
+	if err := s.checkProductOwner(ctx, req.UserId, req.ProductId); err != nil {
+		return nil, err
+	}
+
+	// trim base64 prefix if present (e.g., "data:image/png;base64,")
+	encoded := req.Data
+	if idx := strings.Index(encoded, ","); idx != -1 {
+		encoded = encoded[idx+1:]
+	}
+
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		log.Warn().Err(err).Str("product_id", req.ProductId).Msg("service::UploadBase64Image - Invalid base64 data")
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("data", "data harus berupa base64 yang valid."))
+	}
+
+	return s.createImage(ctx, data, &entity.CreateImageRequest{
+		ProductId: req.ProductId,
+		AltText:   req.AltText,
+		IsPrimary: req.IsPrimary,
+	})
+}
+
+func (s *mediaService) UpdateImage(ctx context.Context, req *entity.UpdateImageRequest) (*entity.ImageItem, error) {
+	if err := s.checkProductOwner(ctx, req.UserId, req.ProductId); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.UpdateImage(ctx, req)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Image not found"))
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *mediaService) ReorderImages(ctx context.Context, req *entity.ReorderImagesRequest) (*entity.ImagesResponse, error) {
+	if err := s.checkProductOwner(ctx, req.UserId, req.ProductId); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ReorderImages(ctx, req)
+}
+
+func (s *mediaService) DeleteImage(ctx context.Context, req *entity.DeleteImageRequest) error {
+	if err := s.checkProductOwner(ctx, req.UserId, req.ProductId); err != nil {
+		return err
+	}
+
+	fileName, err := s.repo.DeleteImage(ctx, req)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errmsg.NewCustomErrors(404, errmsg.WithMessage("Image not found"))
+		}
+		return err
+	}
+
+	return s.storage.Delete(ctx, &storageEntity.DeleteFileRequest{FileName: fileName})
+}
+
+// createImage stores the file first and only then inserts the row, the stored
+// object is removed again when the insert fails so no orphan is left behind.
+func (s *mediaService) createImage(ctx context.Context, data []byte, req *entity.CreateImageRequest) (*entity.ImageItem, error) {
+	file, err := s.storage.Put(ctx, &storageEntity.PutFileRequest{
+		Data: data,
+		Dir:  "products/" + req.ProductId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req.FileName = file.FileName
+	req.Url = file.Url
+
+	res, err := s.repo.CreateImage(ctx, req)
+	if err != nil {
+		if errDelete := s.storage.Delete(ctx, &storageEntity.DeleteFileRequest{FileName: file.FileName}); errDelete != nil {
+			log.Error().Err(errDelete).Str("file_name", file.FileName).Msg("service::createImage - Failed to delete orphan file")
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *mediaService) checkProductOwner(ctx context.Context, userId, productId string) error {
+	isProductOwner, err := s.repo.IsProductOwner(ctx, userId, productId)
+	if err != nil {
+		return err
+	}
+
+	if !isProductOwner {
+		log.Warn().Str("user_id", userId).Str("product_id", productId).Msg("service: User is not product owner")
+		return errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not product owner"))
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	storageEntity "codebase-app/internal/integration/filestorage/entity"
+	"codebase-app/internal/module/media/entity"
+	"codebase-app/internal/module/media/ports"
+	mockStorage "codebase-app/mock/integration/filestorage"
+	mockPort "codebase-app/mock/module/media/ports"
+	"codebase-app/pkg/errmsg"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServiceList struct {
+	suite.Suite
+	mockMediaRepo   *mockPort.MockMediaRepo
+	mockFileStorage *mockStorage.MockFileStorage
+	service         ports.MediaService
+
+	mockUploadBase64ImageReq *entity.UploadBase64ImageRequest
+}
+
+func (suite *ServiceList) SetupTest() {
+	suite.mockMediaRepo = new(mockPort.MockMediaRepo)
+	suite.mockFileStorage = new(mockStorage.MockFileStorage)
+	suite.service = NewMediaService(suite.mockMediaRepo, suite.mockFileStorage)
+	suite.mockUploadBase64ImageReq = &entity.UploadBase64ImageRequest{
+		UserId:    "1",
+		ProductId: "2",
+		Data:      "data:image/png;base64,aW1hZ2U=",
+		AltText:   "front view",
+	}
+}
+
+func (suite *ServiceList) TestUploadBase64Image_Success() {
+	ctx := context.Background()
+	req := suite.mockUploadBase64ImageReq
+	file := storageEntity.PutFileResponse{FileName: "products/2/a.png", Url: "http://localhost/products/2/a.png"}
+
+	suite.mockMediaRepo.On("IsProductOwner", ctx, req.UserId, req.ProductId).Return(true, nil)
+	suite.mockFileStorage.On("Put", ctx, &storageEntity.PutFileRequest{Data: []byte("image"), Dir: "products/2"}).Return(file, nil)
+	suite.mockMediaRepo.On("CreateImage", ctx, &entity.CreateImageRequest{
+		ProductId: req.ProductId,
+		FileName:  file.FileName,
+		Url:       file.Url,
+		AltText:   req.AltText,
+	}).Return(entity.ImageItem{Id: "3", Url: file.Url, IsPrimary: true}, nil)
+	res, err := suite.service.UploadBase64Image(ctx, req)
+
+	suite.Equal(nil, err)
+	suite.Equal("3", res.Id)
+	suite.Equal(file.Url, res.Url)
+}
+
+func (suite *ServiceList) TestUploadBase64Image_InvalidData() {
+	ctx := context.Background()
+	req := suite.mockUploadBase64ImageReq
+	req.Data = "not base64!"
+	errBadRequest := errmsg.NewCustomErrors(400, errmsg.WithErrors("data", "data harus berupa base64 yang valid."))
+
+	suite.mockMediaRepo.On("IsProductOwner", ctx, req.UserId, req.ProductId).Return(true, nil)
+	_, err := suite.service.UploadBase64Image(ctx, req)
+
+	suite.Equal(errBadRequest, err)
+	suite.mockFileStorage.AssertNotCalled(suite.T(), "Put", mock.Anything, mock.Anything)
+}
+
+func (suite *ServiceList) TestUploadBase64Image_UserIsNotTheProductOwner() {
+	ctx := context.Background()
+	req := suite.mockUploadBase64ImageReq
+	errForbidden := errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not product owner"))
+
+	suite.mockMediaRepo.On("IsProductOwner", ctx, req.UserId, req.ProductId).Return(false, nil)
+	_, err := suite.service.UploadBase64Image(ctx, req)
+
+	suite.Equal(errForbidden, err)
+	suite.mockFileStorage.AssertNotCalled(suite.T(), "Put", mock.Anything, mock.Anything)
+}
+
+func (suite *ServiceList) TestUploadBase64Image_CreateImageErrorDeletesFile() {
+	ctx := context.Background()
+	req := suite.mockUploadBase64ImageReq
+	file := storageEntity.PutFileResponse{FileName: "products/2/a.png", Url: "http://localhost/products/2/a.png"}
+
+	suite.mockMediaRepo.On("IsProductOwner", ctx, req.UserId, req.ProductId).Return(true, nil)
+	suite.mockFileStorage.On("Put", ctx, mock.Anything).Return(file, nil)
+	suite.mockMediaRepo.On("CreateImage", ctx, mock.Anything).Return(nil, errors.New(mock.Anything))
+	suite.mockFileStorage.On("Delete", ctx, &storageEntity.DeleteFileRequest{FileName: file.FileName}).Return(nil)
+	_, err := suite.service.UploadBase64Image(ctx, req)
+
+	suite.Equal(errors.New(mock.Anything), err)
+	suite.mockFileStorage.AssertCalled(suite.T(), "Delete", ctx, &storageEntity.DeleteFileRequest{FileName: file.FileName})
+}
+
+func (suite *ServiceList) TestDeleteImage_Success() {
+	ctx := context.Background()
+	req := &entity.DeleteImageRequest{
+		UserId:    "1",
+		ProductId: "2",
+		Id:        "3",
+	}
+
+	suite.mockMediaRepo.On("IsProductOwner", ctx, req.UserId, req.ProductId).Return(true, nil)
+	suite.mockMediaRepo.On("DeleteImage", ctx, req).Return("products/2/a.png", nil)
+	suite.mockFileStorage.On("Delete", ctx, &storageEntity.DeleteFileRequest{FileName: "products/2/a.png"}).Return(nil)
+	err := suite.service.DeleteImage(ctx, req)
+
+	suite.Equal(nil, err)
+}
+
+func (suite *ServiceList) TestDeleteImage_NotFound() {
+	ctx := context.Background()
+	req := &entity.DeleteImageRequest{
+		UserId:    "1",
+		ProductId: "2",
+		Id:        "3",
+	}
+	errNotFound := errmsg.NewCustomErrors(404, errmsg.WithMessage("Image not found"))
+
+	suite.mockMediaRepo.On("IsProductOwner", ctx, req.UserId, req.ProductId).Return(true, nil)
+	suite.mockMediaRepo.On("DeleteImage", ctx, req).Return("", sql.ErrNoRows)
+	err := suite.service.DeleteImage(ctx, req)
+
+	suite.Equal(errNotFound, err)
+	suite.mockFileStorage.AssertNotCalled(suite.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func TestService(t *testing.T) {
+	suite.Run(t, new(ServiceList))
+}
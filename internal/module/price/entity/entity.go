@@ -0,0 +1,118 @@
+package entity
+
+import (
+	"codebase-app/pkg/types"
+	"time"
+)
+
+// LowestPriceDays is the window of the lowest price shown next to a
+// discounted price.
+const LowestPriceDays = 30
+
+const (
+	ScheduleScheduled = "scheduled"
+	ScheduleActive    = "active"
+	ScheduleCompleted = "completed"
+	ScheduleCancelled = "cancelled"
+)
+
+// StatusPublished is the product status visible to everyone.
+const StatusPublished = "published"
+
+// Product is the part of a product the price endpoints need.
+type Product struct {
+	Id     string  `db:"id"`
+	UserId string  `db:"user_id"`
+	Status string  `db:"status"`
+	Price  float64 `db:"price"`
+}
+
+type PriceHistoryItem struct {
+	Price         float64   `json:"price" db:"price"`
+	PreviousPrice *float64  `json:"previous_price" db:"previous_price"`
+	ChangedAt     time.Time `json:"changed_at" db:"changed_at"`
+}
+
+type PriceHistoryRequest struct {
+	UserId    string `prop:"user_id" validate:"uuid"`
+	ProductId string `params:"id" validate:"uuid"`
+	Page      int    `query:"page" validate:"required"`
+	Paginate  int    `query:"paginate" validate:"required"`
+}
+
+func (r *PriceHistoryRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type PriceHistoryResponse struct {
+	CurrentPrice float64            `json:"current_price"`
+	LowestPrice  float64            `json:"lowest_price_30d" db:"lowest_price"` // lowest price of the last LowestPriceDays days
+	Items        []PriceHistoryItem `json:"items"`
+	Meta         types.Meta         `json:"meta"`
+}
+
+type PriceScheduleItem struct {
+	Id            string     `json:"id" db:"id"`
+	ProductId     string     `json:"product_id" db:"product_id"`
+	Price         float64    `json:"price" db:"price"`
+	PreviousPrice *float64   `json:"previous_price" db:"previous_price"`
+	StartsAt      time.Time  `json:"starts_at" db:"starts_at"`
+	EndsAt        *time.Time `json:"ends_at" db:"ends_at"`
+	Status        string     `json:"status" db:"status"`
+	CreatedBy     string     `json:"created_by" db:"created_by"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// Overlaps reports whether the schedule is still pending and its window
+// overlaps the one from startsAt to endsAt, a nil end being open. Applied
+// permanent changes are completed and don't block later schedules.
+func (s PriceScheduleItem) Overlaps(startsAt time.Time, endsAt *time.Time) bool {
+	pending := s.Status == ScheduleScheduled || (s.Status == ScheduleActive && s.EndsAt != nil)
+	if !pending {
+		return false
+	}
+
+	return (endsAt == nil || s.StartsAt.Before(*endsAt)) && (s.EndsAt == nil || s.EndsAt.After(startsAt))
+}
+
+type PriceSchedulesRequest struct {
+	UserId    string `prop:"user_id" validate:"uuid"`
+	ProductId string `params:"id" validate:"uuid"`
+	Status    string `query:"status" validate:"omitempty,oneof=scheduled active completed cancelled"`
+}
+
+type PriceSchedulesResponse struct {
+	Items []PriceScheduleItem `json:"items"`
+}
+
+// CreatePriceScheduleRequest sets the product price to Price from StartsAt,
+// and back to the replaced price at EndsAt. Without EndsAt the change is
+// permanent.
+type CreatePriceScheduleRequest struct {
+	UserId    string `prop:"user_id" validate:"uuid" db:"created_by"`
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+
+	Price    float64    `json:"price" validate:"required,gt=0" db:"price"`
+	StartsAt time.Time  `json:"starts_at" validate:"required" db:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at" db:"ends_at"`
+}
+
+type CancelPriceScheduleRequest struct {
+	UserId    string `prop:"user_id" validate:"uuid"`
+	ProductId string `params:"id" validate:"uuid"`
+	Id        string `params:"schedule_id" validate:"uuid"`
+}
+
+// PriceScheduleResult is the number of product prices changed by a
+// scheduler run.
+type PriceScheduleResult struct {
+	Started  int64
+	Restored int64
+}
@@ -0,0 +1,41 @@
+package job
+
+import (
+	"codebase-app/internal/adapter"
+	"codebase-app/internal/infrastructure/config"
+	"codebase-app/internal/module/price/ports"
+	"codebase-app/internal/module/price/repository"
+	"codebase-app/internal/module/price/service"
+	"codebase-app/pkg/scheduler"
+	"context"
+	"time"
+)
+
+type priceJob struct {
+	service ports.PriceService
+}
+
+func NewPriceJob() *priceJob {
+	var (
+		job     = new(priceJob)
+		repo    = repository.NewPriceRepository(adapter.Adapters.ShopeefunPostgres)
+		service = service.NewPriceService(repo)
+	)
+	job.service = service
+
+	return job
+}
+
+func (j *priceJob) Register(s *scheduler.Scheduler) {
+	s.Register(scheduler.Job{
+		Name:     "price.apply_schedules",
+		Interval: time.Duration(config.Envs.Scheduler.Interval) * time.Second,
+		Run:      j.ApplyPriceSchedules,
+	})
+}
+
+// ApplyPriceSchedules starts and ends the scheduled price changes that are due.
+func (j *priceJob) ApplyPriceSchedules(ctx context.Context) error {
+	_, err := j.service.ApplyPriceSchedules(ctx)
+	return err
+}
@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"codebase-app/internal/module/price/entity"
+	"context"
+)
+
+type PriceRepository interface {
+	GetProduct(ctx context.Context, productId string) (*entity.Product, error)
+	GetPriceHistory(ctx context.Context, req *entity.PriceHistoryRequest) (*entity.PriceHistoryResponse, error)
+
+	GetPriceSchedules(ctx context.Context, req *entity.PriceSchedulesRequest) (*entity.PriceSchedulesResponse, error)
+	GetPriceSchedule(ctx context.Context, productId, id string) (*entity.PriceScheduleItem, error)
+	CreatePriceSchedule(ctx context.Context, req *entity.CreatePriceScheduleRequest) (*entity.PriceScheduleItem, error)
+	CancelPriceSchedule(ctx context.Context, req *entity.CancelPriceScheduleRequest) (*entity.PriceScheduleItem, error)
+	ApplyPriceSchedules(ctx context.Context) (*entity.PriceScheduleResult, error)
+
+	IsProductOwner(ctx context.Context, userId, productId string) (bool, error)
+}
+
+type PriceService interface {
+	GetPriceHistory(ctx context.Context, req *entity.PriceHistoryRequest) (*entity.PriceHistoryResponse, error)
+
+	GetPriceSchedules(ctx context.Context, req *entity.PriceSchedulesRequest) (*entity.PriceSchedulesResponse, error)
+	CreatePriceSchedule(ctx context.Context, req *entity.CreatePriceScheduleRequest) (*entity.PriceScheduleItem, error)
+	CancelPriceSchedule(ctx context.Context, req *entity.CancelPriceScheduleRequest) (*entity.PriceScheduleItem, error)
+	ApplyPriceSchedules(ctx context.Context) (*entity.PriceScheduleResult, error)
+}
@@ -0,0 +1,334 @@
+package repository
+
+import (
+	"codebase-app/internal/module/price/entity"
+	"codebase-app/internal/module/price/ports"
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.PriceRepository = &priceRepository{}
+
+type priceRepository struct {
+	db *sqlx.DB
+}
+
+func NewPriceRepository(db *sqlx.DB) *priceRepository {
+	return &priceRepository{
+		db: db,
+	}
+}
+
+func (r *priceRepository) GetProduct(ctx context.Context, productId string) (*entity.Product, error) {
+	var resp = new(entity.Product)
+
+	query := `SELECT id, user_id, status, price FROM products WHERE id = ? AND deleted_at IS NULL`
+
+	err := r.db.GetContext(ctx, resp, r.db.Rebind(query), productId)
+	if err != nil {
+		log.Error().Err(err).Str("product_id", productId).Msg("repository::GetProduct - Failed to get product")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *priceRepository) GetPriceHistory(ctx context.Context, req *entity.PriceHistoryRequest) (*entity.PriceHistoryResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.PriceHistoryItem
+	}
+
+	var (
+		resp = new(entity.PriceHistoryResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.PriceHistoryItem, 0, req.Paginate)
+
+	query := `
+		SELECT
+			COUNT(h.id) OVER() as total_data,
+			h.price,
+			h.previous_price,
+			h.changed_at
+		FROM product_price_history h
+		WHERE h.product_id = ?
+		ORDER BY h.changed_at DESC, h.id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query),
+		req.ProductId,
+		req.Paginate,
+		req.Paginate*(req.Page-1))
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetPriceHistory - Failed to get price history")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.PriceHistoryItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	// the price in effect when the window opened counts as well
+	query = `
+		SELECT COALESCE(MIN(price), 0) AS lowest_price
+		FROM (
+			SELECT price
+			FROM product_price_history
+			WHERE product_id = ? AND changed_at >= NOW() - make_interval(days => ?)
+			UNION ALL
+			(
+				SELECT price
+				FROM product_price_history
+				WHERE product_id = ? AND changed_at < NOW() - make_interval(days => ?)
+				ORDER BY changed_at DESC, id DESC
+				LIMIT 1
+			)
+		) prices
+	`
+
+	err = r.db.GetContext(ctx, &resp.LowestPrice, r.db.Rebind(query),
+		req.ProductId,
+		entity.LowestPriceDays,
+		req.ProductId,
+		entity.LowestPriceDays)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetPriceHistory - Failed to get lowest price")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+const priceScheduleColumns = `id, product_id, price, previous_price, starts_at, ends_at, status, created_by, created_at, updated_at`
+
+func (r *priceRepository) GetPriceSchedules(ctx context.Context, req *entity.PriceSchedulesRequest) (*entity.PriceSchedulesResponse, error) {
+	var resp = new(entity.PriceSchedulesResponse)
+	resp.Items = make([]entity.PriceScheduleItem, 0)
+
+	query := `
+		SELECT ` + priceScheduleColumns + `
+		FROM product_price_schedules
+		WHERE
+			product_id = ?
+			AND (?::TEXT = '' OR status = ?)
+		ORDER BY starts_at DESC, id DESC
+	`
+
+	err := r.db.SelectContext(ctx, &resp.Items, r.db.Rebind(query), req.ProductId, req.Status, req.Status)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetPriceSchedules - Failed to get price schedules")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *priceRepository) GetPriceSchedule(ctx context.Context, productId, id string) (*entity.PriceScheduleItem, error) {
+	var resp = new(entity.PriceScheduleItem)
+
+	query := `SELECT ` + priceScheduleColumns + ` FROM product_price_schedules WHERE id = ? AND product_id = ?`
+
+	err := r.db.GetContext(ctx, resp, r.db.Rebind(query), id, productId)
+	if err != nil {
+		log.Error().Err(err).Str("product_id", productId).Str("id", id).Msg("repository::GetPriceSchedule - Failed to get price schedule")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// CreatePriceSchedule returns sql.ErrNoRows when the window overlaps another
+// pending price change of the product, see entity.PriceScheduleItem.Overlaps.
+func (r *priceRepository) CreatePriceSchedule(ctx context.Context, req *entity.CreatePriceScheduleRequest) (*entity.PriceScheduleItem, error) {
+	var resp = new(entity.PriceScheduleItem)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreatePriceSchedule - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// serializes schedule writes of the product for the overlap check
+	query := `SELECT id FROM products WHERE id = ? FOR UPDATE`
+
+	if _, err := tx.ExecContext(ctx, tx.Rebind(query), req.ProductId); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreatePriceSchedule - Failed to lock product")
+		return nil, err
+	}
+
+	query = `
+		INSERT INTO product_price_schedules (product_id, price, starts_at, ends_at, created_by)
+		SELECT ?, ?, ?::TIMESTAMPTZ, ?::TIMESTAMPTZ, ?
+		WHERE NOT EXISTS (
+			SELECT 1
+			FROM product_price_schedules s
+			WHERE
+				s.product_id = ?
+				AND (s.status = 'scheduled' OR (s.status = 'active' AND s.ends_at IS NOT NULL))
+				AND s.starts_at < COALESCE(?::TIMESTAMPTZ, 'infinity')
+				AND COALESCE(s.ends_at, 'infinity') > ?::TIMESTAMPTZ
+		)
+		RETURNING ` + priceScheduleColumns
+
+	err = tx.QueryRowxContext(ctx, tx.Rebind(query),
+		req.ProductId,
+		req.Price,
+		req.StartsAt,
+		req.EndsAt,
+		req.UserId,
+		req.ProductId,
+		req.EndsAt,
+		req.StartsAt).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreatePriceSchedule - Failed to create price schedule")
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreatePriceSchedule - Failed to commit transaction")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// CancelPriceSchedule returns sql.ErrNoRows when the schedule already started.
+func (r *priceRepository) CancelPriceSchedule(ctx context.Context, req *entity.CancelPriceScheduleRequest) (*entity.PriceScheduleItem, error) {
+	var resp = new(entity.PriceScheduleItem)
+
+	query := `
+		UPDATE product_price_schedules
+		SET status = 'cancelled', updated_at = NOW()
+		WHERE id = ? AND product_id = ? AND status = 'scheduled'
+		RETURNING ` + priceScheduleColumns
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.Id, req.ProductId).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CancelPriceSchedule - Failed to cancel price schedule")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// ApplyPriceSchedules ends the due price changes before starting new ones,
+// so back to back windows hand over cleanly. An ended change only restores
+// the replaced price when the owner didn't set another price meanwhile.
+func (r *priceRepository) ApplyPriceSchedules(ctx context.Context) (*entity.PriceScheduleResult, error) {
+	var resp = new(entity.PriceScheduleResult)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::ApplyPriceSchedules - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		WITH ended AS (
+			UPDATE product_price_schedules
+			SET status = 'completed', updated_at = NOW()
+			WHERE status = 'active' AND ends_at <= NOW()
+			RETURNING product_id, price, previous_price
+		)
+		UPDATE products
+		SET price = ended.previous_price, updated_at = NOW(), version = version + 1
+		FROM ended
+		WHERE products.id = ended.product_id AND products.price = ended.price
+	`
+
+	res, err := tx.ExecContext(ctx, query)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::ApplyPriceSchedules - Failed to end price schedules")
+		return nil, err
+	}
+	resp.Restored, _ = res.RowsAffected()
+
+	// windows that passed while the scheduler wasn't running never start
+	query = `
+		UPDATE product_price_schedules
+		SET status = 'completed', updated_at = NOW()
+		WHERE status = 'scheduled' AND ends_at <= NOW()
+	`
+
+	if _, err := tx.ExecContext(ctx, query); err != nil {
+		log.Error().Err(err).Msg("repository::ApplyPriceSchedules - Failed to complete missed price schedules")
+		return nil, err
+	}
+
+	query = `
+		WITH started AS (
+			UPDATE product_price_schedules s
+			SET
+				-- a permanent change is done once applied, nothing restores it
+				status = CASE WHEN s.ends_at IS NULL THEN 'completed' ELSE 'active' END,
+				previous_price = p.price,
+				updated_at = NOW()
+			FROM products p
+			WHERE s.product_id = p.id AND s.status = 'scheduled' AND s.starts_at <= NOW() AND p.deleted_at IS NULL
+			RETURNING s.product_id, s.price
+		)
+		UPDATE products
+		SET price = started.price, updated_at = NOW(), version = version + 1
+		FROM started
+		WHERE products.id = started.product_id
+	`
+
+	res, err = tx.ExecContext(ctx, query)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::ApplyPriceSchedules - Failed to start price schedules")
+		return nil, err
+	}
+	resp.Started, _ = res.RowsAffected()
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("repository::ApplyPriceSchedules - Failed to commit transaction")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *priceRepository) IsProductOwner(ctx context.Context, userId, productId string) (bool, error) {
+	var (
+		isOwner bool
+		payload = struct {
+			UserId    string `json:"user_id"`
+			ProductId string `json:"product_id"`
+		}{userId, productId}
+	)
+
+	query := `
+		SELECT
+			EXISTS (
+				SELECT 1
+				FROM
+					products
+				LEFT JOIN
+					shops ON products.shop_id = shops.id
+				WHERE
+					shops.user_id = $1
+					AND products.id = $2
+					AND products.deleted_at IS NULL
+			)
+	`
+
+	err := r.db.GetContext(ctx, &isOwner, query, userId, productId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", payload).Msg("repository: IsProductOwner failed")
+		return isOwner, err
+	}
+
+	return isOwner, nil
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"codebase-app/internal/module/price/entity"
+	"codebase-app/internal/module/price/ports"
+	"codebase-app/pkg/errmsg"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.PriceService = &priceService{}
+
+type priceService struct {
+	repo ports.PriceRepository
+}
+
+func NewPriceService(repo ports.PriceRepository) *priceService {
+	return &priceService{
+		repo: repo,
+	}
+}
+
+// GetPriceHistory follows the visibility of GetProduct, only the owner sees
+// the history of a product that isn't published.
+func (s *priceService) GetPriceHistory(ctx context.Context, req *entity.PriceHistoryRequest) (*entity.PriceHistoryResponse, error) {
+	product, err := s.repo.GetProduct(ctx, req.ProductId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Product not found"))
+		}
+		return nil, err
+	}
+
+	if product.Status != entity.StatusPublished && product.UserId != req.UserId {
+		log.Warn().Any("payload", req).Str("status", product.Status).Msg("service: Product is not published")
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Product not found"))
+	}
+
+	res, err := s.repo.GetPriceHistory(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	res.CurrentPrice = product.Price
+
+	// no history yet, e.g. the product was created before the history existed
+	if res.LowestPrice == 0 {
+		res.LowestPrice = product.Price
+	}
+
+	return res, nil
+}
+
+func (s *priceService) GetPriceSchedules(ctx context.Context, req *entity.PriceSchedulesRequest) (*entity.PriceSchedulesResponse, error) {
+	if err := s.checkProductOwner(ctx, req.UserId, req.ProductId); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetPriceSchedules(ctx, req)
+}
+
+func (s *priceService) CreatePriceSchedule(ctx context.Context, req *entity.CreatePriceScheduleRequest) (*entity.PriceScheduleItem, error) {
+	if err := s.checkProductOwner(ctx, req.UserId, req.ProductId); err != nil {
+		return nil, err
+	}
+
+	errs := errmsg.NewCustomErrors(400)
+
+	if !req.StartsAt.After(time.Now()) {
+		errs.Add("starts_at", "starts at harus di masa depan.")
+	}
+
+	if req.EndsAt != nil && !req.EndsAt.After(req.StartsAt) {
+		errs.Add("ends_at", "ends at harus setelah starts at.")
+	}
+
+	if errs.HasErrors() {
+		log.Warn().Any("payload", req).Msg("service: Invalid price schedule")
+		return nil, errs
+	}
+
+	schedules, err := s.repo.GetPriceSchedules(ctx, &entity.PriceSchedulesRequest{UserId: req.UserId, ProductId: req.ProductId})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, schedule := range schedules.Items {
+		if schedule.Overlaps(req.StartsAt, req.EndsAt) {
+			log.Warn().Any("payload", req).Str("schedule_id", schedule.Id).Msg("service: Price schedule overlaps another schedule")
+			return nil, errmsg.NewCustomErrors(409, errmsg.WithErrors("starts_at", "jadwal harga bertabrakan dengan jadwal lain."))
+		}
+	}
+
+	// the repository checks again under a lock, another schedule may have
+	// been created meanwhile
+	res, err := s.repo.CreatePriceSchedule(ctx, req)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Warn().Any("payload", req).Msg("service: Price schedule overlaps another schedule")
+			return nil, errmsg.NewCustomErrors(409, errmsg.WithErrors("starts_at", "jadwal harga bertabrakan dengan jadwal lain."))
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *priceService) CancelPriceSchedule(ctx context.Context, req *entity.CancelPriceScheduleRequest) (*entity.PriceScheduleItem, error) {
+	if err := s.checkProductOwner(ctx, req.UserId, req.ProductId); err != nil {
+		return nil, err
+	}
+
+	schedule, err := s.repo.GetPriceSchedule(ctx, req.ProductId, req.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Price schedule not found"))
+		}
+		return nil, err
+	}
+
+	if schedule.Status != entity.ScheduleScheduled {
+		log.Warn().Any("payload", req).Str("status", schedule.Status).Msg("service: Price schedule can't be cancelled")
+		return nil, errmsg.NewCustomErrors(409, errmsg.WithMessage("Only scheduled price changes can be cancelled"))
+	}
+
+	res, err := s.repo.CancelPriceSchedule(ctx, req)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(409, errmsg.WithMessage("Price schedule has started, reload it and try again"))
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ApplyPriceSchedules is run by the scheduler, see handler/job.
+func (s *priceService) ApplyPriceSchedules(ctx context.Context) (*entity.PriceScheduleResult, error) {
+	res, err := s.repo.ApplyPriceSchedules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Started > 0 || res.Restored > 0 {
+		log.Info().Int64("started", res.Started).Int64("restored", res.Restored).Msg("service: Price schedules applied")
+	}
+
+	return res, nil
+}
+
+func (s *priceService) checkProductOwner(ctx context.Context, userId, productId string) error {
+	isProductOwner, err := s.repo.IsProductOwner(ctx, userId, productId)
+	if err != nil {
+		return err
+	}
+
+	if !isProductOwner {
+		log.Warn().Str("user_id", userId).Str("product_id", productId).Msg("service: User is not product owner")
+		return errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not product owner"))
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"codebase-app/internal/module/price/entity"
+	"codebase-app/internal/module/price/ports"
+	mockPort "codebase-app/mock/module/price/ports"
+	"codebase-app/pkg/errmsg"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServiceList struct {
+	suite.Suite
+	mockPriceRepo *mockPort.MockPriceRepo
+	service       ports.PriceService
+
+	mockCreatePriceScheduleReq *entity.CreatePriceScheduleRequest
+}
+
+func (suite *ServiceList) SetupTest() {
+	suite.mockPriceRepo = new(mockPort.MockPriceRepo)
+	suite.service = NewPriceService(suite.mockPriceRepo)
+	suite.mockCreatePriceScheduleReq = &entity.CreatePriceScheduleRequest{
+		UserId:    "1",
+		ProductId: "2",
+		Price:     90,
+		StartsAt:  time.Now().Add(time.Hour),
+	}
+}
+
+func (suite *ServiceList) TestGetPriceHistory_Success() {
+	ctx := context.Background()
+	req := &entity.PriceHistoryRequest{UserId: "9", ProductId: "2", Page: 1, Paginate: 10}
+
+	suite.mockPriceRepo.On("GetProduct", ctx, req.ProductId).Return(&entity.Product{Id: "2", UserId: "1", Status: "published", Price: 100}, nil)
+	suite.mockPriceRepo.On("GetPriceHistory", ctx, req).Return(&entity.PriceHistoryResponse{LowestPrice: 80}, nil)
+	res, err := suite.service.GetPriceHistory(ctx, req)
+
+	suite.Equal(nil, err)
+	suite.Equal(float64(100), res.CurrentPrice)
+	suite.Equal(float64(80), res.LowestPrice)
+}
+
+func (suite *ServiceList) TestGetPriceHistory_ProductNotPublished() {
+	ctx := context.Background()
+	req := &entity.PriceHistoryRequest{UserId: "9", ProductId: "2", Page: 1, Paginate: 10}
+	errNotFound := errmsg.NewCustomErrors(404, errmsg.WithMessage("Product not found"))
+
+	suite.mockPriceRepo.On("GetProduct", ctx, req.ProductId).Return(&entity.Product{Id: "2", UserId: "1", Status: "draft", Price: 100}, nil)
+	_, err := suite.service.GetPriceHistory(ctx, req)
+
+	suite.Equal(errNotFound, err)
+	suite.mockPriceRepo.AssertNotCalled(suite.T(), "GetPriceHistory", ctx, req)
+}
+
+func (suite *ServiceList) TestCreatePriceSchedule_Success() {
+	ctx := context.Background()
+	req := suite.mockCreatePriceScheduleReq
+
+	suite.mockPriceRepo.On("IsProductOwner", ctx, req.UserId, req.ProductId).Return(true, nil)
+	suite.mockPriceRepo.On("GetPriceSchedules", ctx, mock.Anything).Return(&entity.PriceSchedulesResponse{}, nil)
+	suite.mockPriceRepo.On("CreatePriceSchedule", ctx, req).Return(&entity.PriceScheduleItem{Id: "3", Status: entity.ScheduleScheduled}, nil)
+	res, err := suite.service.CreatePriceSchedule(ctx, req)
+
+	suite.Equal(nil, err)
+	suite.Equal("3", res.Id)
+}
+
+func (suite *ServiceList) TestCreatePriceSchedule_UserIsNotProductOwner() {
+	ctx := context.Background()
+	req := suite.mockCreatePriceScheduleReq
+	errForbidden := errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not product owner"))
+
+	suite.mockPriceRepo.On("IsProductOwner", ctx, req.UserId, req.ProductId).Return(false, nil)
+	_, err := suite.service.CreatePriceSchedule(ctx, req)
+
+	suite.Equal(errForbidden, err)
+	suite.mockPriceRepo.AssertNotCalled(suite.T(), "CreatePriceSchedule", ctx, req)
+}
+
+func (suite *ServiceList) TestCreatePriceSchedule_EndsBeforeStart() {
+	ctx := context.Background()
+	req := suite.mockCreatePriceScheduleReq
+	endsAt := req.StartsAt.Add(-time.Minute)
+	req.EndsAt = &endsAt
+	errBadRequest := errmsg.NewCustomErrors(400, errmsg.WithErrors("ends_at", "ends at harus setelah starts at."))
+
+	suite.mockPriceRepo.On("IsProductOwner", ctx, req.UserId, req.ProductId).Return(true, nil)
+	_, err := suite.service.CreatePriceSchedule(ctx, req)
+
+	suite.Equal(errBadRequest, err)
+	suite.mockPriceRepo.AssertNotCalled(suite.T(), "CreatePriceSchedule", ctx, req)
+}
+
+func (suite *ServiceList) TestCreatePriceSchedule_Overlap() {
+	ctx := context.Background()
+	req := suite.mockCreatePriceScheduleReq
+	errConflict := errmsg.NewCustomErrors(409, errmsg.WithErrors("starts_at", "jadwal harga bertabrakan dengan jadwal lain."))
+
+	suite.mockPriceRepo.On("IsProductOwner", ctx, req.UserId, req.ProductId).Return(true, nil)
+	suite.mockPriceRepo.On("GetPriceSchedules", ctx, mock.Anything).Return(&entity.PriceSchedulesResponse{}, nil)
+	suite.mockPriceRepo.On("CreatePriceSchedule", ctx, req).Return(nil, sql.ErrNoRows)
+	_, err := suite.service.CreatePriceSchedule(ctx, req)
+
+	suite.Equal(errConflict, err)
+}
+
+func (suite *ServiceList) TestCreatePriceSchedule_AfterPermanentChange() {
+	ctx := context.Background()
+	req := suite.mockCreatePriceScheduleReq
+	applied := entity.PriceScheduleItem{Id: "4", Status: entity.ScheduleCompleted, StartsAt: time.Now().Add(-time.Hour)}
+
+	suite.mockPriceRepo.On("IsProductOwner", ctx, req.UserId, req.ProductId).Return(true, nil)
+	suite.mockPriceRepo.On("GetPriceSchedules", ctx, mock.Anything).Return(&entity.PriceSchedulesResponse{Items: []entity.PriceScheduleItem{applied}}, nil)
+	suite.mockPriceRepo.On("CreatePriceSchedule", ctx, req).Return(&entity.PriceScheduleItem{Id: "3", Status: entity.ScheduleScheduled}, nil)
+	res, err := suite.service.CreatePriceSchedule(ctx, req)
+
+	suite.Equal(nil, err)
+	suite.Equal("3", res.Id)
+}
+
+func (suite *ServiceList) TestCreatePriceSchedule_PendingPermanentChange() {
+	ctx := context.Background()
+	req := suite.mockCreatePriceScheduleReq
+	pending := entity.PriceScheduleItem{Id: "4", Status: entity.ScheduleScheduled, StartsAt: req.StartsAt.Add(-time.Minute)}
+	errConflict := errmsg.NewCustomErrors(409, errmsg.WithErrors("starts_at", "jadwal harga bertabrakan dengan jadwal lain."))
+
+	suite.mockPriceRepo.On("IsProductOwner", ctx, req.UserId, req.ProductId).Return(true, nil)
+	suite.mockPriceRepo.On("GetPriceSchedules", ctx, mock.Anything).Return(&entity.PriceSchedulesResponse{Items: []entity.PriceScheduleItem{pending}}, nil)
+	_, err := suite.service.CreatePriceSchedule(ctx, req)
+
+	suite.Equal(errConflict, err)
+	suite.mockPriceRepo.AssertNotCalled(suite.T(), "CreatePriceSchedule", ctx, req)
+}
+
+func (suite *ServiceList) TestCancelPriceSchedule_AlreadyActive() {
+	ctx := context.Background()
+	req := &entity.CancelPriceScheduleRequest{UserId: "1", ProductId: "2", Id: "3"}
+	errConflict := errmsg.NewCustomErrors(409, errmsg.WithMessage("Only scheduled price changes can be cancelled"))
+
+	suite.mockPriceRepo.On("IsProductOwner", ctx, req.UserId, req.ProductId).Return(true, nil)
+	suite.mockPriceRepo.On("GetPriceSchedule", ctx, req.ProductId, req.Id).Return(&entity.PriceScheduleItem{Id: "3", Status: entity.ScheduleActive}, nil)
+	_, err := suite.service.CancelPriceSchedule(ctx, req)
+
+	suite.Equal(errConflict, err)
+	suite.mockPriceRepo.AssertNotCalled(suite.T(), "CancelPriceSchedule", mock.Anything, mock.Anything)
+}
+
+func TestService(t *testing.T) {
+	suite.Run(t, new(ServiceList))
+}
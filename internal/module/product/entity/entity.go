@@ -2,10 +2,15 @@ package entity
 
 import (
 	"codebase-app/pkg/types"
+	"fmt"
+	"io"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type Shop struct {
+	Slug        string `json:"slug" db:"slug"`
 	Name        string `json:"name" db:"name"`
 	Description string `json:"description" db:"description"`
 	Terms       string `json:"terms" db:"terms"`
@@ -15,23 +20,68 @@ type Category struct {
 	Name string `json:"name" db:"name"`
 }
 
+// ProductCategory is the category of a product detail, Breadcrumb lists the
+// category ancestors from the root down to the category itself.
+type ProductCategory struct {
+	Id         string          `json:"id" db:"id"`
+	Name       string          `json:"name" db:"name"`
+	Breadcrumb []CategoryCrumb `json:"breadcrumb" db:"-"`
+}
+
+type CategoryCrumb struct {
+	Id   string `json:"id" db:"id"`
+	Name string `json:"name" db:"name"`
+}
+
 type Brand struct {
 	Name string `json:"name" db:"name"`
 }
 
 type ProductsRequest struct {
-	UserId      string   `prop:"user_id" validate:"uuid"`
-	Page        int      `query:"page" validate:"required"`
-	Paginate    int      `query:"paginate" validate:"required"`
-	CategoryIds []string `query:"category_ids" validate:"omitempty,dive,uuid"`
-	BrandIds    []string `query:"brand_ids" validate:"omitempty,dive,uuid"`
-	MinPrice    *float64 `query:"min_price" validate:"omitempty,numeric,min=0"`
-	MaxPrice    *float64 `query:"max_price" validate:"omitempty,numeric,min=0"`
-	MinRating   float64  `query:"min_rating" validate:"omitempty,numeric,min=0"`
-	SearchQuery string   `query:"search_query" validate:"omitempty,min=3,max=255"`
-	IsAvailable bool     `query:"is_available" validate:"omitempty"`
+	UserId            string   `prop:"user_id" validate:"omitempty,uuid"`
+	Page              int      `query:"page" validate:"required"`
+	Paginate          int      `query:"paginate" validate:"required"`
+	CategoryIds       []string `query:"category_ids" validate:"omitempty,dive,uuid"`
+	WithSubcategories bool     `query:"with_subcategories" validate:"omitempty"` // also match descendants of CategoryIds
+	BrandIds          []string `query:"brand_ids" validate:"omitempty,dive,uuid"`
+	MinPrice          *float64 `query:"min_price" validate:"omitempty,numeric,min=0"`
+	MaxPrice          *float64 `query:"max_price" validate:"omitempty,numeric,min=0"`
+	MinRating         float64  `query:"min_rating" validate:"omitempty,numeric,min=0"`
+	SearchQuery       string   `query:"search_query" validate:"omitempty,min=3,max=255"`
+	IsAvailable       bool     `query:"is_available" validate:"omitempty"`
+	WithFacets        bool     `query:"with_facets" validate:"omitempty"`
+	Sort              string   `query:"sort" validate:"omitempty,oneof=price_asc price_desc rating reviews newest stock name relevance"`
+	Pagination        string   `query:"pagination" validate:"omitempty,oneof=offset cursor"`
+	Cursor            string   `query:"cursor" validate:"omitempty,base64rawurl"`
+	WithTotal         bool     `query:"with_total" validate:"omitempty"`
+	Status            string   `query:"status" validate:"omitempty,oneof=draft published archived"`
+	Attributes        []string `query:"attributes" validate:"omitempty,dive,contains=:"`        // key:value, values of one key are OR-ed
+	PriceBy           string   `query:"price_by" validate:"omitempty,oneof=original effective"` // price used by the price filter, sort and facets
+
+	After *types.Cursor `query:"-" validate:"-"` // decoded Cursor
 }
 
+const (
+	PriceByOriginal  = "original"
+	PriceByEffective = "effective"
+)
+
+const (
+	PaginationOffset = "offset"
+	PaginationCursor = "cursor"
+)
+
+const (
+	SortPriceAsc  = "price_asc"
+	SortPriceDesc = "price_desc"
+	SortRating    = "rating"
+	SortReviews   = "reviews"
+	SortNewest    = "newest"
+	SortStock     = "stock"
+	SortName      = "name"
+	SortRelevance = "relevance"
+)
+
 func (r *ProductsRequest) SetDefault() {
 	if r.Page < 1 {
 		r.Page = 1
@@ -40,24 +90,103 @@ func (r *ProductsRequest) SetDefault() {
 	if r.Paginate < 1 {
 		r.Paginate = 10
 	}
+
+	if r.Sort == "" {
+		r.Sort = SortNewest
+		if r.SearchQuery != "" {
+			r.Sort = SortRelevance
+		}
+	}
+
+	if r.Cursor != "" {
+		r.Pagination = PaginationCursor
+	}
+
+	if r.Pagination == "" {
+		r.Pagination = PaginationOffset
+	}
+
+	// listings keep filtering and sorting by the stored price, clients opt
+	// in to the promotion price with price_by=effective
+	if r.PriceBy == "" {
+		r.PriceBy = PriceByOriginal
+	}
+}
+
+func (r *ProductsRequest) IsCursor() bool {
+	return r.Pagination == PaginationCursor
 }
 
 type ProductItem struct {
-	Id          string   `json:"id" db:"id"`
-	Name        string   `json:"name" db:"name"`
-	Description string   `json:"description" db:"description"`
-	Price       float64  `json:"price" db:"price"`
-	Stock       int      `json:"stock" db:"stock"`
-	Rating      float64  `json:"rating" db:"rating"`
-	UserId      string   `json:"user_id" db:"user_id"`
-	Category    Category `json:"category"`
-	Shop        Shop     `json:"shop"`
-	Brand       Brand    `json:"brand"`
+	Id             string            `json:"id" db:"id"`
+	Slug           string            `json:"slug" db:"slug"`
+	Name           string            `json:"name" db:"name"`
+	Description    string            `json:"description" db:"description"`
+	Price          float64           `json:"price" db:"price"`                     // lowest original price, variants included
+	EffectivePrice float64           `json:"effective_price" db:"effective_price"` // lowest price after the applied promotion
+	Promotion      *AppliedPromotion `json:"promotion" db:"-"`
+	Stock          int               `json:"stock" db:"stock"`
+	Rating         float64           `json:"rating" db:"rating"`
+	ReviewCount    int               `json:"review_count" db:"review_count"`
+	ImageUrl       *string           `json:"image_url" db:"image_url"` // primary image
+	Status         string            `json:"status" db:"status"`
+	PublishAt      *time.Time        `json:"publish_at" db:"publish_at"`
+	UnpublishAt    *time.Time        `json:"unpublish_at" db:"unpublish_at"`
+	UserId         string            `json:"user_id" db:"user_id"`
+	Category       Category          `json:"category"`
+	Shop           Shop              `json:"shop"`
+	Brand          Brand             `json:"brand"`
+	Highlight      *ProductHighlight `json:"highlight,omitempty" db:"highlight"`
+}
+
+// AppliedPromotion is the shop promotion giving the effective price.
+type AppliedPromotion struct {
+	Id            string    `json:"id"`
+	Name          string    `json:"name"`
+	DiscountType  string    `json:"discount_type"` // percent or fixed
+	DiscountValue float64   `json:"discount_value"`
+	EndsAt        time.Time `json:"ends_at"`
+}
+
+// ProductHighlight holds search snippets with the matched terms wrapped in <mark> tags.
+type ProductHighlight struct {
+	Name        string `json:"name" db:"name"`
+	Description string `json:"description" db:"description"`
 }
 
 type ProductsResponse struct {
-	Items []ProductItem `json:"items"`
-	Meta  types.Meta    `json:"meta"`
+	Items  []ProductItem  `json:"items"`
+	Meta   types.Meta     `json:"meta"`
+	Facets *ProductFacets `json:"facets,omitempty"`
+}
+
+// PriceBucketBounds are the lower bounds of the price facet buckets. The last
+// bucket has no upper bound.
+var PriceBucketBounds = []float64{0, 50000, 100000, 250000, 500000, 1000000, 5000000}
+
+type ProductFacets struct {
+	Categories []FacetCount  `json:"categories"`
+	Brands     []FacetCount  `json:"brands"`
+	Prices     []PriceFacet  `json:"prices"`
+	Ratings    []RatingFacet `json:"ratings"`
+}
+
+type FacetCount struct {
+	Id    string `json:"id" db:"id"`
+	Name  string `json:"name" db:"name"`
+	Count int    `json:"count" db:"count"`
+}
+
+type PriceFacet struct {
+	Min   float64  `json:"min"`
+	Max   *float64 `json:"max"`
+	Count int      `json:"count"`
+}
+
+// RatingFacet counts the products rated at least Rating stars.
+type RatingFacet struct {
+	Rating int `json:"rating"`
+	Count  int `json:"count"`
 }
 
 type CreateProductRequest struct {
@@ -66,13 +195,17 @@ type CreateProductRequest struct {
 	BrandId     string  `json:"brand_id" validate:"required,uuid" db:"brand_id"`
 	Name        string  `json:"name" validate:"required" db:"name"`
 	Description string  `json:"description" validate:"required,max=255" db:"description"`
-	Price       float64 `json:"price" validate:"required" db:"price"`
-	Stock       int     `json:"stock" validate:"required,numeric" db:"stock"`
+	Price       float64 `json:"price" validate:"required,gt=0" db:"price"`
+	Stock       int     `json:"stock" validate:"min=0" db:"stock"`
 	UserId      string  `json:"user_id" validate:"uuid" db:"user_id"`
+	Status      string  `json:"status" validate:"omitempty,oneof=draft published" db:"status"` // draft when empty
+
+	Attributes types.JSONMap `json:"attributes" db:"attributes"` // validated against the category schema
 }
 
 type CreateProductResponse struct {
 	Id          string    `json:"id" db:"id"`
+	Slug        string    `json:"slug" db:"slug"`
 	UserId      string    `json:"user_id" db:"user_id"`
 	CategoryId  string    `json:"category_id" db:"category_id"`
 	BrandId     string    `json:"brand_id" db:"brand_id"`
@@ -85,22 +218,91 @@ type CreateProductResponse struct {
 }
 
 type GetProductRequest struct {
-	Id string `validate:"uuid" db:"id"`
+	UserId string `prop:"user_id"` // unpublished products are only visible to their owner
+	Id     string `validate:"required_without=Slug,omitempty,uuid" db:"id"`
+	Slug   string `validate:"omitempty,max=80" db:"-"` // set instead of Id when fetched by slug
 }
 
 type GetProductResponse struct {
-	Id          string   `json:"id" db:"id"`
-	Name        string   `json:"name" db:"name"`
-	Description string   `json:"description" db:"description"`
-	Price       float64  `json:"price" db:"price"`
-	Stock       int      `json:"stock" db:"stock"`
-	Rating      float64  `json:"rating" db:"rating"`
-	UserId      string   `json:"user_id" db:"user_id"`
-	Category    Category `json:"category"`
-	Shop        Shop     `json:"shop"`
-	Brand       Brand    `json:"brand"`
+	Id              string            `json:"id" db:"id"`
+	Slug            string            `json:"slug" db:"slug"` // an older slug redirects here
+	Name            string            `json:"name" db:"name"`
+	Description     string            `json:"description" db:"description"`
+	Price           float64           `json:"price" db:"price"`                     // original price
+	EffectivePrice  float64           `json:"effective_price" db:"effective_price"` // price after the applied promotion
+	Promotion       *AppliedPromotion `json:"promotion" db:"-"`
+	Stock           int               `json:"stock" db:"stock"`
+	AvailableStock  int               `json:"available_stock" db:"available_stock"` // stock not held by reservations
+	Rating          float64           `json:"rating" db:"rating"`
+	ReviewCount     int               `json:"review_count" db:"review_count"`
+	RatingHistogram RatingHistogram   `json:"rating_histogram" db:"rating_histogram"`
+	UserId          string            `json:"user_id" db:"user_id"`
+	ShopId          string            `json:"shop_id" db:"shop_id"`
+	BrandId         string            `json:"brand_id" db:"brand_id"`
+	Category        ProductCategory   `json:"category"`
+	Shop            Shop              `json:"shop"`
+	Brand           Brand             `json:"brand"`
+	Attributes      types.JSONMap     `json:"attributes" db:"attributes"`
+	Status          string            `json:"status" db:"status"`
+	PublishedAt     *time.Time        `json:"published_at" db:"published_at"`
+	PublishAt       *time.Time        `json:"publish_at" db:"publish_at"`
+	UnpublishAt     *time.Time        `json:"unpublish_at" db:"unpublish_at"`
+	ImageUrl        *string           `json:"image_url" db:"image_url"` // primary image
+	Variants        []ProductVariant  `json:"variants"`
+	Images          []ProductImage    `json:"images"`
+	CreatedAt       time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at" db:"updated_at"`
+	Version         int               `json:"version" db:"version"` // sent as the ETag header
+}
+
+// ProductVariant is a sellable variant of a product, Price falls back to the
+// product price when the variant has no override.
+type ProductVariant struct {
+	Id             string          `json:"id" db:"id"`
+	Sku            string          `json:"sku" db:"sku"`
+	Options        types.StringMap `json:"options" db:"options"`
+	Price          float64         `json:"price" db:"price"`
+	EffectivePrice float64         `json:"effective_price" db:"effective_price"`
+	PriceOverride  *float64        `json:"price_override" db:"price_override"`
+	Stock          int             `json:"stock" db:"stock"`
+	AvailableStock int             `json:"available_stock" db:"available_stock"`
+	Barcode        *string         `json:"barcode" db:"barcode"`
+}
+
+// RatingHistogram is the number of reviews per star.
+type RatingHistogram struct {
+	Star1 int `json:"1" db:"star_1"`
+	Star2 int `json:"2" db:"star_2"`
+	Star3 int `json:"3" db:"star_3"`
+	Star4 int `json:"4" db:"star_4"`
+	Star5 int `json:"5" db:"star_5"`
+}
+
+const (
+	AttributeTypeString  = "string"
+	AttributeTypeNumber  = "number"
+	AttributeTypeEnum    = "enum"
+	AttributeTypeBoolean = "boolean"
+)
+
+// AttributeSchema is an attribute products of a category can or must have.
+type AttributeSchema struct {
+	Key        string         `db:"key"`
+	Type       string         `db:"type"`
+	Options    pq.StringArray `db:"options"`
+	IsRequired bool           `db:"is_required"`
+}
+
+// ProductImage is an image of the product gallery, ordered by Position.
+type ProductImage struct {
+	Id        string `json:"id" db:"id"`
+	Url       string `json:"url" db:"url"`
+	AltText   string `json:"alt_text" db:"alt_text"`
+	Position  int    `json:"position" db:"position"`
+	IsPrimary bool   `json:"is_primary" db:"is_primary"`
 }
 
+// UpdateProductRequest replaces every editable field of a product (PUT).
 type UpdateProductRequest struct {
 	Id          string  `params:"id" validate:"uuid" db:"id"`
 	ShopId      string  `json:"shop_id" validate:"required,uuid" db:"shop_id"`
@@ -108,17 +310,380 @@ type UpdateProductRequest struct {
 	BrandId     string  `json:"brand_id" validate:"required,uuid" db:"brand_id"`
 	Name        string  `json:"name" validate:"required" db:"name"`
 	Description string  `json:"description" validate:"required,max=255" db:"description"`
-	Price       float64 `json:"price" validate:"required" db:"price"`
-	Stock       int     `json:"stock" validate:"required,numeric" db:"stock"`
+	Price       float64 `json:"price" validate:"required,gt=0" db:"price"`
+	Stock       int     `json:"stock" validate:"min=0" db:"stock"`
 	UserId      string  `json:"user_id" validate:"uuid" db:"user_id"`
+
+	Attributes types.JSONMap `json:"attributes" db:"attributes"` // validated against the category schema
+
+	IfMatch types.ETags `json:"-" validate:"-"` // parsed If-Match header, nil when absent
+	Version *int        `json:"-" validate:"-"` // version the write must hit, resolved from IfMatch
 }
 
 type UpdateProductResponse struct {
 	Id string `json:"id" db:"id"`
 }
 
+// PatchProductRequest is a JSON merge patch (RFC 7386) of a product, only
+// the fields listed in Fields are written. Null clears description and
+// attributes, the other fields can't be null. Attributes are merged into
+// the stored ones, a null attribute removes only that key.
+type PatchProductRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+	Id     string `params:"id" validate:"uuid" db:"id"`
+
+	ShopId      *string       `json:"shop_id" validate:"omitnil,uuid" db:"shop_id"`
+	CategoryId  *string       `json:"category_id" validate:"omitnil,uuid" db:"category_id"`
+	BrandId     *string       `json:"brand_id" validate:"omitnil,uuid" db:"brand_id"`
+	Name        *string       `json:"name" validate:"omitnil,min=1,max=255" db:"name"`
+	Description *string       `json:"description" validate:"omitnil,max=255" db:"description"`
+	Price       *float64      `json:"price" validate:"omitnil,gt=0" db:"price"`
+	Stock       *int          `json:"stock" validate:"omitnil,min=0" db:"stock"`
+	Attributes  types.JSONMap `json:"attributes" db:"attributes"` // validated against the category schema
+
+	Fields  types.PatchFields `json:"-" validate:"-"`
+	IfMatch types.ETags       `json:"-" validate:"-"` // parsed If-Match header, nil when absent
+	Version *int              `json:"-" validate:"-"` // version the write must hit, resolved from IfMatch
+}
+
+// PatchAllowed lists the fields a merge patch may carry.
+var PatchAllowed = []string{"shop_id", "category_id", "brand_id", "name", "description", "price", "stock", "attributes"}
+
+// PatchNullable lists the fields a merge patch may set to null.
+var PatchNullable = []string{"description", "attributes"}
+
+const (
+	StatusDraft     = "draft"
+	StatusPublished = "published"
+	StatusArchived  = "archived"
+)
+
+// StatusTransitions lists the statuses a product can move to from each status.
+var StatusTransitions = map[string][]string{
+	StatusDraft:     {StatusPublished, StatusArchived},
+	StatusPublished: {StatusDraft, StatusArchived},
+	StatusArchived:  {StatusDraft},
+}
+
+type TransitionProductRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+	Id     string `params:"id" validate:"uuid" db:"id"`
+	Status string `validate:"oneof=draft published archived" db:"status"`
+}
+
+type TransitionProductResponse struct {
+	Id          string     `json:"id" db:"id"`
+	Status      string     `json:"status" db:"status"`
+	PublishedAt *time.Time `json:"published_at" db:"published_at"`
+}
+
+type ScheduleProductRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+	Id     string `params:"id" validate:"uuid" db:"id"`
+
+	PublishAt   *time.Time `json:"publish_at" db:"publish_at"`     // publishes a draft, null cancels
+	UnpublishAt *time.Time `json:"unpublish_at" db:"unpublish_at"` // moves a published product back to draft, null cancels
+}
+
+type ScheduleProductResponse struct {
+	Id          string     `json:"id" db:"id"`
+	Status      string     `json:"status" db:"status"`
+	PublishAt   *time.Time `json:"publish_at" db:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at" db:"unpublish_at"`
+}
+
+// ScheduleResult is the number of products changed by a scheduler run.
+type ScheduleResult struct {
+	Published   int64
+	Unpublished int64
+}
+
 type DeleteProductRequest struct {
 	UserId string `prop:"user_id" validate:"uuid" db:"user_id"`
 
 	Id string `validate:"uuid" db:"id"`
+
+	IfMatch types.ETags `json:"-" validate:"-"` // parsed If-Match header, nil when absent
+	Version *int        `json:"-" validate:"-"` // version the write must hit, resolved from IfMatch
+}
+
+type TrashedProductsRequest struct {
+	UserId   string `prop:"user_id" validate:"uuid"`
+	Page     int    `query:"page" validate:"required"`
+	Paginate int    `query:"paginate" validate:"required"`
+
+	RetentionDays int `query:"-" validate:"-"` // used to compute purge_at
+}
+
+func (r *TrashedProductsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type TrashedProductItem struct {
+	Id        string    `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	ShopId    string    `json:"shop_id" db:"shop_id"`
+	ShopName  string    `json:"shop_name" db:"shop_name"`
+	DeletedAt time.Time `json:"deleted_at" db:"deleted_at"`
+	PurgeAt   time.Time `json:"purge_at" db:"purge_at"`
+}
+
+type TrashedProductsResponse struct {
+	Items []TrashedProductItem `json:"items"`
+	Meta  types.Meta           `json:"meta"`
+}
+
+// TrashedProduct is what restoring a product needs to know about it.
+type TrashedProduct struct {
+	Id          string `db:"id"`
+	UserId      string `db:"user_id"`
+	ShopDeleted bool   `db:"shop_deleted"`
+}
+
+type RestoreProductRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+	Id     string `params:"id" validate:"uuid" db:"id"`
+}
+
+type RestoreProductResponse struct {
+	Id string `json:"id" db:"id"`
+}
+
+type ImportProductsRequest struct {
+	UserId    string `prop:"user_id" validate:"uuid"`
+	ShopId    string `query:"shop_id" validate:"required,uuid"`
+	Format    string `query:"format" validate:"required,oneof=csv jsonl"`
+	DryRun    bool   `query:"dry_run"`
+	BatchSize int    `query:"batch_size" validate:"min=0,max=10000"` // 0 imports every row in one transaction
+
+	File io.Reader `query:"-" validate:"-"`
+}
+
+// ImportProductRow is a row of an import file. Category and brand are given
+// either by id or by name, the id wins when a row has both.
+type ImportProductRow struct {
+	CategoryId  string  `json:"category_id"`
+	Category    string  `json:"category"`
+	BrandId     string  `json:"brand_id"`
+	Brand       string  `json:"brand"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Price       float64 `json:"price"`
+	Stock       int     `json:"stock"`
+	Status      string  `json:"status"`
+
+	Attributes types.JSONMap `json:"attributes"`
+}
+
+// ImportRef is a category or brand an import row can point at.
+type ImportRef struct {
+	Id   string `db:"id"`
+	Name string `db:"name"`
+}
+
+// ImportProductsResponse reports an import, Rows only lists the rows that
+// failed and Created stays 0 on a dry run.
+type ImportProductsResponse struct {
+	DryRun  bool             `json:"dry_run"`
+	Total   int              `json:"total"`
+	Valid   int              `json:"valid"`
+	Invalid int              `json:"invalid"`
+	Created int              `json:"created"`
+	Rows    []ImportRowError `json:"rows"`
+}
+
+// ImportRowError carries the field errors of a row keyed like the
+// validation errors of creating a product.
+type ImportRowError struct {
+	Row    int                 `json:"row"` // line number in the file
+	Errors map[string][]string `json:"errors"`
+}
+
+const (
+	ExportPending   = "pending"
+	ExportRunning   = "running"
+	ExportCompleted = "completed"
+	ExportFailed    = "failed"
+)
+
+// ExportProductsRequest exports the products of the user matching Filters,
+// the pagination of Filters is ignored.
+type ExportProductsRequest struct {
+	UserId  string          `prop:"user_id" validate:"uuid"`
+	Format  string          `query:"format" validate:"required,oneof=csv jsonl"`
+	Async   bool            `query:"async"` // run in the background whatever the size
+	Filters ProductsRequest `query:"-" validate:"-"`
+
+	SyncLimit int `query:"-" validate:"-"` // larger exports run in the background
+}
+
+// ExportRow is a product as written to an export file, the columns an
+// import reads are named the same so an export can be imported again.
+type ExportRow struct {
+	Id             string     `json:"id" db:"id"`
+	Name           string     `json:"name" db:"name"`
+	Description    string     `json:"description" db:"description"`
+	Status         string     `json:"status" db:"status"`
+	Price          float64    `json:"price" db:"price"`
+	EffectivePrice float64    `json:"effective_price" db:"effective_price"`
+	Stock          int        `json:"stock" db:"stock"`
+	AvailableStock int        `json:"available_stock" db:"available_stock"`
+	CategoryId     string     `json:"category_id" db:"category_id"`
+	Category       string     `json:"category" db:"category"`
+	BrandId        string     `json:"brand_id" db:"brand_id"`
+	Brand          string     `json:"brand" db:"brand"`
+	ShopId         string     `json:"shop_id" db:"shop_id"`
+	Shop           string     `json:"shop" db:"shop"`
+	Rating         float64    `json:"rating" db:"rating"`
+	ReviewCount    int        `json:"review_count" db:"review_count"`
+	PublishedAt    *time.Time `json:"published_at" db:"published_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
+
+	Attributes types.JSONMap `json:"attributes" db:"attributes"`
+}
+
+// ProductExport is an export run by the background job, Url is only set
+// once the export is completed.
+type ProductExport struct {
+	Id          string     `json:"id" db:"id"`
+	UserId      string     `json:"user_id" db:"user_id"`
+	Format      string     `json:"format" db:"format"`
+	Status      string     `json:"status" db:"status"`
+	RowCount    *int       `json:"row_count" db:"row_count"`
+	Error       *string    `json:"error" db:"error"`
+	FileName    *string    `json:"-" db:"file_name"`
+	Filters     []byte     `json:"-" db:"filters"` // ProductsRequest as JSON
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	StartedAt   *time.Time `json:"started_at" db:"started_at"`
+	CompletedAt *time.Time `json:"completed_at" db:"completed_at"`
+
+	Url          *string    `json:"url" db:"-"`
+	UrlExpiresAt *time.Time `json:"url_expires_at" db:"-"`
+}
+
+type GetExportRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+	Id     string `params:"id" validate:"uuid"`
+
+	UrlTtl int `query:"-" validate:"-"` // seconds the download url stays valid
+}
+
+// ExportResult is what a run of the export job did.
+type ExportResult struct {
+	Completed int `json:"completed"`
+	Failed    int `json:"failed"`
+}
+
+// product feed formats
+const (
+	FeedFormatXML = "xml" // Google Merchant Center RSS 2.0
+	FeedFormatTSV = "tsv" // Google Merchant Center tab separated
+)
+
+// FeedContentTypes are the content types feeds of each format are served with.
+var FeedContentTypes = map[string]string{
+	FeedFormatXML: "application/rss+xml; charset=utf-8",
+	FeedFormatTSV: "text/tab-separated-values; charset=utf-8",
+}
+
+// FeedRequest asks for the feed of a shop, or the site-wide feed when
+// ShopId is empty.
+type FeedRequest struct {
+	ShopId string `json:"shop_id" params:"id" validate:"omitempty,uuid"`
+	Format string `json:"format" params:"format" validate:"required,oneof=xml tsv"`
+
+	Title    string `json:"-" validate:"-"` // title of the site-wide feed
+	LinkURL  string `json:"-" validate:"-"` // storefront the items link to
+	Currency string `json:"-" validate:"-"`
+}
+
+// Feed describes a product feed before its items are written. Version is
+// the version of the shop of a shop feed, it changes the ETag when the
+// shop is renamed.
+type Feed struct {
+	Slug        string `db:"slug"` // of the shop of a shop feed
+	Title       string `db:"title"`
+	Description string `db:"description"`
+	Link        string `db:"-"`
+	Version     int    `db:"version"`
+
+	FeedStats
+}
+
+// FeedStats sum up the items of a feed, LastModified is nil for an empty feed.
+type FeedStats struct {
+	ItemCount    int        `db:"item_count"`
+	LastModified *time.Time `db:"last_modified"`
+}
+
+// ETag identifies the feed in a format, it changes whenever an item is
+// added, changed or removed.
+func (f *Feed) ETag(format string) string {
+	var modified int64
+	if f.LastModified != nil {
+		modified = f.LastModified.UnixMicro()
+	}
+
+	return fmt.Sprintf(`W/"%s-%d-%d-%d"`, format, f.ItemCount, modified, f.Version)
+}
+
+// FeedItem is a published product as listed in the feeds.
+type FeedItem struct {
+	ProductId    string     `db:"product_id"`
+	ShopId       string     `db:"shop_id"`
+	Slug         string     `db:"slug"`
+	Title        string     `db:"title"`
+	Description  string     `db:"description"`
+	ImageLink    *string    `db:"image_link"`
+	Price        float64    `db:"price"`
+	SalePrice    *float64   `db:"sale_price"`
+	SaleStartsAt *time.Time `db:"sale_starts_at"`
+	SaleEndsAt   *time.Time `db:"sale_ends_at"`
+	Availability string     `db:"availability"`
+	Brand        string     `db:"brand"`
+	ProductType  string     `db:"product_type"`
+}
+
+// FeedSyncResult is what a run of the feed job changed.
+type FeedSyncResult struct {
+	Updated int `json:"updated" db:"updated"`
+	Removed int `json:"removed" db:"removed"`
+}
+
+// StorefrontRequest asks for the public page of a shop by id or by slug,
+// its published products are listed the same way as in the catalog.
+type StorefrontRequest struct {
+	ShopId   string          `params:"id" validate:"required_without=ShopSlug,omitempty,uuid"`
+	ShopSlug string          `params:"-" validate:"omitempty,max=80"` // set instead of ShopId when fetched by slug
+	Filters  ProductsRequest `query:"-" validate:"-"`
+}
+
+type StorefrontResponse struct {
+	Shop     StorefrontShop    `json:"shop" db:"shop"`
+	Stats    StorefrontStats   `json:"stats" db:"stats"`
+	Products *ProductsResponse `json:"products" db:"-"`
+}
+
+type StorefrontShop struct {
+	Id          string `json:"id" db:"id"`
+	Slug        string `json:"slug" db:"slug"` // an older slug redirects here
+	Name        string `json:"name" db:"name"`
+	Description string `json:"description" db:"description"`
+	Terms       string `json:"terms" db:"terms"`
+}
+
+// StorefrontStats sum up the published products of a shop. AverageRating
+// is the average of all their reviews, 0 when there are none.
+type StorefrontStats struct {
+	ProductCount  int       `json:"product_count" db:"product_count"`
+	AverageRating float64   `json:"average_rating" db:"average_rating"`
+	ReviewCount   int       `json:"review_count" db:"review_count"`
+	JoinedAt      time.Time `json:"joined_at" db:"joined_at"`
 }
@@ -0,0 +1,77 @@
+package job
+
+import (
+	"codebase-app/internal/adapter"
+	"codebase-app/internal/infrastructure/config"
+	integration "codebase-app/internal/integration/filestorage"
+	"codebase-app/internal/module/product/ports"
+	"codebase-app/internal/module/product/repository"
+	"codebase-app/internal/module/product/service"
+	"codebase-app/pkg/scheduler"
+	"context"
+	"time"
+)
+
+type productJob struct {
+	service ports.ProductService
+}
+
+func NewProductJob() *productJob {
+	var (
+		job     = new(productJob)
+		repo    = repository.NewProductRepository(adapter.Adapters.ShopeefunPostgres)
+		storage = integration.NewFileStorageIntegration()
+		service = service.NewProductService(repo, adapter.Adapters.Validator, storage)
+	)
+	job.service = service
+
+	return job
+}
+
+func (j *productJob) Register(s *scheduler.Scheduler) {
+	s.Register(scheduler.Job{
+		Name:     "product.apply_schedules",
+		Interval: time.Duration(config.Envs.Scheduler.Interval) * time.Second,
+		Run:      j.ApplySchedules,
+	})
+	s.Register(scheduler.Job{
+		Name:     "product.purge_trash",
+		Interval: time.Duration(config.Envs.Trash.PurgeInterval) * time.Second,
+		Run:      j.PurgeTrash,
+	})
+	s.Register(scheduler.Job{
+		Name:     "product.run_exports",
+		Interval: time.Duration(config.Envs.Export.Interval) * time.Second,
+		Run:      j.RunExports,
+	})
+	s.Register(scheduler.Job{
+		Name:     "product.sync_feed",
+		Interval: time.Duration(config.Envs.Feed.Interval) * time.Second,
+		Run:      j.SyncFeed,
+	})
+}
+
+// ApplySchedules publishes and unpublishes the products whose scheduled time has passed.
+func (j *productJob) ApplySchedules(ctx context.Context) error {
+	_, err := j.service.ApplySchedules(ctx)
+	return err
+}
+
+// PurgeTrash hard deletes the products kept in the trash longer than the retention period.
+func (j *productJob) PurgeTrash(ctx context.Context) error {
+	before := time.Now().AddDate(0, 0, -config.Envs.Trash.RetentionDays)
+	_, err := j.service.PurgeProducts(ctx, before)
+	return err
+}
+
+// RunExports writes the queued product exports to the private storage.
+func (j *productJob) RunExports(ctx context.Context) error {
+	_, err := j.service.RunExports(ctx)
+	return err
+}
+
+// SyncFeed regenerates the product feed items that changed since the last run.
+func (j *productJob) SyncFeed(ctx context.Context) error {
+	_, err := j.service.SyncFeed(ctx)
+	return err
+}
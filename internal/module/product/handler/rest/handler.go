@@ -1,7 +1,12 @@
 package handler
 
 import (
+	"bufio"
+	"bytes"
+	"cmp"
 	"codebase-app/internal/adapter"
+	"codebase-app/internal/infrastructure/config"
+	integration "codebase-app/internal/integration/filestorage"
 	"codebase-app/internal/middleware"
 	"codebase-app/internal/module/product/entity"
 	"codebase-app/internal/module/product/ports"
@@ -9,6 +14,14 @@ import (
 	"codebase-app/internal/module/product/service"
 	"codebase-app/pkg/errmsg"
 	"codebase-app/pkg/response"
+	"codebase-app/pkg/slug"
+	"codebase-app/pkg/types"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/rs/zerolog/log"
@@ -22,7 +35,8 @@ func NewProductHandler() *productHandler {
 	var (
 		handler = new(productHandler)
 		repo    = repository.NewProductRepository(adapter.Adapters.ShopeefunPostgres)
-		service = service.NewProductService(repo)
+		storage = integration.NewFileStorageIntegration()
+		service = service.NewProductService(repo, adapter.Adapters.Validator, storage)
 	)
 	handler.service = service
 
@@ -30,11 +44,25 @@ func NewProductHandler() *productHandler {
 }
 
 func (h *productHandler) Register(router fiber.Router) {
+	router.Get("/catalog", h.GetCatalog)
+	router.Get("/feeds/products.:format", h.GetFeed)
+	router.Get("/shops/:id/feeds/products.:format", h.GetFeed)
+	router.Get("/shops/:id/storefront", h.GetStorefront)
 	router.Get("/products", middleware.UserIdHeader, h.GetProducts)
+	router.Get("/products/trash", middleware.UserIdHeader, h.GetTrashedProducts)
+	router.Get("/products/export", middleware.UserIdHeader, h.ExportProducts)
+	router.Get("/products/exports/:id", middleware.UserIdHeader, h.GetExport)
 	router.Get("/products/:id", middleware.UserIdHeader, h.GetProduct)
 	router.Post("/products", middleware.UserIdHeader, h.CreateProduct)
-	router.Patch("/products/:id", middleware.UserIdHeader, h.UpdateProduct)
+	router.Post("/products/import", middleware.UserIdHeader, h.ImportProducts)
+	router.Put("/products/:id", middleware.UserIdHeader, h.UpdateProduct)
+	router.Patch("/products/:id", middleware.UserIdHeader, h.PatchProduct)
 	router.Delete("/products/:id", middleware.UserIdHeader, h.DeleteProduct)
+	router.Post("/products/:id/publish", middleware.UserIdHeader, h.TransitionProduct(entity.StatusPublished))
+	router.Post("/products/:id/archive", middleware.UserIdHeader, h.TransitionProduct(entity.StatusArchived))
+	router.Post("/products/:id/draft", middleware.UserIdHeader, h.TransitionProduct(entity.StatusDraft))
+	router.Put("/products/:id/schedule", middleware.UserIdHeader, h.ScheduleProduct)
+	router.Post("/products/:id/restore", middleware.UserIdHeader, h.RestoreProduct)
 }
 
 func (h *productHandler) GetProducts(c *fiber.Ctx) error {
@@ -68,6 +96,84 @@ func (h *productHandler) GetProducts(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
 }
 
+func (h *productHandler) GetCatalog(c *fiber.Ctx) error {
+	var (
+		req = new(entity.ProductsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetCatalog - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = ""
+	req.Status = ""
+	req.SetDefault()
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetCatalog - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetCatalog(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *productHandler) GetStorefront(c *fiber.Ctx) error {
+	var (
+		req = new(entity.StorefrontRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	if err := c.QueryParser(&req.Filters); err != nil {
+		log.Warn().Err(err).Msg("handler::GetStorefront - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.ShopId, req.ShopSlug = slug.Parse(c.Params("id"))
+	req.Filters.UserId = ""
+	req.Filters.Status = ""
+	req.Filters.SetDefault()
+
+	if err := v.Validate(&req.Filters); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetStorefront - Validate request query")
+		code, errs := errmsg.Errors(err, &req.Filters)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetStorefront - Validate request params")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetStorefront(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	// an old slug moves clients to the current one
+	if req.ShopSlug != "" && req.ShopSlug != resp.Shop.Slug {
+		location := strings.Replace(c.Path(), "/shops/"+req.ShopSlug+"/", "/shops/"+resp.Shop.Slug+"/", 1)
+		if query := c.Context().QueryArgs().String(); query != "" {
+			location += "?" + query
+		}
+		return c.Redirect(location, fiber.StatusMovedPermanently)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
 func (h *productHandler) CreateProduct(c *fiber.Ctx) error {
 	var (
 		req = new(entity.CreateProductRequest)
@@ -103,9 +209,11 @@ func (h *productHandler) GetProduct(c *fiber.Ctx) error {
 		req = new(entity.GetProductRequest)
 		ctx = c.Context()
 		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
 	)
 
-	req.Id = c.Params("id")
+	req.UserId = l.UserId
+	req.Id, req.Slug = slug.Parse(c.Params("id"))
 
 	if err := v.Validate(req); err != nil {
 		log.Warn().Err(err).Any("payload", req).Msg("handler::GetProduct - Validate request body")
@@ -119,6 +227,21 @@ func (h *productHandler) GetProduct(c *fiber.Ctx) error {
 		return c.Status(code).JSON(response.Error(errs))
 	}
 
+	// an old slug moves clients to the current one
+	if req.Slug != "" && req.Slug != resp.Slug {
+		location := strings.TrimSuffix(c.Path(), req.Slug) + resp.Slug
+		if query := c.Context().QueryArgs().String(); query != "" {
+			location += "?" + query
+		}
+		return c.Redirect(location, fiber.StatusMovedPermanently)
+	}
+
+	etag := types.ContentETag(resp.Version, resp)
+	c.Set(fiber.HeaderETag, etag)
+	if types.ParseETags(c.Get(fiber.HeaderIfNoneMatch)).Contains(etag) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
 	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
 }
 
@@ -137,6 +260,7 @@ func (h *productHandler) UpdateProduct(c *fiber.Ctx) error {
 
 	req.UserId = l.UserId
 	req.Id = c.Params("id")
+	req.IfMatch = types.ParseETags(c.Get(fiber.HeaderIfMatch))
 
 	if err := v.Validate(req); err != nil {
 		log.Warn().Err(err).Any("payload", req).Msg("handler::UpdateProduct - Validate request body")
@@ -150,6 +274,48 @@ func (h *productHandler) UpdateProduct(c *fiber.Ctx) error {
 		return c.Status(code).JSON(response.Error(errs))
 	}
 
+	c.Set(fiber.HeaderETag, types.ContentETag(resp.Version, resp))
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+// PatchProduct accepts application/json and application/merge-patch+json bodies.
+func (h *productHandler) PatchProduct(c *fiber.Ctx) error {
+	var (
+		req = new(entity.PatchProductRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+		err error
+	)
+
+	req.Fields, err = types.ParsePatchFields(c.Body())
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::PatchProduct - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	if err := json.Unmarshal(c.Body(), req); err != nil {
+		log.Warn().Err(err).Msg("handler::PatchProduct - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+	req.IfMatch = types.ParseETags(c.Get(fiber.HeaderIfMatch))
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::PatchProduct - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.PatchProduct(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	c.Set(fiber.HeaderETag, types.ContentETag(resp.Version, resp))
 	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
 }
 
@@ -162,6 +328,7 @@ func (h *productHandler) DeleteProduct(c *fiber.Ctx) error {
 	)
 	req.UserId = l.UserId
 	req.Id = c.Params("id")
+	req.IfMatch = types.ParseETags(c.Get(fiber.HeaderIfMatch))
 
 	if err := v.Validate(req); err != nil {
 		log.Warn().Err(err).Any("payload", req).Msg("handler::DeleteProduct - Validate request body")
@@ -177,3 +344,340 @@ func (h *productHandler) DeleteProduct(c *fiber.Ctx) error {
 
 	return c.Status(fiber.StatusOK).JSON(response.Success(nil, ""))
 }
+
+// TransitionProduct returns the handler moving a product to the given status.
+func (h *productHandler) TransitionProduct(status string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var (
+			req = new(entity.TransitionProductRequest)
+			ctx = c.Context()
+			v   = adapter.Adapters.Validator
+			l   = middleware.GetLocals(c)
+		)
+		req.UserId = l.UserId
+		req.Id = c.Params("id")
+		req.Status = status
+
+		if err := v.Validate(req); err != nil {
+			log.Warn().Err(err).Any("payload", req).Msg("handler::TransitionProduct - Validate request body")
+			code, errs := errmsg.Errors(err, req)
+			return c.Status(code).JSON(response.Error(errs))
+		}
+
+		resp, err := h.service.TransitionProduct(ctx, req)
+		if err != nil {
+			code, errs := errmsg.Errors[error](err)
+			return c.Status(code).JSON(response.Error(errs))
+		}
+
+		return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+	}
+}
+
+func (h *productHandler) ScheduleProduct(c *fiber.Ctx) error {
+	var (
+		req = new(entity.ScheduleProductRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::ScheduleProduct - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::ScheduleProduct - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.ScheduleProduct(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *productHandler) GetTrashedProducts(c *fiber.Ctx) error {
+	var (
+		req = new(entity.TrashedProductsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetTrashedProducts - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.RetentionDays = config.Envs.Trash.RetentionDays
+	req.SetDefault()
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetTrashedProducts - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetTrashedProducts(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *productHandler) RestoreProduct(c *fiber.Ctx) error {
+	var (
+		req = new(entity.RestoreProductRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::RestoreProduct - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.RestoreProduct(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+// ImportProducts takes the file as multipart "file" or as the raw request
+// body, the format falls back to the file name or the content type.
+func (h *productHandler) ImportProducts(c *fiber.Ctx) error {
+	var (
+		req         = new(entity.ImportProductsRequest)
+		ctx         = c.Context()
+		v           = adapter.Adapters.Validator
+		l           = middleware.GetLocals(c)
+		filename    string
+		contentType = c.Get(fiber.HeaderContentType)
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::ImportProducts - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	if file, err := c.FormFile("file"); err == nil {
+		f, err := file.Open()
+		if err != nil {
+			log.Warn().Err(err).Msg("handler::ImportProducts - Open uploaded file")
+			return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+		}
+		defer f.Close()
+
+		req.File = f
+		filename = file.Filename
+		contentType = file.Header.Get(fiber.HeaderContentType)
+	} else if len(c.Body()) > 0 && !strings.HasPrefix(contentType, fiber.MIMEMultipartForm) {
+		req.File = bytes.NewReader(c.Body())
+	}
+
+	if req.File == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(errmsg.NewCustomErrors(400, errmsg.WithErrors("file", "file harus diisi."))))
+	}
+
+	req.UserId = l.UserId
+	if req.Format == "" {
+		req.Format = types.DetectFormat(filename, contentType)
+	}
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::ImportProducts - Validate request query")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.ImportProducts(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		body := response.Error(errs)
+		if resp != nil {
+			body["data"] = resp // the per row report
+		}
+		return c.Status(code).JSON(body)
+	}
+
+	if req.DryRun {
+		return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Success(resp, ""))
+}
+
+// ExportProducts streams the products matching the listing filters as the
+// response body, or answers 202 with the queued export when there are more
+// than the sync limit or async is set.
+func (h *productHandler) ExportProducts(c *fiber.Ctx) error {
+	var (
+		req = new(entity.ExportProductsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::ExportProducts - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	if err := c.QueryParser(&req.Filters); err != nil {
+		log.Warn().Err(err).Msg("handler::ExportProducts - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.SyncLimit = config.Envs.Export.SyncLimit
+	req.Filters.UserId = l.UserId
+	req.Filters.SetDefault()
+
+	if err := v.Validate(&req.Filters); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::ExportProducts - Validate request query")
+		code, errs := errmsg.Errors(err, &req.Filters)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::ExportProducts - Validate request query")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	export, err := h.service.ExportProducts(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if export != nil {
+		return c.Status(fiber.StatusAccepted).JSON(response.Success(export, ""))
+	}
+
+	filename := fmt.Sprintf("products-%s.%s", time.Now().Format("20060102-150405"), req.Format)
+	c.Set(fiber.HeaderContentType, types.ContentTypes[req.Format])
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+filename+`"`)
+
+	// the writer runs once the handler has returned, so it can't use the request context
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		total, err := h.service.WriteExport(context.Background(), req.Format, &req.Filters, w)
+		if err != nil {
+			log.Error().Err(err).Int("written", total).Str("user_id", req.UserId).Msg("handler::ExportProducts - Stream export")
+		}
+	})
+
+	return nil
+}
+
+func (h *productHandler) GetExport(c *fiber.Ctx) error {
+	var (
+		req = new(entity.GetExportRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+	req.UrlTtl = config.Envs.Export.UrlTtl
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetExport - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetExport(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+// GetFeed serves the Google Merchant Center feed of a shop, or the
+// site-wide feed. Feeds are regenerated by the feed job, so they are
+// cacheable and answer conditional requests with 304.
+func (h *productHandler) GetFeed(c *fiber.Ctx) error {
+	var (
+		req = new(entity.FeedRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		cfg = config.Envs.Feed
+	)
+	req.ShopId = c.Params("id")
+	req.Format = c.Params("format")
+	req.Title = cmp.Or(cfg.Title, config.Envs.App.Name)
+	req.LinkURL = strings.TrimRight(cfg.LinkURL, "/")
+	req.Currency = cfg.Currency
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetFeed - Validate request params")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	feed, err := h.service.GetFeed(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	etag := feed.ETag(req.Format)
+	c.Set(fiber.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", cfg.MaxAge))
+	c.Set(fiber.HeaderETag, etag)
+	if feed.LastModified != nil {
+		c.Set(fiber.HeaderLastModified, feed.LastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(c, etag, feed.LastModified) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	c.Set(fiber.HeaderContentType, entity.FeedContentTypes[req.Format])
+
+	// the writer runs once the handler has returned, so it can't use the request context
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		total, err := h.service.WriteFeed(context.Background(), req, feed, w)
+		if err != nil {
+			log.Error().Err(err).Int("written", total).Str("shop_id", req.ShopId).Msg("handler::GetFeed - Stream feed")
+		}
+	})
+
+	return nil
+}
+
+// notModified checks If-None-Match, or If-Modified-Since when the client
+// sent no entity tags.
+func notModified(c *fiber.Ctx, etag string, lastModified *time.Time) bool {
+	if tags := types.ParseETags(c.Get(fiber.HeaderIfNoneMatch)); tags != nil {
+		return tags.Contains(etag)
+	}
+
+	since, err := http.ParseTime(c.Get(fiber.HeaderIfModifiedSince))
+	if err != nil || lastModified == nil {
+		return false
+	}
+
+	return !lastModified.Truncate(time.Second).After(since)
+}
@@ -3,23 +3,73 @@ package ports
 import (
 	"codebase-app/internal/module/product/entity"
 	"context"
+	"io"
+	"time"
 )
 
 type ProductRepository interface {
 	GetProducts(ctx context.Context, req *entity.ProductsRequest) (*entity.ProductsResponse, error)
+	GetCatalog(ctx context.Context, req *entity.ProductsRequest) (*entity.ProductsResponse, error)
+	GetShopCatalog(ctx context.Context, req *entity.StorefrontRequest) (*entity.ProductsResponse, error)
 	CreateProduct(ctx context.Context, req *entity.CreateProductRequest) (*entity.CreateProductResponse, error)
 	GetProduct(ctx context.Context, req *entity.GetProductRequest) (*entity.GetProductResponse, error)
 	DeleteProduct(ctx context.Context, req *entity.DeleteProductRequest) error
 	UpdateProduct(ctx context.Context, req *entity.UpdateProductRequest) (*entity.UpdateProductResponse, error)
+	PatchProduct(ctx context.Context, req *entity.PatchProductRequest) (*entity.UpdateProductResponse, error)
+	GetProductVersion(ctx context.Context, id string) (int, error)
+	GetProductStatus(ctx context.Context, id string) (string, error)
+	UpdateProductStatus(ctx context.Context, req *entity.TransitionProductRequest, from string) (*entity.TransitionProductResponse, error)
+	ScheduleProduct(ctx context.Context, req *entity.ScheduleProductRequest) (*entity.ScheduleProductResponse, error)
+	ApplySchedules(ctx context.Context) (*entity.ScheduleResult, error)
+	GetTrashedProducts(ctx context.Context, req *entity.TrashedProductsRequest) (*entity.TrashedProductsResponse, error)
+	GetTrashedProduct(ctx context.Context, id string) (*entity.TrashedProduct, error)
+	RestoreProduct(ctx context.Context, req *entity.RestoreProductRequest) (*entity.RestoreProductResponse, error)
+	PurgeProducts(ctx context.Context, before time.Time) (int64, error)
+	ImportProducts(ctx context.Context, reqs []*entity.CreateProductRequest, batchSize int) (int, error)
+	GetCategoryRefs(ctx context.Context, keys []string) ([]entity.ImportRef, error)
+	GetBrandRefs(ctx context.Context, keys []string) ([]entity.ImportRef, error)
+	CountExportProducts(ctx context.Context, req *entity.ProductsRequest) (int, error)
+	StreamExportProducts(ctx context.Context, req *entity.ProductsRequest, fn func(row *entity.ExportRow) error) error
+	CreateExport(ctx context.Context, export *entity.ProductExport) (*entity.ProductExport, error)
+	GetExport(ctx context.Context, id string) (*entity.ProductExport, error)
+	ClaimExports(ctx context.Context, limit int) ([]entity.ProductExport, error)
+	CompleteExport(ctx context.Context, id, fileName string, rowCount int) error
+	FailExport(ctx context.Context, id, reason string) error
+	SyncFeedItems(ctx context.Context) (*entity.FeedSyncResult, error)
+	GetFeedShop(ctx context.Context, shopId string) (*entity.Feed, error)
+	GetFeedStats(ctx context.Context, shopId string) (*entity.FeedStats, error)
+	StreamFeedItems(ctx context.Context, shopId string, fn func(item *entity.FeedItem) error) error
+	GetProductIdBySlug(ctx context.Context, slug string) (string, error)
+	GetShopIdBySlug(ctx context.Context, slug string) (string, error)
+	GetStorefront(ctx context.Context, shopId string) (*entity.StorefrontResponse, error)
 
 	IsShopOwner(ctx context.Context, userId, shopId string) (bool, error)
 	IsProductOwner(ctx context.Context, userId, productId string) (bool, error)
+	// GetAttributeSchema returns the attributes of a category including the inherited ones.
+	GetAttributeSchema(ctx context.Context, categoryId string) ([]entity.AttributeSchema, error)
 }
 
 type ProductService interface {
 	GetProducts(ctx context.Context, req *entity.ProductsRequest) (*entity.ProductsResponse, error)
+	GetCatalog(ctx context.Context, req *entity.ProductsRequest) (*entity.ProductsResponse, error)
+	GetStorefront(ctx context.Context, req *entity.StorefrontRequest) (*entity.StorefrontResponse, error)
 	CreateProduct(ctx context.Context, req *entity.CreateProductRequest) (*entity.CreateProductResponse, error)
 	GetProduct(ctx context.Context, req *entity.GetProductRequest) (*entity.GetProductResponse, error)
 	DeleteProduct(ctx context.Context, req *entity.DeleteProductRequest) error
-	UpdateProduct(ctx context.Context, req *entity.UpdateProductRequest) (*entity.UpdateProductResponse, error)
+	UpdateProduct(ctx context.Context, req *entity.UpdateProductRequest) (*entity.GetProductResponse, error)
+	PatchProduct(ctx context.Context, req *entity.PatchProductRequest) (*entity.GetProductResponse, error)
+	TransitionProduct(ctx context.Context, req *entity.TransitionProductRequest) (*entity.TransitionProductResponse, error)
+	ScheduleProduct(ctx context.Context, req *entity.ScheduleProductRequest) (*entity.ScheduleProductResponse, error)
+	ApplySchedules(ctx context.Context) (*entity.ScheduleResult, error)
+	GetTrashedProducts(ctx context.Context, req *entity.TrashedProductsRequest) (*entity.TrashedProductsResponse, error)
+	RestoreProduct(ctx context.Context, req *entity.RestoreProductRequest) (*entity.RestoreProductResponse, error)
+	PurgeProducts(ctx context.Context, before time.Time) (int64, error)
+	ImportProducts(ctx context.Context, req *entity.ImportProductsRequest) (*entity.ImportProductsResponse, error)
+	ExportProducts(ctx context.Context, req *entity.ExportProductsRequest) (*entity.ProductExport, error)
+	WriteExport(ctx context.Context, format string, filters *entity.ProductsRequest, w io.Writer) (int, error)
+	GetExport(ctx context.Context, req *entity.GetExportRequest) (*entity.ProductExport, error)
+	RunExports(ctx context.Context) (*entity.ExportResult, error)
+	GetFeed(ctx context.Context, req *entity.FeedRequest) (*entity.Feed, error)
+	WriteFeed(ctx context.Context, req *entity.FeedRequest, feed *entity.Feed, w io.Writer) (int, error)
+	SyncFeed(ctx context.Context) (*entity.FeedSyncResult, error)
 }
@@ -3,240 +3,1718 @@ package repository
 import (
 	"codebase-app/internal/module/product/entity"
 	"codebase-app/internal/module/product/ports"
+	"codebase-app/pkg"
+	"codebase-app/pkg/slug"
+	"codebase-app/pkg/types"
 	"context"
+	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/rs/zerolog/log"
 )
 
 var _ ports.ProductRepository = &productRepository{}
 
+// headlineOptions wraps matched search terms so clients can render them.
+const headlineOptions = "StartSel=<mark>, StopSel=</mark>, HighlightAll=false, MaxFragments=2"
+
+// listing facets, used to leave a facet's own filter out of its counts
+const (
+	facetNone     = ""
+	facetCategory = "category"
+	facetBrand    = "brand"
+	facetPrice    = "price"
+	facetRating   = "rating"
+)
+
+const (
+	// rating aggregates are maintained on products by the reviews trigger
+	productRatingExpr      = "ROUND(p.rating_avg, 1)"
+	productReviewCountExpr = "p.rating_count"
+
+	// productPriceExpr is the lowest price a product can be bought for,
+	// taking variant price overrides into account.
+	productPriceExpr = "COALESCE((SELECT MIN(COALESCE(v.price, p.price)) FROM product_variants v WHERE v.product_id = p.id AND v.deleted_at IS NULL), p.price)"
+
+	productImageUrlExpr = "(SELECT url FROM product_images WHERE product_id = p.id AND is_primary)"
+
+	productHasVariantsExpr = "EXISTS (SELECT 1 FROM product_variants v WHERE v.product_id = p.id AND v.deleted_at IS NULL)"
+
+	productPromotionColumns = `
+			pr.id AS promotion_id,
+			pr.name AS promotion_name,
+			pr.discount_type AS promotion_discount_type,
+			pr.discount_value AS promotion_discount_value,
+			pr.ends_at AS promotion_ends_at`
+)
+
+var (
+	// productPromotionJoin picks the active promotion applied to p as pr:
+	// the highest priority first, then the lowest resulting price, then the
+	// newest. Promotions don't stack.
+	productPromotionJoin = `
+		LEFT JOIN LATERAL (
+			SELECT promo.id, promo.name, promo.discount_type, promo.discount_value, promo.starts_at, promo.ends_at
+			FROM promotions promo
+			WHERE
+				promo.shop_id = p.shop_id
+				AND promo.starts_at <= NOW()
+				AND promo.ends_at > NOW()
+				AND (
+					promo.scope = 'shop'
+					OR (promo.scope = 'brand' AND promo.brand_id = p.brand_id)
+					OR (promo.scope = 'category' AND p.category_id IN (
+						WITH RECURSIVE subtree AS (
+							SELECT promo.category_id AS id
+							UNION
+							SELECT sc.id FROM categories sc INNER JOIN subtree st ON sc.parent_id = st.id
+						)
+						SELECT id FROM subtree
+					))
+					OR (promo.scope = 'products' AND EXISTS (
+						SELECT 1 FROM promotion_products pp WHERE pp.promotion_id = promo.id AND pp.product_id = p.id
+					))
+				)
+			ORDER BY promo.priority DESC, ` + discountedPrice("p.price", "promo") + ` ASC, promo.created_at DESC, promo.id DESC
+			LIMIT 1
+		) pr ON TRUE`
+
+	productListFrom = `
+		FROM products p
+		INNER JOIN shops s ON p.shop_id = s.id
+		INNER JOIN categories c ON p.category_id = c.id
+		INNER JOIN brands b ON p.brand_id = b.id` + productPromotionJoin + `
+		WHERE
+			p.deleted_at IS NULL`
+
+	// productEffectivePriceExpr is productPriceExpr after the applied promotion.
+	productEffectivePriceExpr = effectivePrice(productPriceExpr)
+
+	// listingPriceColumns are the prices listings show, the "from" prices
+	// their price sorts, filter and facets go by, so a variant cheaper than
+	// its product doesn't put the product out of order.
+	listingPriceColumns = productPriceExpr + ` AS price,
+			` + productEffectivePriceExpr + ` AS effective_price`
+)
+
+// discountedPrice applies the discount of the promotion aliased as alias to
+// the base price. Prices don't go below zero.
+func discountedPrice(base, alias string) string {
+	return `GREATEST((` + base + `) - CASE ` + alias + `.discount_type
+		WHEN 'percent' THEN ROUND((` + base + `) * ` + alias + `.discount_value / 100, 2)
+		ELSE ` + alias + `.discount_value
+	END, 0)`
+}
+
+// effectivePrice is the base price after the promotion of productPromotionJoin.
+func effectivePrice(base string) string {
+	return `CASE WHEN pr.id IS NULL THEN ` + base + ` ELSE ` + discountedPrice(base, "pr") + ` END`
+}
+
+// promotionDao holds the applied promotion columns, all NULL when no
+// promotion applies.
+type promotionDao struct {
+	PromotionId            *string    `db:"promotion_id"`
+	PromotionName          *string    `db:"promotion_name"`
+	PromotionDiscountType  *string    `db:"promotion_discount_type"`
+	PromotionDiscountValue *float64   `db:"promotion_discount_value"`
+	PromotionEndsAt        *time.Time `db:"promotion_ends_at"`
+}
+
+func (d promotionDao) applied() *entity.AppliedPromotion {
+	if d.PromotionId == nil {
+		return nil
+	}
+
+	return &entity.AppliedPromotion{
+		Id:            *d.PromotionId,
+		Name:          *d.PromotionName,
+		DiscountType:  *d.PromotionDiscountType,
+		DiscountValue: *d.PromotionDiscountValue,
+		EndsAt:        *d.PromotionEndsAt,
+	}
+}
+
+// catalogScope limits a listing to the products anyone can see.
+const catalogScope = "s.deleted_at IS NULL AND p.status = 'published'"
+
 type productRepository struct {
 	db *sqlx.DB
 }
 
-func NewProductRepository(db *sqlx.DB) *productRepository {
-	return &productRepository{
-		db: db,
+func NewProductRepository(db *sqlx.DB) *productRepository {
+	return &productRepository{
+		db: db,
+	}
+}
+
+func (r *productRepository) GetProducts(ctx context.Context, req *entity.ProductsRequest) (*entity.ProductsResponse, error) {
+	return r.listProducts(ctx, req, "p.user_id = ?", req.UserId)
+}
+
+func (r *productRepository) GetCatalog(ctx context.Context, req *entity.ProductsRequest) (*entity.ProductsResponse, error) {
+	return r.listProducts(ctx, req, catalogScope)
+}
+
+// GetShopCatalog lists the catalog products of the shop of a storefront.
+func (r *productRepository) GetShopCatalog(ctx context.Context, req *entity.StorefrontRequest) (*entity.ProductsResponse, error) {
+	return r.listProducts(ctx, &req.Filters, catalogScope+" AND p.shop_id = ?", req.ShopId)
+}
+
+// listProducts runs the shared product listing query. The scope condition
+// decides whose products are visible (a seller's inventory or the public
+// catalog), while the rest of the filters come from the request.
+func (r *productRepository) listProducts(ctx context.Context, req *entity.ProductsRequest, scope string, scopeArgs ...any) (*entity.ProductsResponse, error) {
+	type dao struct {
+		TotalData   int `db:"total_data"`
+		CursorValue any `db:"cursor_value"`
+		entity.ProductItem
+		promotionDao
+	}
+
+	var (
+		resp = new(entity.ProductsResponse)
+		data = make([]dao, 0, req.Paginate+1)
+	)
+	resp.Items = make([]entity.ProductItem, 0, req.Paginate)
+
+	var (
+		args        []any
+		highlights  string
+		totalData   = "COUNT(p.id) OVER()"
+		tsQuery     = pkg.FormatKeywords(req.SearchQuery)
+		isSearching = req.SearchQuery != ""
+		isCursor    = req.IsCursor()
+		isBackward  = req.After != nil && req.After.Backward
+	)
+
+	sort, sortArgs := listingSort(req)
+
+	if isCursor {
+		// keyset pages don't pay for the window count, see countProducts
+		totalData = "0"
+	}
+
+	if isSearching {
+		highlights = `,
+			ts_headline('simple', p.name, to_tsquery('simple', ?), '` + headlineOptions + `') AS "highlight.name",
+			ts_headline('simple', p.description, to_tsquery('simple', ?), '` + headlineOptions + `') AS "highlight.description"`
+		args = append(args, tsQuery, tsQuery)
+	}
+
+	query := `
+		SELECT
+			` + totalData + ` as total_data,
+			` + sort.expr + ` AS cursor_value,
+			p.id,
+			p.slug,
+			p.name,
+			p.description,
+			` + listingPriceColumns + `,
+			p.stock,
+			p.status,
+			p.publish_at,
+			p.unpublish_at,
+			p.user_id,
+			s.slug AS "shop.slug",
+			s.name AS "shop.name",
+			s.description AS "shop.description",
+			s.terms AS "shop.terms",
+			c.name AS "category.name",
+			b.name AS "brand.name",
+			` + productRatingExpr + ` AS rating,
+			` + productReviewCountExpr + ` AS review_count,
+			` + productImageUrlExpr + ` AS image_url,` + productPromotionColumns + highlights + productListFrom + `
+			AND ` + scope
+
+	args = joinArgs(sortArgs, args, scopeArgs)
+
+	filters, filterArgs := productFilters(req, facetNone)
+	query += filters
+	args = append(args, filterArgs...)
+
+	if isCursor {
+		if req.After != nil {
+			query += " AND " + sort.keyset(isBackward)
+			args = joinArgs(args, sortArgs, []any{req.After.Value, req.After.Id})
+		}
+
+		query += " ORDER BY " + sort.orderBy(isBackward) + " LIMIT ?"
+		args = joinArgs(args, sortArgs, []any{req.Paginate + 1})
+	} else {
+		query += " ORDER BY " + sort.orderBy(false) + " LIMIT ? OFFSET ?"
+		args = joinArgs(args, sortArgs, []any{req.Paginate, req.Paginate * (req.Page - 1)})
+	}
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query), args...)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::listProducts - Failed to get products")
+		return nil, err
+	}
+
+	if isCursor {
+		hasMore := len(data) > req.Paginate
+		if hasMore {
+			data = data[:req.Paginate]
+		}
+
+		if isBackward {
+			for i, j := 0, len(data)-1; i < j; i, j = i+1, j-1 {
+				data[i], data[j] = data[j], data[i]
+			}
+		}
+
+		var next, prev *types.Cursor
+		if len(data) > 0 {
+			first, last := data[0], data[len(data)-1]
+			if hasMore || isBackward {
+				next = &types.Cursor{Sort: req.Sort, Value: types.CursorValue(last.CursorValue), Id: last.Id}
+			}
+			if req.After != nil && (hasMore || !isBackward) {
+				prev = &types.Cursor{Sort: req.Sort, Value: types.CursorValue(first.CursorValue), Id: first.Id, Backward: true}
+			}
+		}
+
+		resp.Meta.SetCursors(req.Paginate, next, prev)
+
+		if req.WithTotal {
+			resp.Meta.TotalData, err = r.countProducts(ctx, req, scope, scopeArgs...)
+			if err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		if len(data) > 0 {
+			resp.Meta.TotalData = data[0].TotalData
+		}
+
+		resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+	}
+
+	for _, d := range data {
+		d.ProductItem.Promotion = d.applied()
+		resp.Items = append(resp.Items, d.ProductItem)
+	}
+
+	if req.WithFacets {
+		resp.Facets, err = r.getProductFacets(ctx, req, scope, scopeArgs...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) countProducts(ctx context.Context, req *entity.ProductsRequest, scope string, scopeArgs ...any) (int, error) {
+	var total int
+
+	filters, filterArgs := productFilters(req, facetNone)
+	query := "SELECT COUNT(p.id)" + productListFrom + " AND " + scope + filters
+
+	err := r.db.GetContext(ctx, &total, r.db.Rebind(query), joinArgs(scopeArgs, filterArgs)...)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::countProducts - Failed to count products")
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// getProductFacets counts the listing per category, brand, price bucket and
+// rating star. Every facet is computed without its own filter so the other
+// options of that facet stay visible.
+func (r *productRepository) getProductFacets(ctx context.Context, req *entity.ProductsRequest, scope string, scopeArgs ...any) (*entity.ProductFacets, error) {
+	var (
+		facets = &entity.ProductFacets{
+			Categories: make([]entity.FacetCount, 0),
+			Brands:     make([]entity.FacetCount, 0),
+			Prices:     make([]entity.PriceFacet, 0, len(entity.PriceBucketBounds)),
+			Ratings:    make([]entity.RatingFacet, 0, 5),
+		}
+		from = productListFrom + " AND " + scope
+	)
+
+	filters, filterArgs := productFilters(req, facetCategory)
+	query := `
+		SELECT c.id, c.name, COUNT(p.id) AS count` + from + filters + `
+		GROUP BY c.id, c.name
+		ORDER BY count DESC, c.name ASC
+	`
+
+	err := r.db.SelectContext(ctx, &facets.Categories, r.db.Rebind(query), joinArgs(scopeArgs, filterArgs)...)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::getProductFacets - Failed to count categories")
+		return nil, err
+	}
+
+	filters, filterArgs = productFilters(req, facetBrand)
+	query = `
+		SELECT b.id, b.name, COUNT(p.id) AS count` + from + filters + `
+		GROUP BY b.id, b.name
+		ORDER BY count DESC, b.name ASC
+	`
+
+	err = r.db.SelectContext(ctx, &facets.Brands, r.db.Rebind(query), joinArgs(scopeArgs, filterArgs)...)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::getProductFacets - Failed to count brands")
+		return nil, err
+	}
+
+	var buckets []struct {
+		Bucket int `db:"bucket"`
+		Count  int `db:"count"`
+	}
+
+	priceExpr := productPriceExpr
+	if req.PriceBy == entity.PriceByEffective {
+		priceExpr = productEffectivePriceExpr
+	}
+
+	filters, filterArgs = productFilters(req, facetPrice)
+	query = `
+		SELECT width_bucket((` + priceExpr + `)::FLOAT8, ?::FLOAT8[]) AS bucket, COUNT(p.id) AS count` + from + filters + `
+		GROUP BY bucket
+	`
+
+	args := joinArgs([]any{pq.Array(entity.PriceBucketBounds)}, scopeArgs, filterArgs)
+	err = r.db.SelectContext(ctx, &buckets, r.db.Rebind(query), args...)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::getProductFacets - Failed to count price buckets")
+		return nil, err
+	}
+
+	bucketCounts := make(map[int]int, len(buckets))
+	for _, b := range buckets {
+		bucketCounts[b.Bucket] = b.Count
+	}
+
+	for i, min := range entity.PriceBucketBounds {
+		price := entity.PriceFacet{Min: min, Count: bucketCounts[i+1]}
+		if i+1 < len(entity.PriceBucketBounds) {
+			max := entity.PriceBucketBounds[i+1]
+			price.Max = &max
+		}
+		facets.Prices = append(facets.Prices, price)
+	}
+
+	var stars [5]int
+
+	filters, filterArgs = productFilters(req, facetRating)
+	query = `
+		SELECT
+			COUNT(p.id) FILTER (WHERE ` + productRatingExpr + ` >= 1),
+			COUNT(p.id) FILTER (WHERE ` + productRatingExpr + ` >= 2),
+			COUNT(p.id) FILTER (WHERE ` + productRatingExpr + ` >= 3),
+			COUNT(p.id) FILTER (WHERE ` + productRatingExpr + ` >= 4),
+			COUNT(p.id) FILTER (WHERE ` + productRatingExpr + ` >= 5)` + from + filters
+
+	err = r.db.QueryRowxContext(ctx, r.db.Rebind(query), joinArgs(scopeArgs, filterArgs)...).
+		Scan(&stars[0], &stars[1], &stars[2], &stars[3], &stars[4])
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::getProductFacets - Failed to count ratings")
+		return nil, err
+	}
+
+	for i := len(stars) - 1; i >= 0; i-- {
+		facets.Ratings = append(facets.Ratings, entity.RatingFacet{Rating: i + 1, Count: stars[i]})
+	}
+
+	return facets, nil
+}
+
+// productSort is a whitelisted ORDER BY expression. The product id is always
+// appended in the same direction as a tie-breaker so pages stay stable.
+type productSort struct {
+	expr string
+	desc bool
+}
+
+// orderBy returns the ORDER BY clause, reversed when paging backwards.
+func (s productSort) orderBy(reverse bool) string {
+	if s.desc != reverse {
+		return s.expr + " DESC, p.id DESC"
+	}
+
+	return s.expr + " ASC, p.id ASC"
+}
+
+// keyset returns the condition selecting the rows after a cursor in the
+// paging direction. It takes the cursor value and id as arguments.
+func (s productSort) keyset(reverse bool) string {
+	if s.desc != reverse {
+		return "(" + s.expr + ", p.id) < (?, ?)"
+	}
+
+	return "(" + s.expr + ", p.id) > (?, ?)"
+}
+
+var productSorts = map[string]productSort{
+	entity.SortPriceAsc:  {expr: productPriceExpr},
+	entity.SortPriceDesc: {expr: productPriceExpr, desc: true},
+	entity.SortRating:    {expr: "p.rating_avg", desc: true},
+	entity.SortReviews:   {expr: productReviewCountExpr, desc: true},
+	entity.SortNewest:    {expr: "p.created_at", desc: true},
+	entity.SortStock:     {expr: "p.stock", desc: true},
+	entity.SortName:      {expr: "p.name"},
+	entity.SortRelevance: {expr: "ts_rank(p.search_vector, to_tsquery('simple', ?))", desc: true},
+}
+
+// effectivePriceSorts replace the price sorts when listing by effective price.
+var effectivePriceSorts = map[string]productSort{
+	entity.SortPriceAsc:  {expr: productEffectivePriceExpr},
+	entity.SortPriceDesc: {expr: productEffectivePriceExpr, desc: true},
+}
+
+// listingSort picks the sort of a listing, falling back to newest when the
+// sort is unknown or is relevance without a search query. The arguments go
+// with every use of the sort expression.
+func listingSort(req *entity.ProductsRequest) (productSort, []any) {
+	sort, ok := productSorts[req.Sort]
+	if !ok || (req.Sort == entity.SortRelevance && req.SearchQuery == "") {
+		req.Sort = entity.SortNewest
+		sort = productSorts[req.Sort]
+	}
+
+	// price sorts follow the price the price filter uses
+	if s, ok := effectivePriceSorts[req.Sort]; ok && req.PriceBy == entity.PriceByEffective {
+		sort = s
+	}
+
+	// the sort expression of relevance carries the search query placeholder
+	sortArgs := []any{}
+	if req.Sort == entity.SortRelevance {
+		sortArgs = append(sortArgs, pkg.FormatKeywords(req.SearchQuery))
+	}
+
+	return sort, sortArgs
+}
+
+// joinArgs concatenates query arguments into a new slice.
+func joinArgs(parts ...[]any) []any {
+	var args []any
+	for _, part := range parts {
+		args = append(args, part...)
+	}
+
+	return args
+}
+
+// productFilters builds the optional WHERE conditions shared by every
+// product listing. Each condition is prefixed with " AND ". The filters that
+// belong to the skipped facet are left out.
+func productFilters(req *entity.ProductsRequest, skip string) (string, []any) {
+	var (
+		query string
+		args  []any
+	)
+
+	if req.Status != "" {
+		query += " AND p.status = ?"
+		args = append(args, req.Status)
+	}
+
+	if len(req.CategoryIds) > 0 && skip != facetCategory {
+		placeholders := make([]string, len(req.CategoryIds))
+		for i := range req.CategoryIds {
+			placeholders[i] = "?"
+			args = append(args, req.CategoryIds[i])
+		}
+
+		if req.WithSubcategories {
+			query += fmt.Sprintf(`
+				AND c.id IN (
+					WITH RECURSIVE subtree AS (
+						SELECT id FROM categories WHERE id IN (%s)
+						UNION
+						SELECT sc.id FROM categories sc INNER JOIN subtree st ON sc.parent_id = st.id
+					)
+					SELECT id FROM subtree
+				)`, strings.Join(placeholders, ","))
+		} else {
+			query += fmt.Sprintf(" AND c.id IN (%s)", strings.Join(placeholders, ","))
+		}
+	}
+
+	if len(req.BrandIds) > 0 && skip != facetBrand {
+		placeholders := make([]string, len(req.BrandIds))
+		for i := range req.BrandIds {
+			placeholders[i] = "?"
+			args = append(args, req.BrandIds[i])
+		}
+		query += fmt.Sprintf(" AND b.id IN (%s)", strings.Join(placeholders, ","))
+	}
+
+	if (req.MinPrice != nil || req.MaxPrice != nil) && skip != facetPrice {
+		// a product with variants matches when one of its variants is in range
+		var (
+			productPrice = "TRUE"
+			variantPrice = "TRUE"
+			priceArgs    []any
+			price        = func(base string) string { return base }
+		)
+
+		if req.PriceBy == entity.PriceByEffective {
+			price = effectivePrice
+		}
+
+		if req.MinPrice != nil {
+			productPrice += " AND " + price("p.price") + " >= ?"
+			variantPrice += " AND " + price("COALESCE(v.price, p.price)") + " >= ?"
+			priceArgs = append(priceArgs, *req.MinPrice)
+		}
+
+		if req.MaxPrice != nil {
+			productPrice += " AND " + price("p.price") + " <= ?"
+			variantPrice += " AND " + price("COALESCE(v.price, p.price)") + " <= ?"
+			priceArgs = append(priceArgs, *req.MaxPrice)
+		}
+
+		query += fmt.Sprintf(`
+			AND (
+				(NOT %s AND %s)
+				OR EXISTS (
+					SELECT 1 FROM product_variants v
+					WHERE v.product_id = p.id AND v.deleted_at IS NULL AND %s
+				)
+			)`, productHasVariantsExpr, productPrice, variantPrice)
+		args = joinArgs(args, priceArgs, priceArgs)
+	}
+
+	if req.MinRating > 0 && skip != facetRating {
+		query += " AND " + productRatingExpr + " >= ?"
+		args = append(args, req.MinRating)
+	}
+
+	if len(req.Attributes) > 0 {
+		// ->> compares the text form, so 8, true and "red" all match as typed in the query
+		values := make(map[string][]any)
+		keys := make([]string, 0)
+		for _, attribute := range req.Attributes {
+			key, value, _ := strings.Cut(attribute, ":")
+			if _, ok := values[key]; !ok {
+				keys = append(keys, key)
+			}
+			values[key] = append(values[key], value)
+		}
+
+		for _, key := range keys {
+			placeholders := strings.TrimSuffix(strings.Repeat("?,", len(values[key])), ",")
+			query += fmt.Sprintf(" AND p.attributes->>? IN (%s)", placeholders)
+			args = append(args, key)
+			args = append(args, values[key]...)
+		}
+	}
+
+	if req.SearchQuery != "" {
+		query += " AND p.search_vector @@ to_tsquery('simple', ?)"
+		args = append(args, pkg.FormatKeywords(req.SearchQuery))
+	}
+
+	if req.IsAvailable {
+		query += `
+			AND (
+				(NOT ` + productHasVariantsExpr + ` AND p.stock > 0)
+				OR EXISTS (
+					SELECT 1 FROM product_variants v
+					WHERE v.product_id = p.id AND v.deleted_at IS NULL AND v.stock > 0
+				)
+			)`
+	}
+
+	return query, args
+}
+
+// createProductQuery inserts a product, the initial stock opens the inventory
+// ledger of the product.
+const createProductQuery = `
+	WITH p AS (
+		INSERT INTO products (shop_id, category_id, brand_id, name, description, price, stock, user_id, attributes, status, published_at, slug)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CASE WHEN ? = 'published' THEN NOW() END, ?)
+		RETURNING id, slug, stock, user_id
+	), m AS (
+		INSERT INTO inventory_movements (product_id, delta, reason, actor_id, note, stock_after)
+		SELECT id, stock, 'restock', user_id, 'initial stock', stock FROM p WHERE stock > 0
+	)
+	SELECT id, slug FROM p
+`
+
+func createProductArgs(req *entity.CreateProductRequest, slug string) []any {
+	return []any{
+		req.ShopId,
+		req.CategoryId,
+		req.BrandId,
+		req.Name,
+		req.Description,
+		req.Price,
+		req.Stock,
+		req.UserId,
+		req.Attributes,
+		req.Status,
+		req.Status,
+		slug,
+	}
+}
+
+func (r *productRepository) CreateProduct(ctx context.Context, req *entity.CreateProductRequest) (*entity.CreateProductResponse, error) {
+	var resp = new(entity.CreateProductResponse)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateProduct - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	picked, err := slug.Pick(ctx, tx, slug.Products, req.Name, "")
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateProduct - Failed to pick slug")
+		return nil, err
+	}
+
+	err = tx.QueryRowxContext(ctx, tx.Rebind(createProductQuery), createProductArgs(req, picked)...).Scan(&resp.Id, &resp.Slug)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateProduct - Failed to create product")
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateProduct - Failed to commit transaction")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// ImportProducts creates the products batchSize at a time, every batch in its
+// own transaction, 0 puts all of them in one. It returns how many products
+// were committed before a batch failed.
+func (r *productRepository) ImportProducts(ctx context.Context, reqs []*entity.CreateProductRequest, batchSize int) (int, error) {
+	if batchSize <= 0 {
+		batchSize = len(reqs)
+	}
+
+	var created int
+	for start := 0; start < len(reqs); start += batchSize {
+		batch := reqs[start:min(start+batchSize, len(reqs))]
+
+		if err := r.importBatch(ctx, batch); err != nil {
+			log.Error().Err(err).Int("created", created).Int("batch_start", start).Msg("repository::ImportProducts - Failed to import batch")
+			return created, err
+		}
+
+		created += len(batch)
+	}
+
+	return created, nil
+}
+
+func (r *productRepository) importBatch(ctx context.Context, reqs []*entity.CreateProductRequest) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := tx.Rebind(createProductQuery)
+	for _, req := range reqs {
+		picked, err := slug.Pick(ctx, tx, slug.Products, req.Name, "")
+		if err != nil {
+			return err
+		}
+
+		var id string
+		if err := tx.QueryRowxContext(ctx, query, createProductArgs(req, picked)...).Scan(&id, &picked); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetProductIdBySlug finds a product by its current slug or by one it had
+// before a rename.
+func (r *productRepository) GetProductIdBySlug(ctx context.Context, value string) (string, error) {
+	id, err := slug.FindId(ctx, r.db, slug.Products, value)
+	if err != nil {
+		log.Error().Err(err).Str("slug", value).Msg("repository::GetProductIdBySlug - Failed to get product")
+		return "", err
+	}
+
+	return id, nil
+}
+
+// GetShopIdBySlug finds the shop of a storefront by its current slug or by
+// one it had before a rename, the same lookup the shop module does.
+func (r *productRepository) GetShopIdBySlug(ctx context.Context, value string) (string, error) {
+	id, err := slug.FindId(ctx, r.db, slug.Shops, value)
+	if err != nil {
+		log.Error().Err(err).Str("slug", value).Msg("repository::GetShopIdBySlug - Failed to get shop")
+		return "", err
+	}
+
+	return id, nil
+}
+
+// GetStorefront returns the profile and stats of a shop, deleted shops have
+// no storefront. The stats only count the products of the catalog.
+func (r *productRepository) GetStorefront(ctx context.Context, shopId string) (*entity.StorefrontResponse, error) {
+	var resp = new(entity.StorefrontResponse)
+
+	query := `
+		SELECT
+			s.id AS "shop.id",
+			s.slug AS "shop.slug",
+			s.name AS "shop.name",
+			s.description AS "shop.description",
+			s.terms AS "shop.terms",
+			COUNT(p.id) AS "stats.product_count",
+			COALESCE(ROUND(SUM(p.rating_avg * p.rating_count) / NULLIF(SUM(p.rating_count), 0), 1), 0) AS "stats.average_rating",
+			COALESCE(SUM(p.rating_count), 0) AS "stats.review_count",
+			s.created_at AS "stats.joined_at"
+		FROM shops s
+		LEFT JOIN products p ON
+			p.shop_id = s.id
+			AND p.deleted_at IS NULL
+			AND p.status = 'published'
+		WHERE s.id = ? AND s.deleted_at IS NULL
+		GROUP BY s.id
+	`
+
+	err := r.db.GetContext(ctx, resp, r.db.Rebind(query), shopId)
+	if err != nil {
+		log.Error().Err(err).Str("shop_id", shopId).Msg("repository::GetStorefront - Failed to get shop")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetCategoryRefs returns the categories matching any of the keys by id or
+// by name, ignoring case.
+func (r *productRepository) GetCategoryRefs(ctx context.Context, keys []string) ([]entity.ImportRef, error) {
+	return r.getImportRefs(ctx, "categories", keys)
+}
+
+// GetBrandRefs returns the brands matching any of the keys by id or by
+// name, ignoring case.
+func (r *productRepository) GetBrandRefs(ctx context.Context, keys []string) ([]entity.ImportRef, error) {
+	return r.getImportRefs(ctx, "brands", keys)
+}
+
+func (r *productRepository) getImportRefs(ctx context.Context, table string, keys []string) ([]entity.ImportRef, error) {
+	var resp = make([]entity.ImportRef, 0)
+
+	if len(keys) == 0 {
+		return resp, nil
+	}
+
+	// table comes from the callers above, never from user input
+	query := `
+		SELECT id, name
+		FROM ` + table + `
+		WHERE id::text = ANY(?) OR LOWER(name) = ANY(?)
+	`
+
+	lowered := make([]string, len(keys))
+	for i, key := range keys {
+		lowered[i] = strings.ToLower(key)
+	}
+
+	err := r.db.SelectContext(ctx, &resp, r.db.Rebind(query), pq.Array(lowered), pq.Array(lowered))
+	if err != nil {
+		log.Error().Err(err).Str("table", table).Msg("repository::getImportRefs - Failed to get import refs")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// CountExportProducts counts the products of the user matching the listing filters.
+func (r *productRepository) CountExportProducts(ctx context.Context, req *entity.ProductsRequest) (int, error) {
+	return r.countProducts(ctx, req, "p.user_id = ?", req.UserId)
+}
+
+// StreamExportProducts hands the products of the user matching the listing
+// filters to fn one at a time, in the order of the listing and without
+// paging. It stops at the first error of fn.
+func (r *productRepository) StreamExportProducts(ctx context.Context, req *entity.ProductsRequest, fn func(row *entity.ExportRow) error) error {
+	sort, sortArgs := listingSort(req)
+
+	query := `
+		SELECT
+			p.id,
+			p.name,
+			p.description,
+			p.status,
+			` + listingPriceColumns + `,
+			p.stock,
+			p.stock - ` + types.ReservedStockExpr("p.id", "NULL") + ` AS available_stock,
+			c.id AS category_id,
+			c.name AS category,
+			b.id AS brand_id,
+			b.name AS brand,
+			s.id AS shop_id,
+			s.name AS shop,
+			` + productRatingExpr + ` AS rating,
+			` + productReviewCountExpr + ` AS review_count,
+			p.published_at,
+			p.created_at,
+			p.updated_at,
+			p.attributes` + productListFrom + `
+			AND p.user_id = ?`
+
+	filters, filterArgs := productFilters(req, facetNone)
+	query += filters + " ORDER BY " + sort.orderBy(false)
+	args := joinArgs([]any{req.UserId}, filterArgs, sortArgs)
+
+	rows, err := r.db.QueryxContext(ctx, r.db.Rebind(query), args...)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::StreamExportProducts - Failed to query products")
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row entity.ExportRow
+		if err := rows.StructScan(&row); err != nil {
+			log.Error().Err(err).Msg("repository::StreamExportProducts - Failed to scan product")
+			return err
+		}
+
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+const productExportColumns = "id, user_id, format, status, row_count, error, file_name, filters, created_at, started_at, completed_at"
+
+func (r *productRepository) CreateExport(ctx context.Context, export *entity.ProductExport) (*entity.ProductExport, error) {
+	var resp = new(entity.ProductExport)
+
+	query := `
+		INSERT INTO product_exports (user_id, format, filters)
+		VALUES (?, ?, ?)
+		RETURNING ` + productExportColumns
+
+	err := r.db.GetContext(ctx, resp, r.db.Rebind(query), export.UserId, export.Format, export.Filters)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", export.UserId).Msg("repository::CreateExport - Failed to create export")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) GetExport(ctx context.Context, id string) (*entity.ProductExport, error) {
+	var resp = new(entity.ProductExport)
+
+	query := "SELECT " + productExportColumns + " FROM product_exports WHERE id = ?"
+
+	err := r.db.GetContext(ctx, resp, r.db.Rebind(query), id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::GetExport - Failed to get export")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// ClaimExports marks up to limit pending exports as running and returns
+// them. An export left running for an hour is taken to belong to a crashed
+// run and is claimed again.
+func (r *productRepository) ClaimExports(ctx context.Context, limit int) ([]entity.ProductExport, error) {
+	var resp = make([]entity.ProductExport, 0)
+
+	query := `
+		UPDATE product_exports
+		SET status = 'running', started_at = NOW()
+		WHERE id IN (
+			SELECT id
+			FROM product_exports
+			WHERE
+				status = 'pending'
+				OR (status = 'running' AND started_at < NOW() - INTERVAL '1 hour')
+			ORDER BY created_at ASC
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + productExportColumns
+
+	err := r.db.SelectContext(ctx, &resp, r.db.Rebind(query), limit)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::ClaimExports - Failed to claim exports")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) CompleteExport(ctx context.Context, id, fileName string, rowCount int) error {
+	query := `
+		UPDATE product_exports
+		SET status = 'completed', file_name = ?, row_count = ?, error = NULL, completed_at = NOW()
+		WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), fileName, rowCount, id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::CompleteExport - Failed to complete export")
+		return err
+	}
+
+	return nil
+}
+
+func (r *productRepository) FailExport(ctx context.Context, id, reason string) error {
+	query := `
+		UPDATE product_exports
+		SET status = 'failed', error = ?, completed_at = NOW()
+		WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), reason, id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::FailExport - Failed to fail export")
+		return err
+	}
+
+	return nil
+}
+
+// productInStockExpr tells whether a product can be bought, by the stock left
+// once live reservations are taken out, of one of its variants when it has
+// any.
+var productInStockExpr = `(
+	(NOT ` + productHasVariantsExpr + ` AND p.stock - ` + types.ReservedStockExpr("p.id", "NULL") + ` > 0)
+	OR EXISTS (
+		SELECT 1 FROM product_variants v
+		WHERE
+			v.product_id = p.id
+			AND v.deleted_at IS NULL
+			AND v.stock - ` + types.ReservedStockExpr("p.id", "v.id") + ` > 0
+	)
+)`
+
+// feedItemColumns are the columns of product_feed_items a sync compares.
+const feedItemColumns = "shop_id, slug, title, description, image_link, price, sale_price, sale_starts_at, sale_ends_at, availability, brand, product_type"
+
+// SyncFeedItems brings product_feed_items in line with the published
+// products. Only the items whose content changed are written, so
+// updated_at moves only when a feed really changed. Items that are no longer
+// published are marked removed.
+func (r *productRepository) SyncFeedItems(ctx context.Context) (*entity.FeedSyncResult, error) {
+	var (
+		resp     = new(entity.FeedSyncResult)
+		sale     = discountedPrice("p.price", "pr")
+		onSale   = "pr.id IS NOT NULL AND " + sale + " < p.price"
+		current  = "product_feed_items." + strings.ReplaceAll(feedItemColumns, ", ", ", product_feed_items.")
+		excluded = "EXCLUDED." + strings.ReplaceAll(feedItemColumns, ", ", ", EXCLUDED.")
+	)
+
+	query := `
+		WITH RECURSIVE category_paths AS (
+			SELECT id, name::TEXT AS path FROM categories WHERE parent_id IS NULL
+			UNION ALL
+			SELECT c.id, cp.path || ' > ' || c.name FROM categories c INNER JOIN category_paths cp ON c.parent_id = cp.id
+		), source AS (
+			SELECT
+				p.id AS product_id,
+				p.shop_id,
+				p.slug,
+				p.name AS title,
+				p.description,
+				` + productImageUrlExpr + ` AS image_link,
+				p.price,
+				CASE WHEN ` + onSale + ` THEN ` + sale + ` END AS sale_price,
+				CASE WHEN ` + onSale + ` THEN pr.starts_at END AS sale_starts_at,
+				CASE WHEN ` + onSale + ` THEN pr.ends_at END AS sale_ends_at,
+				CASE WHEN ` + productInStockExpr + ` THEN 'in_stock' ELSE 'out_of_stock' END AS availability,
+				b.name AS brand,
+				COALESCE((SELECT path FROM category_paths WHERE id = p.category_id), c.name) AS product_type` + productListFrom + `
+				AND s.deleted_at IS NULL
+				AND p.status = 'published'
+		), updated AS (
+			INSERT INTO product_feed_items (product_id, ` + feedItemColumns + `)
+			SELECT product_id, ` + feedItemColumns + ` FROM source
+			ON CONFLICT (product_id) DO UPDATE SET
+				(` + feedItemColumns + `) = (` + excluded + `),
+				updated_at = NOW(),
+				removed_at = NULL
+			WHERE
+				product_feed_items.removed_at IS NOT NULL
+				OR (` + current + `) IS DISTINCT FROM (` + excluded + `)
+			RETURNING 1
+		), removed AS (
+			UPDATE product_feed_items fi
+			SET removed_at = NOW(), updated_at = NOW()
+			WHERE
+				fi.removed_at IS NULL
+				AND NOT EXISTS (SELECT 1 FROM source WHERE source.product_id = fi.product_id)
+			RETURNING 1
+		)
+		SELECT
+			(SELECT COUNT(*) FROM updated) AS updated,
+			(SELECT COUNT(*) FROM removed) AS removed
+	`
+
+	err := r.db.GetContext(ctx, resp, query)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::SyncFeedItems - Failed to sync feed items")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetFeedShop returns the shop of a shop feed, deleted shops have no feed.
+func (r *productRepository) GetFeedShop(ctx context.Context, shopId string) (*entity.Feed, error) {
+	var resp = new(entity.Feed)
+
+	query := `
+		SELECT slug, name AS title, description, version
+		FROM shops
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	err := r.db.GetContext(ctx, resp, r.db.Rebind(query), shopId)
+	if err != nil {
+		log.Error().Err(err).Str("shop_id", shopId).Msg("repository::GetFeedShop - Failed to get shop")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetFeedStats sums up the items of the feed of a shop, or of the
+// site-wide feed when shopId is empty.
+func (r *productRepository) GetFeedStats(ctx context.Context, shopId string) (*entity.FeedStats, error) {
+	var (
+		resp = new(entity.FeedStats)
+		args = make([]any, 0, 1)
+	)
+
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE removed_at IS NULL) AS item_count,
+			MAX(updated_at) AS last_modified
+		FROM product_feed_items`
+
+	if shopId != "" {
+		query += " WHERE shop_id = ?"
+		args = append(args, shopId)
+	}
+
+	err := r.db.GetContext(ctx, resp, r.db.Rebind(query), args...)
+	if err != nil {
+		log.Error().Err(err).Str("shop_id", shopId).Msg("repository::GetFeedStats - Failed to get feed stats")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// StreamFeedItems hands the items of the feed of a shop, or of the site-wide
+// feed when shopId is empty, to fn one at a time. It stops at the first
+// error of fn.
+func (r *productRepository) StreamFeedItems(ctx context.Context, shopId string, fn func(item *entity.FeedItem) error) error {
+	var args = make([]any, 0, 1)
+
+	query := `
+		SELECT product_id, ` + feedItemColumns + `
+		FROM product_feed_items
+		WHERE removed_at IS NULL`
+
+	if shopId != "" {
+		query += " AND shop_id = ?"
+		args = append(args, shopId)
+	}
+	query += " ORDER BY product_id"
+
+	rows, err := r.db.QueryxContext(ctx, r.db.Rebind(query), args...)
+	if err != nil {
+		log.Error().Err(err).Str("shop_id", shopId).Msg("repository::StreamFeedItems - Failed to query feed items")
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entity.FeedItem
+		if err := rows.StructScan(&item); err != nil {
+			log.Error().Err(err).Msg("repository::StreamFeedItems - Failed to scan feed item")
+			return err
+		}
+
+		if err := fn(&item); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r *productRepository) GetProduct(ctx context.Context, req *entity.GetProductRequest) (*entity.GetProductResponse, error) {
+	var data struct {
+		entity.GetProductResponse
+		promotionDao
+	}
+
+	query := `
+	  SELECT
+			p.id,
+			p.slug,
+			p.name,
+			p.description,
+			p.price,
+			` + effectivePrice("p.price") + ` AS effective_price,
+			p.stock,
+			p.stock - ` + types.ReservedStockExpr("p.id", "NULL") + ` AS available_stock,
+			p.status,
+			p.published_at,
+			p.publish_at,
+			p.unpublish_at,
+			p.created_at,
+			p.updated_at,
+			p.version,
+			p.user_id,
+			p.shop_id,
+			p.brand_id,
+			s.slug AS "shop.slug",
+			s.name AS "shop.name",
+			s.description AS "shop.description",
+			s.terms AS "shop.terms",
+			c.id AS "category.id",
+			c.name AS "category.name",
+			b.name AS "brand.name",
+			p.attributes,
+			` + productRatingExpr + ` AS rating,
+			p.rating_count AS review_count,
+			p.rating_1_count AS "rating_histogram.star_1",
+			p.rating_2_count AS "rating_histogram.star_2",
+			p.rating_3_count AS "rating_histogram.star_3",
+			p.rating_4_count AS "rating_histogram.star_4",
+			p.rating_5_count AS "rating_histogram.star_5",
+			` + productImageUrlExpr + ` AS image_url,` + productPromotionColumns + `
+		FROM products p
+		INNER JOIN shops s ON p.shop_id = s.id
+		INNER JOIN categories c ON p.category_id = c.id
+		INNER JOIN brands b ON p.brand_id = b.id` + productPromotionJoin + `
+		WHERE p.id = ? AND p.deleted_at IS NULL
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.Id).StructScan(&data)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetProduct - Failed to get product")
+		return nil, err
+	}
+
+	resp := &data.GetProductResponse
+	resp.Promotion = data.applied()
+
+	resp.Category.Breadcrumb = make([]entity.CategoryCrumb, 0)
+
+	query = `
+		WITH RECURSIVE path AS (
+			SELECT id, name, parent_id, 0 AS depth
+			FROM categories
+			WHERE id = ?
+			UNION ALL
+			SELECT c.id, c.name, c.parent_id, path.depth + 1
+			FROM categories c
+			INNER JOIN path ON c.id = path.parent_id
+		)
+		SELECT id, name
+		FROM path
+		ORDER BY depth DESC
+	`
+
+	err = r.db.SelectContext(ctx, &resp.Category.Breadcrumb, r.db.Rebind(query), resp.Category.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetProduct - Failed to get category breadcrumb")
+		return nil, err
+	}
+
+	resp.Variants = make([]entity.ProductVariant, 0)
+
+	query = `
+		SELECT
+			v.id,
+			v.sku,
+			v.options,
+			COALESCE(v.price, p.price) AS price,
+			` + effectivePrice("COALESCE(v.price, p.price)") + ` AS effective_price,
+			v.price AS price_override,
+			v.stock,
+			v.stock - ` + types.ReservedStockExpr("v.product_id", "v.id") + ` AS available_stock,
+			v.barcode
+		FROM product_variants v
+		INNER JOIN products p ON v.product_id = p.id` + productPromotionJoin + `
+		WHERE v.product_id = ? AND v.deleted_at IS NULL
+		ORDER BY v.created_at ASC, v.id ASC
+	`
+
+	err = r.db.SelectContext(ctx, &resp.Variants, r.db.Rebind(query), resp.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetProduct - Failed to get product variants")
+		return nil, err
+	}
+
+	resp.Images = make([]entity.ProductImage, 0)
+
+	query = `
+		SELECT id, url, alt_text, position, is_primary
+		FROM product_images
+		WHERE product_id = ?
+		ORDER BY position ASC, created_at ASC
+	`
+
+	err = r.db.SelectContext(ctx, &resp.Images, r.db.Rebind(query), resp.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetProduct - Failed to get product images")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) UpdateProduct(ctx context.Context, req *entity.UpdateProductRequest) (*entity.UpdateProductResponse, error) {
+	var resp = new(entity.UpdateProductResponse)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateProduct - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	stock, err := lockStock(ctx, tx, req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateProduct - Failed to lock product")
+		return nil, err
+	}
+
+	query := `
+		UPDATE products
+		SET shop_id = ?, category_id = ?, brand_id = ?, name = ?, description = ?, price = ?, stock = ?, attributes = ?, updated_at = NOW(), version = version + 1
+		WHERE id = ? AND user_id = ? AND deleted_at IS NULL` + types.VersionCondition + `
+		RETURNING id
+	`
+
+	err = tx.QueryRowxContext(ctx, tx.Rebind(query),
+		req.ShopId,
+		req.CategoryId,
+		req.BrandId,
+		req.Name,
+		req.Description,
+		req.Price,
+		req.Stock,
+		req.Attributes,
+		req.Id,
+		req.UserId,
+		req.Version,
+		req.Version).Scan(&resp.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateProduct - Failed to update product")
+		return nil, err
 	}
-}
 
-func (r *productRepository) GetProducts(ctx context.Context, req *entity.ProductsRequest) (*entity.ProductsResponse, error) {
-	type dao struct {
-		TotalData int `db:"total_data"`
-		entity.ProductItem
+	if _, err := slug.Rename(ctx, tx, slug.Products, req.Id, req.Name); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateProduct - Failed to rename slug")
+		return nil, err
+	}
+
+	if err := recordStockCorrection(ctx, tx, req.Id, req.UserId, stock, req.Stock); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateProduct - Failed to record stock correction")
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateProduct - Failed to commit transaction")
+		return nil, err
 	}
 
+	return resp, nil
+}
+
+// PatchProduct writes only the fields present in the merge patch. Null
+// description and attributes are stored as their empty values since the
+// columns are NOT NULL.
+func (r *productRepository) PatchProduct(ctx context.Context, req *entity.PatchProductRequest) (*entity.UpdateProductResponse, error) {
 	var (
-		resp = new(entity.ProductsResponse)
-		data = make([]dao, 0, req.Paginate)
+		resp = new(entity.UpdateProductResponse)
+		sets []string
+		args []any
 	)
-	resp.Items = make([]entity.ProductItem, 0, req.Paginate)
+
+	set := func(field, column string, value any) {
+		if req.Fields.Has(field) {
+			sets = append(sets, column+" = ?")
+			args = append(args, value)
+		}
+	}
+
+	set("shop_id", "shop_id", req.ShopId)
+	set("category_id", "category_id", req.CategoryId)
+	set("brand_id", "brand_id", req.BrandId)
+	set("name", "name", req.Name)
+	if req.Description == nil {
+		set("description", "description", "")
+	} else {
+		set("description", "description", req.Description)
+	}
+	set("price", "price", req.Price)
+	set("stock", "stock", req.Stock)
+	if req.Fields.IsNull("attributes") {
+		set("attributes", "attributes", req.Attributes)
+	} else if req.Fields.Has("attributes") {
+		// attribute values are scalars, so a top level merge that drops the
+		// null keys is the whole of RFC 7386
+		sets = append(sets, "attributes = jsonb_strip_nulls(attributes || ?::JSONB)")
+		args = append(args, req.Attributes)
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::PatchProduct - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var stock int
+	if req.Stock != nil {
+		stock, err = lockStock(ctx, tx, req.Id)
+		if err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::PatchProduct - Failed to lock product")
+			return nil, err
+		}
+	}
 
 	query := `
-		SELECT
-			COUNT(p.id) OVER() as total_data,
-			p.id,
-			p.name,
-			p.description,
-			p.price,
-			p.stock,
-			p.user_id,
-			s.name AS "shop.name",
-			s.description AS "shop.description",
-			s.terms AS "shop.terms",
-			c.name AS "category.name",
-			b.name AS "brand.name",
-			COALESCE(
-				ROUND(
-					(SELECT AVG(rating) FROM reviews WHERE product_id = p.id),
-					1
-				),
-				0.0
-			) AS rating
-		FROM products p
-		INNER JOIN shops s ON p.shop_id = s.id
-		INNER JOIN categories c ON p.category_id = c.id
-		INNER JOIN brands b ON p.brand_id = b.id
-		WHERE
-			p.user_id = ?
-			AND p.deleted_at IS NULL
+		UPDATE products
+		SET ` + strings.Join(append(sets, "updated_at = NOW()", "version = version + 1"), ", ") + `
+		WHERE id = ? AND user_id = ? AND deleted_at IS NULL` + types.VersionCondition + `
+		RETURNING id
 	`
+	args = append(args, req.Id, req.UserId, req.Version, req.Version)
 
-	args := []interface{}{req.UserId}
+	err = tx.QueryRowxContext(ctx, tx.Rebind(query), args...).Scan(&resp.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::PatchProduct - Failed to patch product")
+		return nil, err
+	}
 
-	if len(req.CategoryIds) > 0 {
-		placeholders := make([]string, len(req.CategoryIds))
-		for i := range req.CategoryIds {
-			placeholders[i] = "?"
-			args = append(args, req.CategoryIds[i])
+	if req.Name != nil {
+		if _, err := slug.Rename(ctx, tx, slug.Products, req.Id, *req.Name); err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::PatchProduct - Failed to rename slug")
+			return nil, err
 		}
-		query += fmt.Sprintf(" AND c.id IN (%s)", strings.Join(placeholders, ","))
 	}
 
-	if len(req.BrandIds) > 0 {
-		placeholders := make([]string, len(req.BrandIds))
-		for i := range req.BrandIds {
-			placeholders[i] = "?"
-			args = append(args, req.BrandIds[i])
+	if req.Stock != nil {
+		if err := recordStockCorrection(ctx, tx, req.Id, req.UserId, stock, *req.Stock); err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::PatchProduct - Failed to record stock correction")
+			return nil, err
 		}
-		query += fmt.Sprintf(" AND b.id IN (%s)", strings.Join(placeholders, ","))
 	}
 
-	if req.MinPrice != nil {
-		query += " AND p.price >= ?"
-		args = append(args, *req.MinPrice)
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::PatchProduct - Failed to commit transaction")
+		return nil, err
 	}
 
-	if req.MaxPrice != nil {
-		query += " AND p.price <= ?"
-		args = append(args, *req.MaxPrice)
-	}
+	return resp, nil
+}
 
-	if req.MinRating > 0 {
-		query += " AND COALESCE(ROUND((SELECT AVG(rating) FROM reviews WHERE product_id = p.id), 1), 0.0) >= ?"
-		args = append(args, req.MinRating)
+// lockStock locks the product row for the rest of the transaction and
+// returns its stock.
+func lockStock(ctx context.Context, tx *sqlx.Tx, productId string) (int, error) {
+	var stock int
+
+	query := `SELECT stock FROM products WHERE id = ? AND deleted_at IS NULL FOR UPDATE`
+
+	err := tx.GetContext(ctx, &stock, tx.Rebind(query), productId)
+
+	return stock, err
+}
+
+// recordStockCorrection keeps the inventory ledger in line with a stock
+// overwritten by a product update.
+func recordStockCorrection(ctx context.Context, tx *sqlx.Tx, productId, actorId string, from, to int) error {
+	if from == to {
+		return nil
 	}
 
-	if req.SearchQuery != "" {
-		query += " AND (p.name ILIKE ? OR p.description ILIKE ?)"
-		searchTerm := "%" + req.SearchQuery + "%"
-		args = append(args, searchTerm, searchTerm)
+	query := `
+		INSERT INTO inventory_movements (product_id, delta, reason, actor_id, note, stock_after)
+		VALUES (?, ?, 'correction', ?, 'product update', ?)
+	`
+
+	_, err := tx.ExecContext(ctx, tx.Rebind(query), productId, to-from, actorId, to)
+
+	return err
+}
+
+// DeleteProduct returns sql.ErrNoRows when nothing was deleted, e.g. on a
+// stale version.
+func (r *productRepository) DeleteProduct(ctx context.Context, req *entity.DeleteProductRequest) error {
+	query := `
+		UPDATE products
+		SET deleted_at = NOW(), version = version + 1
+		WHERE id = ? AND user_id = ? AND deleted_at IS NULL` + types.VersionCondition
+
+	res, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.Id, req.UserId, req.Version, req.Version)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteProduct - Failed to delete product")
+		return err
 	}
 
-	if req.IsAvailable {
-		query += " AND p.stock > 0"
+	if n, _ := res.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
 	}
 
-	query += " ORDER BY p.created_at DESC LIMIT ? OFFSET ?"
-	args = append(args, req.Paginate, req.Paginate*(req.Page-1))
+	return nil
+}
+
+func (r *productRepository) GetProductVersion(ctx context.Context, id string) (int, error) {
+	var version int
 
-	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query), args...)
+	query := `SELECT version FROM products WHERE id = ? AND deleted_at IS NULL`
+
+	err := r.db.GetContext(ctx, &version, r.db.Rebind(query), id)
 	if err != nil {
-		log.Error().Err(err).Any("payload", req).Msg("repository::GetProducts - Failed to get products")
-		return nil, err
+		log.Error().Err(err).Str("id", id).Msg("repository::GetProductVersion - Failed to get product version")
+		return 0, err
 	}
 
-	if len(data) > 0 {
-		resp.Meta.TotalData = data[0].TotalData
-	}
+	return version, nil
+}
 
-	for _, d := range data {
-		resp.Items = append(resp.Items, d.ProductItem)
+func (r *productRepository) GetProductStatus(ctx context.Context, id string) (string, error) {
+	var status string
+
+	query := `SELECT status FROM products WHERE id = ? AND deleted_at IS NULL`
+
+	err := r.db.GetContext(ctx, &status, r.db.Rebind(query), id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::GetProductStatus - Failed to get product status")
+		return "", err
 	}
 
-	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+	return status, nil
+}
+
+// UpdateProductStatus moves the product from the given status to the
+// requested one. It returns sql.ErrNoRows when the status changed meanwhile.
+// Publishing stamps published_at and drops the pending publish_at, any other
+// transition drops the whole schedule.
+func (r *productRepository) UpdateProductStatus(ctx context.Context, req *entity.TransitionProductRequest, from string) (*entity.TransitionProductResponse, error) {
+	var resp = new(entity.TransitionProductResponse)
+
+	query := `
+		UPDATE products
+		SET
+			status = ?,
+			published_at = CASE WHEN ? = 'published' THEN NOW() ELSE published_at END,
+			publish_at = NULL,
+			unpublish_at = CASE WHEN ? = 'published' THEN unpublish_at END,
+			updated_at = NOW(),
+			version = version + 1
+		WHERE id = ? AND status = ? AND deleted_at IS NULL
+		RETURNING id, status, published_at
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query),
+		req.Status,
+		req.Status,
+		req.Status,
+		req.Id,
+		from).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateProductStatus - Failed to update product status")
+		return nil, err
+	}
 
 	return resp, nil
 }
 
-func (r *productRepository) CreateProduct(ctx context.Context, req *entity.CreateProductRequest) (*entity.CreateProductResponse, error) {
-	var resp = new(entity.CreateProductResponse)
+// ScheduleProduct stores the publish and unpublish times. Archived products
+// can't be scheduled and yield sql.ErrNoRows.
+func (r *productRepository) ScheduleProduct(ctx context.Context, req *entity.ScheduleProductRequest) (*entity.ScheduleProductResponse, error) {
+	var resp = new(entity.ScheduleProductResponse)
 
 	query := `
-		INSERT INTO products (shop_id, category_id, brand_id, name, description, price, stock, user_id)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?) RETURNING id
+		UPDATE products
+		SET publish_at = ?, unpublish_at = ?, updated_at = NOW(), version = version + 1
+		WHERE id = ? AND status <> 'archived' AND deleted_at IS NULL
+		RETURNING id, status, publish_at, unpublish_at
 	`
 
 	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query),
-		req.ShopId,
-		req.CategoryId,
-		req.BrandId,
-		req.Name,
-		req.Description,
-		req.Price,
-		req.Stock,
-		req.UserId).Scan(&resp.Id)
+		req.PublishAt,
+		req.UnpublishAt,
+		req.Id).StructScan(resp)
 	if err != nil {
-		log.Error().Err(err).Any("payload", req).Msg("repository::CreateProduct - Failed to create product")
+		log.Error().Err(err).Any("payload", req).Msg("repository::ScheduleProduct - Failed to schedule product")
 		return nil, err
 	}
 
 	return resp, nil
 }
 
-func (r *productRepository) GetProduct(ctx context.Context, req *entity.GetProductRequest) (*entity.GetProductResponse, error) {
-	var resp = new(entity.GetProductResponse)
+// ApplySchedules publishes the drafts whose publish_at has passed and moves
+// the published products whose unpublish_at has passed back to draft.
+func (r *productRepository) ApplySchedules(ctx context.Context) (*entity.ScheduleResult, error) {
+	var resp = new(entity.ScheduleResult)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::ApplySchedules - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
 
 	query := `
-	  SELECT
+		UPDATE products
+		SET status = 'published', published_at = NOW(), publish_at = NULL, updated_at = NOW(), version = version + 1
+		WHERE status = 'draft' AND publish_at <= NOW() AND deleted_at IS NULL
+	`
+
+	res, err := tx.ExecContext(ctx, query)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::ApplySchedules - Failed to publish scheduled products")
+		return nil, err
+	}
+	resp.Published, _ = res.RowsAffected()
+
+	query = `
+		UPDATE products
+		SET status = 'draft', unpublish_at = NULL, updated_at = NOW(), version = version + 1
+		WHERE status = 'published' AND unpublish_at <= NOW() AND deleted_at IS NULL
+	`
+
+	res, err = tx.ExecContext(ctx, query)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::ApplySchedules - Failed to unpublish scheduled products")
+		return nil, err
+	}
+	resp.Unpublished, _ = res.RowsAffected()
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("repository::ApplySchedules - Failed to commit transaction")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) GetTrashedProducts(ctx context.Context, req *entity.TrashedProductsRequest) (*entity.TrashedProductsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.TrashedProductItem
+	}
+
+	var (
+		resp = new(entity.TrashedProductsResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.TrashedProductItem, 0, req.Paginate)
+
+	query := `
+		SELECT
+			COUNT(p.id) OVER() as total_data,
 			p.id,
 			p.name,
-			p.description,
-			p.price,
-			p.stock,
-			p.user_id,
-			s.name AS "shop.name",
-			s.description AS "shop.description",
-			s.terms AS "shop.terms",
-			c.name AS "category.name",
-			b.name AS "brand.name",
-			COALESCE(
-				ROUND(
-					(SELECT AVG(rating) FROM reviews WHERE product_id = p.id),
-					1
-				),
-				0.0
-			) AS rating
+			p.shop_id,
+			s.name AS shop_name,
+			p.deleted_at,
+			p.deleted_at + make_interval(days => ?) AS purge_at
 		FROM products p
 		INNER JOIN shops s ON p.shop_id = s.id
-		INNER JOIN categories c ON p.category_id = c.id
-		INNER JOIN brands b ON p.brand_id = b.id
-		WHERE p.id = ? AND p.deleted_at IS NULL
+		WHERE p.user_id = ? AND p.deleted_at IS NOT NULL
+		ORDER BY p.deleted_at DESC, p.id DESC
+		LIMIT ? OFFSET ?
 	`
 
-	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.Id).StructScan(resp)
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query),
+		req.RetentionDays,
+		req.UserId,
+		req.Paginate,
+		req.Paginate*(req.Page-1))
 	if err != nil {
-		log.Error().Err(err).Any("payload", req).Msg("repository::GetProduct - Failed to get product")
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetTrashedProducts - Failed to get trashed products")
 		return nil, err
 	}
 
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.TrashedProductItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
 	return resp, nil
 }
 
-func (r *productRepository) UpdateProduct(ctx context.Context, req *entity.UpdateProductRequest) (*entity.UpdateProductResponse, error) {
-	var resp = new(entity.UpdateProductResponse)
+func (r *productRepository) GetTrashedProduct(ctx context.Context, id string) (*entity.TrashedProduct, error) {
+	var resp = new(entity.TrashedProduct)
 
 	query := `
-		UPDATE products
-		SET shop_id = ?, category_id = ?, name = ?, description = ?, price = ?, stock = ?, updated_at = NOW()
-		WHERE id = ? AND user_id = ?
-		RETURNING id
+		SELECT p.id, p.user_id, s.deleted_at IS NOT NULL AS shop_deleted
+		FROM products p
+		INNER JOIN shops s ON p.shop_id = s.id
+		WHERE p.id = ? AND p.deleted_at IS NOT NULL
 	`
 
-	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query),
-		req.ShopId,
-		req.CategoryId,
-		req.Name,
-		req.Description,
-		req.Price,
-		req.Stock,
-		req.Id,
-		req.UserId).Scan(&resp.Id)
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), id).StructScan(resp)
 	if err != nil {
-		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateProduct - Failed to update product")
+		log.Error().Err(err).Str("id", id).Msg("repository::GetTrashedProduct - Failed to get trashed product")
 		return nil, err
 	}
 
 	return resp, nil
 }
 
-func (r *productRepository) DeleteProduct(ctx context.Context, req *entity.DeleteProductRequest) error {
+func (r *productRepository) RestoreProduct(ctx context.Context, req *entity.RestoreProductRequest) (*entity.RestoreProductResponse, error) {
+	var resp = new(entity.RestoreProductResponse)
+
 	query := `
 		UPDATE products
-		SET deleted_at = NOW()
-		WHERE id = ? AND user_id = ? AND deleted_at IS NULL
+		SET deleted_at = NULL, updated_at = NOW(), version = version + 1
+		WHERE id = ? AND user_id = ? AND deleted_at IS NOT NULL
+		RETURNING id
 	`
 
-	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.Id, req.UserId)
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.Id, req.UserId).Scan(&resp.Id)
 	if err != nil {
-		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteProduct - Failed to delete product")
-		return err
+		log.Error().Err(err).Any("payload", req).Msg("repository::RestoreProduct - Failed to restore product")
+		return nil, err
 	}
 
-	return nil
+	return resp, nil
+}
+
+// PurgeProducts hard deletes the products soft-deleted before the given time,
+// their variants, images and reviews go with them.
+func (r *productRepository) PurgeProducts(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM products WHERE deleted_at < ?`
+
+	res, err := r.db.ExecContext(ctx, r.db.Rebind(query), before)
+	if err != nil {
+		log.Error().Err(err).Time("before", before).Msg("repository::PurgeProducts - Failed to purge products")
+		return 0, err
+	}
+
+	return res.RowsAffected()
 }
 
 func (p *productRepository) IsShopOwner(ctx context.Context, userId, shopId string) (bool, error) {
@@ -301,3 +1779,72 @@ func (p *productRepository) IsProductOwner(ctx context.Context, userId, productI
 
 	return isOwner, nil
 }
+
+// RebuildRatings recomputes the rating aggregates of every product, or of a
+// single product when productId is set, straight from the reviews table.
+func (r *productRepository) RebuildRatings(ctx context.Context, productId string) (int64, error) {
+	query := `
+		UPDATE products p SET
+			rating_count = COALESCE(a.total, 0),
+			rating_1_count = COALESCE(a.star_1, 0),
+			rating_2_count = COALESCE(a.star_2, 0),
+			rating_3_count = COALESCE(a.star_3, 0),
+			rating_4_count = COALESCE(a.star_4, 0),
+			rating_5_count = COALESCE(a.star_5, 0),
+			rating_avg = COALESCE(ROUND(a.average, 2), 0)
+		FROM products target
+		LEFT JOIN (
+			SELECT
+				product_id,
+				COUNT(id) AS total,
+				COUNT(id) FILTER (WHERE rating = 1) AS star_1,
+				COUNT(id) FILTER (WHERE rating = 2) AS star_2,
+				COUNT(id) FILTER (WHERE rating = 3) AS star_3,
+				COUNT(id) FILTER (WHERE rating = 4) AS star_4,
+				COUNT(id) FILTER (WHERE rating = 5) AS star_5,
+				AVG(rating) AS average
+			FROM reviews
+			GROUP BY product_id
+		) a ON a.product_id = target.id
+		WHERE
+			p.id = target.id
+			AND (?::TEXT = '' OR target.id::TEXT = ?)
+	`
+
+	res, err := r.db.ExecContext(ctx, r.db.Rebind(query), productId, productId)
+	if err != nil {
+		log.Error().Err(err).Str("product_id", productId).Msg("repository::RebuildRatings - Failed to rebuild ratings")
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (r *productRepository) GetAttributeSchema(ctx context.Context, categoryId string) ([]entity.AttributeSchema, error) {
+	var resp = make([]entity.AttributeSchema, 0)
+
+	// an attribute redefined by a subcategory overrides the inherited one
+	query := `
+		WITH RECURSIVE path AS (
+			SELECT id, parent_id, 0 AS depth
+			FROM categories
+			WHERE id = ?
+			UNION ALL
+			SELECT c.id, c.parent_id, path.depth + 1
+			FROM categories c
+			INNER JOIN path ON c.id = path.parent_id
+		)
+		SELECT DISTINCT ON (a.key) a.key, a.type, a.options, a.is_required
+		FROM category_attributes a
+		INNER JOIN path ON a.category_id = path.id
+		ORDER BY a.key, path.depth ASC
+	`
+
+	err := r.db.SelectContext(ctx, &resp, r.db.Rebind(query), categoryId)
+	if err != nil {
+		log.Error().Err(err).Str("category_id", categoryId).Msg("repository::GetAttributeSchema - Failed to get attribute schema")
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"codebase-app/internal/module/product/entity"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// A variant cheaper than its product makes the lowest variant price the
+// price a listing sorts by, the listing has to show that same price.
+func TestListingPriceColumns_FollowPriceSorts(t *testing.T) {
+	assert.Contains(t, productPriceExpr, "MIN(COALESCE(v.price, p.price))")
+
+	for priceBy, column := range map[string]string{
+		entity.PriceByOriginal:  " AS price",
+		entity.PriceByEffective: " AS effective_price",
+	} {
+		for _, sortBy := range []string{entity.SortPriceAsc, entity.SortPriceDesc} {
+			sort, _ := listingSort(&entity.ProductsRequest{Sort: sortBy, PriceBy: priceBy})
+			assert.True(t, strings.Contains(listingPriceColumns, sort.expr+column), "%s by %s", sortBy, priceBy)
+		}
+	}
+}
@@ -1,10 +1,30 @@
 package service
 
 import (
+	"bufio"
+	"cmp"
+	"codebase-app/internal/adapter"
+	integration "codebase-app/internal/integration/filestorage"
+	storageEntity "codebase-app/internal/integration/filestorage/entity"
 	"codebase-app/internal/module/product/entity"
 	"codebase-app/internal/module/product/ports"
 	"codebase-app/pkg/errmsg"
+	storage "codebase-app/pkg/storage-manager"
+	"codebase-app/pkg/types"
 	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/rs/zerolog/log"
 )
@@ -12,16 +32,24 @@ import (
 var _ ports.ProductService = &productService{}
 
 type productService struct {
-	repo ports.ProductRepository
+	repo      ports.ProductRepository
+	validator adapter.Validator               // validates the rows of an import
+	storage   integration.FileStorageContract // keeps the background exports
 }
 
-func NewProductService(repo ports.ProductRepository) *productService {
+func NewProductService(repo ports.ProductRepository, validator adapter.Validator, storage integration.FileStorageContract) *productService {
 	return &productService{
-		repo: repo,
+		repo:      repo,
+		validator: validator,
+		storage:   storage,
 	}
 }
 
 func (s *productService) GetProducts(ctx context.Context, req *entity.ProductsRequest) (*entity.ProductsResponse, error) {
+	if err := validateProductsSort(req); err != nil {
+		return nil, err
+	}
+
 	res, err := s.repo.GetProducts(ctx, req)
 	if err != nil {
 		return res, err
@@ -35,6 +63,83 @@ func (s *productService) GetProducts(ctx context.Context, req *entity.ProductsRe
 	return res, nil
 }
 
+func (s *productService) GetCatalog(ctx context.Context, req *entity.ProductsRequest) (*entity.ProductsResponse, error) {
+	if err := validateProductsSort(req); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.GetCatalog(ctx, req)
+	if err != nil {
+		return res, err
+	}
+
+	if len(res.Items) == 0 {
+		log.Warn().Any("payload", req).Msg("service: Catalog products not found")
+		return res, errmsg.NewCustomErrors(404, errmsg.WithMessage("Products not found"))
+	}
+
+	return res, nil
+}
+
+// GetStorefront returns a shop with its stats and a page of its catalog
+// products. Unlike the catalog, a page without products is not an error.
+func (s *productService) GetStorefront(ctx context.Context, req *entity.StorefrontRequest) (*entity.StorefrontResponse, error) {
+	if err := validateProductsSort(&req.Filters); err != nil {
+		return nil, err
+	}
+
+	if req.ShopSlug != "" {
+		id, err := s.repo.GetShopIdBySlug(ctx, req.ShopSlug)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Shop not found"))
+			}
+			return nil, err
+		}
+		req.ShopId = id
+	}
+
+	res, err := s.repo.GetStorefront(ctx, req.ShopId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Warn().Any("payload", req).Msg("service: Storefront shop not found")
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Shop not found"))
+		}
+		return nil, err
+	}
+
+	res.Products, err = s.repo.GetShopCatalog(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// validateProductsSort rejects sort keys that need context the request
+// doesn't have, e.g. relevance without a search query, and decodes the
+// pagination cursor, which has to belong to the requested sort.
+func validateProductsSort(req *entity.ProductsRequest) error {
+	if req.Sort == entity.SortRelevance && req.SearchQuery == "" {
+		log.Warn().Any("payload", req).Msg("service: Relevance sort without search query")
+		return errmsg.NewCustomErrors(400, errmsg.WithErrors("sort", "sort relevance hanya dapat digunakan bersama search query."))
+	}
+
+	if req.Cursor == "" {
+		return nil
+	}
+
+	cursor, err := types.DecodeCursor(req.Cursor)
+	if err != nil || cursor.Sort != req.Sort {
+		log.Warn().Err(err).Any("payload", req).Msg("service: Invalid products cursor")
+		return errmsg.NewCustomErrors(400, errmsg.WithErrors("cursor", "cursor tidak valid untuk sort ini."))
+	}
+
+	req.After = cursor
+
+	return nil
+}
+
 func (s *productService) CreateProduct(ctx context.Context, req *entity.CreateProductRequest) (*entity.CreateProductResponse, error) {
 	var res *entity.CreateProductResponse
 
@@ -48,6 +153,14 @@ func (s *productService) CreateProduct(ctx context.Context, req *entity.CreatePr
 		return res, errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not shop owner"))
 	}
 
+	if err := s.validateAttributes(ctx, req.CategoryId, req.Attributes); err != nil {
+		return res, err
+	}
+
+	if req.Status == "" {
+		req.Status = entity.StatusDraft
+	}
+
 	res, err = s.repo.CreateProduct(ctx, req)
 	if err != nil {
 		return res, err
@@ -56,29 +169,162 @@ func (s *productService) CreateProduct(ctx context.Context, req *entity.CreatePr
 	return res, nil
 }
 
+// GetProduct finds the product by id or by slug, a slug the product had
+// before a rename finds it as well.
 func (s *productService) GetProduct(ctx context.Context, req *entity.GetProductRequest) (*entity.GetProductResponse, error) {
-	return s.repo.GetProduct(ctx, req)
+	if req.Slug != "" {
+		id, err := s.repo.GetProductIdBySlug(ctx, req.Slug)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Product not found"))
+			}
+			return nil, err
+		}
+		req.Id = id
+	}
+
+	res, err := s.repo.GetProduct(ctx, req)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Product not found"))
+		}
+		return nil, err
+	}
+
+	if res.Status != entity.StatusPublished && res.UserId != req.UserId {
+		log.Warn().Any("payload", req).Str("status", res.Status).Msg("service: Product is not published")
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Product not found"))
+	}
+
+	return res, nil
 }
 
-func (s *productService) UpdateProduct(ctx context.Context, req *entity.UpdateProductRequest) (*entity.UpdateProductResponse, error) {
-	var res *entity.UpdateProductResponse
+func (s *productService) UpdateProduct(ctx context.Context, req *entity.UpdateProductRequest) (*entity.GetProductResponse, error) {
+	isProductOwner, err := s.repo.IsProductOwner(ctx, req.UserId, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isProductOwner {
+		log.Warn().Any("payload", req).Msg("service: User is not product owner")
+		return nil, errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not product owner"))
+	}
+
+	isShopOwner, err := s.repo.IsShopOwner(ctx, req.UserId, req.ShopId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isShopOwner {
+		log.Warn().Any("payload", req).Msg("service: User is not shop owner")
+		return nil, errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not shop owner"))
+	}
+
+	if err := s.validateAttributes(ctx, req.CategoryId, req.Attributes); err != nil {
+		return nil, err
+	}
+
+	req.Version, err = types.CheckVersion(ctx, "Product", req.Id, req.IfMatch, s.repo.GetProductVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.UpdateProduct(ctx, req); err != nil {
+		return nil, types.WriteError("Product", err, req.Version)
+	}
 
+	return s.repo.GetProduct(ctx, &entity.GetProductRequest{UserId: req.UserId, Id: req.Id})
+}
+
+// PatchProduct applies a JSON merge patch, fields missing from the body are
+// left untouched and only the present ones have been validated.
+func (s *productService) PatchProduct(ctx context.Context, req *entity.PatchProductRequest) (*entity.GetProductResponse, error) {
 	isProductOwner, err := s.repo.IsProductOwner(ctx, req.UserId, req.Id)
 	if err != nil {
-		return res, err
+		return nil, err
 	}
 
 	if !isProductOwner {
 		log.Warn().Any("payload", req).Msg("service: User is not product owner")
-		return res, errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not product owner"))
+		return nil, errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not product owner"))
+	}
+
+	errs := errmsg.NewCustomErrors(400)
+	for _, field := range req.Fields.Unknown(entity.PatchAllowed) {
+		errs.Add(field, fmt.Sprintf("%s tidak dikenal.", strings.ReplaceAll(field, "_", " ")))
+	}
+
+	if errs.HasErrors() {
+		log.Warn().Any("payload", req).Msg("service: Unknown product fields")
+		return nil, errs
+	}
+
+	for _, field := range req.Fields.Nulls(entity.PatchNullable) {
+		errs.Add(field, fmt.Sprintf("%s tidak boleh null.", strings.ReplaceAll(field, "_", " ")))
+	}
+
+	if errs.HasErrors() {
+		log.Warn().Any("payload", req).Msg("service: Null on non nullable product fields")
+		return nil, errs
 	}
 
-	res, err = s.repo.UpdateProduct(ctx, req)
+	if req.Fields.Has("shop_id") {
+		isShopOwner, err := s.repo.IsShopOwner(ctx, req.UserId, *req.ShopId)
+		if err != nil {
+			return nil, err
+		}
+
+		if !isShopOwner {
+			log.Warn().Any("payload", req).Msg("service: User is not shop owner")
+			return nil, errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not shop owner"))
+		}
+	}
+
+	current := &entity.GetProductRequest{UserId: req.UserId, Id: req.Id}
+
+	// a new category brings a new schema the stored attributes have to match
+	if req.Fields.Has("category_id") || req.Fields.Has("attributes") {
+		product, err := s.repo.GetProduct(ctx, current)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Product not found"))
+			}
+			return nil, err
+		}
+
+		var (
+			categoryId = product.Category.Id
+			attributes = product.Attributes
+		)
+
+		if req.Fields.Has("category_id") {
+			categoryId = *req.CategoryId
+		}
+
+		// the repository merges the patch the same way when writing it
+		if req.Fields.IsNull("attributes") {
+			attributes = nil
+		} else if req.Fields.Has("attributes") {
+			attributes = types.MergePatch(product.Attributes, req.Attributes)
+		}
+
+		if err := s.validateAttributes(ctx, categoryId, attributes); err != nil {
+			return nil, err
+		}
+	}
+
+	req.Version, err = types.CheckVersion(ctx, "Product", req.Id, req.IfMatch, s.repo.GetProductVersion)
 	if err != nil {
-		return res, err
+		return nil, err
 	}
 
-	return res, nil
+	if len(req.Fields) > 0 {
+		if _, err := s.repo.PatchProduct(ctx, req); err != nil {
+			return nil, types.WriteError("Product", err, req.Version)
+		}
+	}
+
+	return s.repo.GetProduct(ctx, current)
 }
 
 func (s *productService) DeleteProduct(ctx context.Context, req *entity.DeleteProductRequest) error {
@@ -92,5 +338,936 @@ func (s *productService) DeleteProduct(ctx context.Context, req *entity.DeletePr
 		return errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not product owner"))
 	}
 
-	return s.repo.DeleteProduct(ctx, req)
+	req.Version, err = types.CheckVersion(ctx, "Product", req.Id, req.IfMatch, s.repo.GetProductVersion)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteProduct(ctx, req); err != nil {
+		return types.WriteError("Product", err, req.Version)
+	}
+
+	return nil
+}
+
+// TransitionProduct moves a product along entity.StatusTransitions.
+func (s *productService) TransitionProduct(ctx context.Context, req *entity.TransitionProductRequest) (*entity.TransitionProductResponse, error) {
+	isProductOwner, err := s.repo.IsProductOwner(ctx, req.UserId, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isProductOwner {
+		log.Warn().Any("payload", req).Msg("service: User is not product owner")
+		return nil, errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not product owner"))
+	}
+
+	from, err := s.repo.GetProductStatus(ctx, req.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Product not found"))
+		}
+		return nil, err
+	}
+
+	if !slices.Contains(entity.StatusTransitions[from], req.Status) {
+		log.Warn().Any("payload", req).Str("from", from).Msg("service: Invalid product status transition")
+		return nil, errmsg.NewCustomErrors(409, errmsg.WithErrors("status", fmt.Sprintf("status %s tidak dapat diubah menjadi %s.", from, req.Status)))
+	}
+
+	res, err := s.repo.UpdateProductStatus(ctx, req, from)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Warn().Any("payload", req).Msg("service: Product status changed concurrently")
+			return nil, errmsg.NewCustomErrors(409, errmsg.WithMessage("Product status has changed, please retry"))
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *productService) ScheduleProduct(ctx context.Context, req *entity.ScheduleProductRequest) (*entity.ScheduleProductResponse, error) {
+	isProductOwner, err := s.repo.IsProductOwner(ctx, req.UserId, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isProductOwner {
+		log.Warn().Any("payload", req).Msg("service: User is not product owner")
+		return nil, errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not product owner"))
+	}
+
+	var (
+		errs = errmsg.NewCustomErrors(400)
+		now  = time.Now()
+	)
+
+	if req.PublishAt != nil && !req.PublishAt.After(now) {
+		errs.Add("publish_at", "publish at harus di masa depan.")
+	}
+
+	if req.UnpublishAt != nil && !req.UnpublishAt.After(now) {
+		errs.Add("unpublish_at", "unpublish at harus di masa depan.")
+	}
+
+	if req.PublishAt != nil && req.UnpublishAt != nil && !req.UnpublishAt.After(*req.PublishAt) {
+		errs.Add("unpublish_at", "unpublish at harus setelah publish at.")
+	}
+
+	if errs.HasErrors() {
+		log.Warn().Any("payload", req).Msg("service: Invalid product schedule")
+		return nil, errs
+	}
+
+	res, err := s.repo.ScheduleProduct(ctx, req)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Warn().Any("payload", req).Msg("service: Archived product can't be scheduled")
+			return nil, errmsg.NewCustomErrors(409, errmsg.WithErrors("status", "produk yang diarsipkan tidak dapat dijadwalkan."))
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ApplySchedules is run by the scheduler, see handler/job.
+func (s *productService) ApplySchedules(ctx context.Context) (*entity.ScheduleResult, error) {
+	res, err := s.repo.ApplySchedules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Published > 0 || res.Unpublished > 0 {
+		log.Info().Int64("published", res.Published).Int64("unpublished", res.Unpublished).Msg("service: Product schedules applied")
+	}
+
+	return res, nil
+}
+
+func (s *productService) GetTrashedProducts(ctx context.Context, req *entity.TrashedProductsRequest) (*entity.TrashedProductsResponse, error) {
+	return s.repo.GetTrashedProducts(ctx, req)
+}
+
+func (s *productService) RestoreProduct(ctx context.Context, req *entity.RestoreProductRequest) (*entity.RestoreProductResponse, error) {
+	product, err := s.repo.GetTrashedProduct(ctx, req.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Product not found in trash"))
+		}
+		return nil, err
+	}
+
+	if product.UserId != req.UserId {
+		log.Warn().Any("payload", req).Msg("service: User is not product owner")
+		return nil, errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not product owner"))
+	}
+
+	if product.ShopDeleted {
+		log.Warn().Any("payload", req).Msg("service: Product shop is deleted")
+		return nil, errmsg.NewCustomErrors(409, errmsg.WithMessage("Shop is deleted, restore the shop first"))
+	}
+
+	res, err := s.repo.RestoreProduct(ctx, req)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Product not found in trash"))
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// PurgeProducts hard deletes the products that sit in the trash since before the given time.
+func (s *productService) PurgeProducts(ctx context.Context, before time.Time) (int64, error) {
+	total, err := s.repo.PurgeProducts(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+
+	if total > 0 {
+		log.Info().Int64("products", total).Time("before", before).Msg("service: Trashed products purged")
+	}
+
+	return total, nil
+}
+
+// ImportProducts creates the products of a CSV or JSON Lines file. Every row
+// is checked before anything is written and a file with an invalid row is
+// rejected as a whole, a dry run stops after the checks.
+func (s *productService) ImportProducts(ctx context.Context, req *entity.ImportProductsRequest) (*entity.ImportProductsResponse, error) {
+	isShopOwner, err := s.repo.IsShopOwner(ctx, req.UserId, req.ShopId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isShopOwner {
+		log.Warn().Any("payload", req).Msg("service: User is not shop owner")
+		return nil, errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not shop owner"))
+	}
+
+	records, err := types.DecodeRecords[entity.ImportProductRow](req.File, req.Format)
+	if err != nil {
+		log.Warn().Err(err).Str("format", req.Format).Msg("service: Unreadable import file")
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("file", fmt.Sprintf("file %s tidak dapat dibaca: %s.", req.Format, err)))
+	}
+
+	if len(records) == 0 {
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("file", "file tidak berisi data."))
+	}
+
+	var categoryKeys, brandKeys []string
+	for _, record := range records {
+		if record.Err != nil {
+			continue
+		}
+		categoryKeys = append(categoryKeys, cmp.Or(record.Value.CategoryId, record.Value.Category))
+		brandKeys = append(brandKeys, cmp.Or(record.Value.BrandId, record.Value.Brand))
+	}
+
+	categories, err := s.repo.GetCategoryRefs(ctx, unique(categoryKeys))
+	if err != nil {
+		return nil, err
+	}
+
+	brands, err := s.repo.GetBrandRefs(ctx, unique(brandKeys))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		res = &entity.ImportProductsResponse{
+			DryRun: req.DryRun,
+			Total:  len(records),
+			Rows:   make([]entity.ImportRowError, 0),
+		}
+		products = make([]*entity.CreateProductRequest, 0, len(records))
+		schemas  = make(map[string][]entity.AttributeSchema)
+		refs     = importRefs{categories: refIndex(categories), brands: refIndex(brands)}
+	)
+
+	for _, record := range records {
+		product, errs := s.importRow(req, record, refs)
+
+		if product != nil && !errs.HasErrors() {
+			schema, ok := schemas[product.CategoryId]
+			if !ok {
+				schema, err = s.repo.GetAttributeSchema(ctx, product.CategoryId)
+				if err != nil {
+					return nil, err
+				}
+				schemas[product.CategoryId] = schema
+			}
+
+			if attributeErrs := checkAttributes(schema, product.Attributes); attributeErrs != nil {
+				errs = attributeErrs
+			}
+		}
+
+		if errs.HasErrors() {
+			res.Invalid++
+			res.Rows = append(res.Rows, entity.ImportRowError{Row: record.Line, Errors: errs.Errors})
+			continue
+		}
+
+		res.Valid++
+		products = append(products, product)
+	}
+
+	if res.Invalid > 0 {
+		log.Warn().Str("shop_id", req.ShopId).Int("invalid", res.Invalid).Bool("dry_run", req.DryRun).Msg("service: Import file contains invalid rows")
+		if req.DryRun {
+			return res, nil
+		}
+		return res, errmsg.NewCustomErrors(400, errmsg.WithMessage("Import file contains invalid rows"))
+	}
+
+	if req.DryRun {
+		return res, nil
+	}
+
+	res.Created, err = s.repo.ImportProducts(ctx, products, req.BatchSize)
+	if err != nil {
+		return res, err
+	}
+
+	log.Info().Str("shop_id", req.ShopId).Int("created", res.Created).Msg("service: Products imported")
+
+	return res, nil
+}
+
+// importRefs indexes categories and brands by id and by lowercased name,
+// matching ids doesn't care about case either. A name can belong to more than
+// one category or brand, so a key maps to every id it matches.
+type importRefs struct {
+	categories map[string][]string
+	brands     map[string][]string
+}
+
+func refIndex(refs []entity.ImportRef) map[string][]string {
+	index := make(map[string][]string, len(refs)*2)
+	for _, ref := range refs {
+		index[strings.ToLower(ref.Id)] = []string{ref.Id}
+	}
+	for _, ref := range refs {
+		name := strings.ToLower(ref.Name)
+		if !slices.Contains(index[name], ref.Id) {
+			index[name] = append(index[name], ref.Id)
+		}
+	}
+	return index
+}
+
+// importRow turns a row into a create request and runs it through the same
+// validation as creating a single product, the attributes are left to the caller.
+func (s *productService) importRow(req *entity.ImportProductsRequest, record types.Record[entity.ImportProductRow], refs importRefs) (*entity.CreateProductRequest, *errmsg.CustomError) {
+	errs := errmsg.NewCustomErrors(400)
+
+	if record.Err != nil {
+		var fieldErr *types.FieldError
+		if errors.As(record.Err, &fieldErr) && fieldErr.Field != "" {
+			errs.Add(fieldErr.Field, fmt.Sprintf("%s tidak valid.", strings.ReplaceAll(fieldErr.Field, "_", " ")))
+		} else {
+			errs.Add("row", fmt.Sprintf("baris tidak dapat dibaca: %s.", record.Err))
+		}
+		return nil, errs
+	}
+
+	var (
+		row     = record.Value
+		product = &entity.CreateProductRequest{
+			ShopId:      req.ShopId,
+			UserId:      req.UserId,
+			Name:        row.Name,
+			Description: row.Description,
+			Price:       row.Price,
+			Stock:       row.Stock,
+			Status:      cmp.Or(row.Status, entity.StatusDraft),
+			Attributes:  row.Attributes,
+		}
+		unresolved = make(map[string]bool)
+	)
+
+	product.CategoryId = resolveRef(errs, unresolved, "category", row.CategoryId, row.Category, refs.categories)
+	product.BrandId = resolveRef(errs, unresolved, "brand", row.BrandId, row.Brand, refs.brands)
+
+	if err := s.validator.Validate(product); err != nil {
+		_, fields := errmsg.Errors(err, product)
+		if fields, ok := fields.(map[string][]string); ok {
+			for field, msgs := range fields {
+				if unresolved[field] {
+					continue
+				}
+				errs.Errors[field] = append(errs.Errors[field], msgs...)
+			}
+		}
+	}
+
+	return product, errs
+}
+
+// resolveRef finds the id of the category or brand a row points at, an
+// unknown reference or a name shared by several of them is reported and its
+// id field is marked as unresolved.
+func resolveRef(errs *errmsg.CustomError, unresolved map[string]bool, field, id, name string, refs map[string][]string) string {
+	switch {
+	case id != "":
+		if resolved := refs[strings.ToLower(id)]; len(resolved) == 1 {
+			return resolved[0]
+		}
+		errs.Add(field+"_id", fmt.Sprintf("%s id tidak ditemukan.", field))
+	case name != "":
+		switch resolved := refs[strings.ToLower(name)]; len(resolved) {
+		case 0:
+			errs.Add(field, fmt.Sprintf("%s %s tidak ditemukan.", field, name))
+		case 1:
+			return resolved[0]
+		default:
+			errs.Add(field, fmt.Sprintf("%s %s ambigu, gunakan %s id.", field, name, field))
+		}
+	default:
+		return "" // reported by the validator as missing
+	}
+
+	unresolved[field+"_id"] = true
+	return ""
+}
+
+// unique drops empty and repeated keys.
+func unique(keys []string) []string {
+	var (
+		seen = make(map[string]bool, len(keys))
+		resp = make([]string, 0, len(keys))
+	)
+
+	for _, key := range keys {
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		resp = append(resp, key)
+	}
+
+	return resp
+}
+
+// ExportProducts checks an export request. It returns nil when the products
+// are few enough to be streamed right away with WriteExport, otherwise the
+// export is queued for the export job and returned.
+func (s *productService) ExportProducts(ctx context.Context, req *entity.ExportProductsRequest) (*entity.ProductExport, error) {
+	req.Filters.UserId = req.UserId
+	req.Filters.Cursor = "" // exports aren't paged
+
+	if err := validateProductsSort(&req.Filters); err != nil {
+		return nil, err
+	}
+
+	if !req.Async {
+		total, err := s.repo.CountExportProducts(ctx, &req.Filters)
+		if err != nil {
+			return nil, err
+		}
+
+		if total <= req.SyncLimit {
+			return nil, nil
+		}
+	}
+
+	filters, err := json.Marshal(req.Filters)
+	if err != nil {
+		return nil, err
+	}
+
+	export, err := s.repo.CreateExport(ctx, &entity.ProductExport{
+		UserId:  req.UserId,
+		Format:  req.Format,
+		Filters: filters,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Str("id", export.Id).Str("user_id", req.UserId).Msg("service: Product export queued")
+
+	return export, nil
+}
+
+// WriteExport streams the products matching the filters to w and returns
+// how many were written.
+func (s *productService) WriteExport(ctx context.Context, format string, filters *entity.ProductsRequest, w io.Writer) (int, error) {
+	encoder, err := newExportEncoder(format, w)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int
+	err = s.repo.StreamExportProducts(ctx, filters, func(row *entity.ExportRow) error {
+		total++
+		return encoder.write(row)
+	})
+	if err != nil {
+		return total, err
+	}
+
+	return total, encoder.flush()
+}
+
+func (s *productService) GetExport(ctx context.Context, req *entity.GetExportRequest) (*entity.ProductExport, error) {
+	export, err := s.repo.GetExport(ctx, req.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Export not found"))
+		}
+		return nil, err
+	}
+
+	if export.UserId != req.UserId {
+		log.Warn().Any("payload", req).Msg("service: User is not export owner")
+		return nil, errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not export owner"))
+	}
+
+	if export.Status == entity.ExportCompleted && export.FileName != nil {
+		var (
+			ttl       = time.Duration(req.UrlTtl) * time.Second
+			url       = storage.GenerateSignedURL(*export.FileName, ttl)
+			expiresAt = time.Now().Add(ttl)
+		)
+		export.Url = &url
+		export.UrlExpiresAt = &expiresAt
+	}
+
+	return export, nil
+}
+
+// exportClaimLimit is how many exports a run of the export job takes on.
+const exportClaimLimit = 5
+
+// RunExports writes the queued exports to the private storage. A failed
+// export keeps the reason for its owner and doesn't stop the others.
+func (s *productService) RunExports(ctx context.Context) (*entity.ExportResult, error) {
+	var res = new(entity.ExportResult)
+
+	exports, err := s.repo.ClaimExports(ctx, exportClaimLimit)
+	if err != nil {
+		return res, err
+	}
+
+	for i := range exports {
+		export := &exports[i]
+
+		if err := s.runExport(ctx, export); err != nil {
+			log.Error().Err(err).Str("id", export.Id).Msg("service: Product export failed")
+			res.Failed++
+
+			if err := s.repo.FailExport(ctx, export.Id, "Export gagal diproses, silakan coba lagi."); err != nil {
+				return res, err
+			}
+			continue
+		}
+
+		res.Completed++
+	}
+
+	if len(exports) > 0 {
+		log.Info().Int("completed", res.Completed).Int("failed", res.Failed).Msg("service: Product exports run")
+	}
+
+	return res, nil
+}
+
+// runExport spools the export to a temporary file first, the storage upload
+// needs the whole file while the products are read row by row.
+func (s *productService) runExport(ctx context.Context, export *entity.ProductExport) error {
+	var filters entity.ProductsRequest
+	if err := json.Unmarshal(export.Filters, &filters); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp("", "product-export-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+	total, err := s.WriteExport(ctx, export.Format, &filters, w)
+	if err != nil {
+		return err
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	stored, err := s.storage.PutPrivate(ctx, &storageEntity.PutPrivateFileRequest{
+		FileName:    path.Join("exports", export.UserId, export.Id+"."+export.Format),
+		ContentType: types.ContentTypes[export.Format],
+		Body:        file,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.repo.CompleteExport(ctx, export.Id, stored.FileName, total)
+}
+
+// exportColumns are the CSV columns of an export, named like the json of ExportRow.
+var exportColumns = []string{
+	"id", "name", "description", "status", "price", "effective_price", "stock", "available_stock",
+	"category_id", "category", "brand_id", "brand", "shop_id", "shop", "rating", "review_count",
+	"attributes", "published_at", "created_at", "updated_at",
+}
+
+type exportEncoder interface {
+	write(row *entity.ExportRow) error
+	flush() error
+}
+
+func newExportEncoder(format string, w io.Writer) (exportEncoder, error) {
+	switch format {
+	case types.FormatCSV:
+		encoder := &csvExportEncoder{w: csv.NewWriter(w)}
+		return encoder, encoder.w.Write(exportColumns)
+	case types.FormatJSONL:
+		return &jsonlExportEncoder{w: json.NewEncoder(w)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+type csvExportEncoder struct {
+	w *csv.Writer
+}
+
+func (e *csvExportEncoder) write(row *entity.ExportRow) error {
+	var publishedAt, attributes string
+	if row.PublishedAt != nil {
+		publishedAt = row.PublishedAt.Format(time.RFC3339)
+	}
+	if len(row.Attributes) > 0 {
+		data, err := json.Marshal(row.Attributes)
+		if err != nil {
+			return err
+		}
+		attributes = string(data)
+	}
+
+	return e.w.Write([]string{
+		row.Id,
+		row.Name,
+		row.Description,
+		row.Status,
+		strconv.FormatFloat(row.Price, 'f', -1, 64),
+		strconv.FormatFloat(row.EffectivePrice, 'f', -1, 64),
+		strconv.Itoa(row.Stock),
+		strconv.Itoa(row.AvailableStock),
+		row.CategoryId,
+		row.Category,
+		row.BrandId,
+		row.Brand,
+		row.ShopId,
+		row.Shop,
+		strconv.FormatFloat(row.Rating, 'f', -1, 64),
+		strconv.Itoa(row.ReviewCount),
+		attributes,
+		publishedAt,
+		row.CreatedAt.Format(time.RFC3339),
+		row.UpdatedAt.Format(time.RFC3339),
+	})
+}
+
+func (e *csvExportEncoder) flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type jsonlExportEncoder struct {
+	w *json.Encoder
+}
+
+func (e *jsonlExportEncoder) write(row *entity.ExportRow) error {
+	return e.w.Encode(row)
+}
+
+func (e *jsonlExportEncoder) flush() error {
+	return nil
+}
+
+// GetFeed describes the feed of a shop or the site-wide feed, its items
+// are written by WriteFeed.
+func (s *productService) GetFeed(ctx context.Context, req *entity.FeedRequest) (*entity.Feed, error) {
+	var feed = &entity.Feed{Title: req.Title, Link: req.LinkURL}
+
+	if req.ShopId != "" {
+		shop, err := s.repo.GetFeedShop(ctx, req.ShopId)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Shop not found"))
+			}
+			return nil, err
+		}
+
+		feed = shop
+		feed.Link = req.LinkURL + "/shops/" + shop.Slug
+	}
+
+	if feed.Description == "" {
+		feed.Description = "Katalog produk " + feed.Title
+	}
+
+	stats, err := s.repo.GetFeedStats(ctx, req.ShopId)
+	if err != nil {
+		return nil, err
+	}
+	feed.FeedStats = *stats
+
+	return feed, nil
+}
+
+// WriteFeed writes the feed with its items in the format of the request
+// and returns the number of items written.
+func (s *productService) WriteFeed(ctx context.Context, req *entity.FeedRequest, feed *entity.Feed, w io.Writer) (int, error) {
+	encoder, err := newFeedEncoder(req, w)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := encoder.begin(feed); err != nil {
+		return 0, err
+	}
+
+	var total int
+	err = s.repo.StreamFeedItems(ctx, req.ShopId, func(item *entity.FeedItem) error {
+		total++
+		return encoder.write(item)
+	})
+	if err != nil {
+		return total, err
+	}
+
+	return total, encoder.end()
+}
+
+// SyncFeed regenerates the feed items of the products that changed since
+// the last run.
+func (s *productService) SyncFeed(ctx context.Context) (*entity.FeedSyncResult, error) {
+	res, err := s.repo.SyncFeedItems(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Updated > 0 || res.Removed > 0 {
+		log.Info().Int("updated", res.Updated).Int("removed", res.Removed).Msg("service: Product feed synced")
+	}
+
+	return res, nil
+}
+
+// feedColumns are the TSV columns of a feed, named after the Google
+// Merchant Center product attributes.
+var feedColumns = []string{
+	"id", "title", "description", "link", "image_link", "price", "sale_price",
+	"sale_price_effective_date", "availability", "brand", "product_type",
+}
+
+// feedItem is a FeedItem with the values formatted for Google Merchant Center.
+type feedItem struct {
+	XMLName                xml.Name `xml:"item"`
+	Id                     string   `xml:"g:id"`
+	Title                  string   `xml:"title"`
+	Description            string   `xml:"description"`
+	Link                   string   `xml:"link"`
+	ImageLink              string   `xml:"g:image_link,omitempty"`
+	Price                  string   `xml:"g:price"`
+	SalePrice              string   `xml:"g:sale_price,omitempty"`
+	SalePriceEffectiveDate string   `xml:"g:sale_price_effective_date,omitempty"`
+	Availability           string   `xml:"g:availability"`
+	Brand                  string   `xml:"g:brand"`
+	ProductType            string   `xml:"g:product_type"`
+}
+
+func newFeedItem(req *entity.FeedRequest, item *entity.FeedItem) *feedItem {
+	var resp = &feedItem{
+		Id:           item.ProductId,
+		Title:        item.Title,
+		Description:  item.Description,
+		Link:         req.LinkURL + "/products/" + item.Slug,
+		Price:        fmt.Sprintf("%.2f %s", item.Price, req.Currency),
+		Availability: item.Availability,
+		Brand:        item.Brand,
+		ProductType:  item.ProductType,
+	}
+
+	if item.ImageLink != nil {
+		resp.ImageLink = *item.ImageLink
+	}
+
+	if item.SalePrice != nil {
+		resp.SalePrice = fmt.Sprintf("%.2f %s", *item.SalePrice, req.Currency)
+		if item.SaleStartsAt != nil && item.SaleEndsAt != nil {
+			resp.SalePriceEffectiveDate = item.SaleStartsAt.UTC().Format(time.RFC3339) + "/" + item.SaleEndsAt.UTC().Format(time.RFC3339)
+		}
+	}
+
+	return resp
+}
+
+type feedEncoder interface {
+	begin(feed *entity.Feed) error
+	write(item *entity.FeedItem) error
+	end() error
+}
+
+func newFeedEncoder(req *entity.FeedRequest, w io.Writer) (feedEncoder, error) {
+	switch req.Format {
+	case entity.FeedFormatXML:
+		return &xmlFeedEncoder{req: req, w: w, enc: xml.NewEncoder(w)}, nil
+	case entity.FeedFormatTSV:
+		return &tsvFeedEncoder{req: req, w: w}, nil
+	default:
+		return nil, fmt.Errorf("unsupported feed format %q", req.Format)
+	}
+}
+
+// xmlFeedEncoder writes an RSS 2.0 feed with the Google namespace.
+type xmlFeedEncoder struct {
+	req *entity.FeedRequest
+	w   io.Writer
+	enc *xml.Encoder
+}
+
+var (
+	rssStart = xml.StartElement{Name: xml.Name{Local: "rss"}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: "version"}, Value: "2.0"},
+		{Name: xml.Name{Local: "xmlns:g"}, Value: "http://base.google.com/ns/1.0"},
+	}}
+	rssChannelStart = xml.StartElement{Name: xml.Name{Local: "channel"}}
+)
+
+func (e *xmlFeedEncoder) begin(feed *entity.Feed) error {
+	if _, err := io.WriteString(e.w, xml.Header); err != nil {
+		return err
+	}
+
+	e.enc.Indent("", "  ")
+	if err := e.enc.EncodeToken(rssStart); err != nil {
+		return err
+	}
+	if err := e.enc.EncodeToken(rssChannelStart); err != nil {
+		return err
+	}
+
+	for _, field := range [][2]string{{"title", feed.Title}, {"link", feed.Link}, {"description", feed.Description}} {
+		if err := e.enc.EncodeElement(field[1], xml.StartElement{Name: xml.Name{Local: field[0]}}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *xmlFeedEncoder) write(item *entity.FeedItem) error {
+	return e.enc.Encode(newFeedItem(e.req, item))
+}
+
+func (e *xmlFeedEncoder) end() error {
+	if err := e.enc.EncodeToken(rssChannelStart.End()); err != nil {
+		return err
+	}
+	if err := e.enc.EncodeToken(rssStart.End()); err != nil {
+		return err
+	}
+	if err := e.enc.Flush(); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(e.w, "\n")
+	return err
+}
+
+// tsvFeedEncoder writes a tab separated feed. Values can't be quoted in
+// it, so tabs and line breaks are replaced with spaces.
+type tsvFeedEncoder struct {
+	req *entity.FeedRequest
+	w   io.Writer
+}
+
+var tsvReplacer = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+
+func (e *tsvFeedEncoder) begin(feed *entity.Feed) error {
+	return e.writeRow(feedColumns)
+}
+
+func (e *tsvFeedEncoder) write(item *entity.FeedItem) error {
+	row := newFeedItem(e.req, item)
+
+	return e.writeRow([]string{
+		row.Id,
+		row.Title,
+		row.Description,
+		row.Link,
+		row.ImageLink,
+		row.Price,
+		row.SalePrice,
+		row.SalePriceEffectiveDate,
+		row.Availability,
+		row.Brand,
+		row.ProductType,
+	})
+}
+
+func (e *tsvFeedEncoder) end() error {
+	return nil
+}
+
+func (e *tsvFeedEncoder) writeRow(values []string) error {
+	cells := make([]string, len(values))
+	for i, value := range values {
+		cells[i] = tsvReplacer.Replace(value)
+	}
+
+	_, err := io.WriteString(e.w, strings.Join(cells, "\t")+"\n")
+	return err
+}
+
+// validateAttributes checks product attributes against the attribute schema
+// of the category, errors are keyed like validator errors ("attributes.ram").
+func (s *productService) validateAttributes(ctx context.Context, categoryId string, attributes types.JSONMap) error {
+	schema, err := s.repo.GetAttributeSchema(ctx, categoryId)
+	if err != nil {
+		return err
+	}
+
+	if errs := checkAttributes(schema, attributes); errs != nil {
+		log.Warn().Str("category_id", categoryId).Any("errors", errs.Errors).Msg("service: Invalid product attributes")
+		return errs
+	}
+
+	return nil
+}
+
+// checkAttributes returns nil when the attributes fit the schema.
+func checkAttributes(schema []entity.AttributeSchema, attributes types.JSONMap) *errmsg.CustomError {
+	var (
+		errs  = errmsg.NewCustomErrors(400)
+		known = make(map[string]bool, len(schema))
+	)
+
+	for _, attribute := range schema {
+		var (
+			field = "attributes." + attribute.Key
+			name  = strings.ReplaceAll(attribute.Key, "_", " ")
+		)
+		known[attribute.Key] = true
+
+		value, ok := attributes[attribute.Key]
+		if !ok || value == nil {
+			if attribute.IsRequired {
+				errs.Add(field, fmt.Sprintf("%s harus diisi.", name))
+			}
+			continue
+		}
+
+		switch attribute.Type {
+		case entity.AttributeTypeString:
+			if _, ok := value.(string); !ok {
+				errs.Add(field, fmt.Sprintf("%s harus berupa teks.", name))
+			}
+		case entity.AttributeTypeNumber:
+			if _, ok := value.(float64); !ok {
+				errs.Add(field, fmt.Sprintf("%s harus angka.", name))
+			}
+		case entity.AttributeTypeBoolean:
+			if _, ok := value.(bool); !ok {
+				errs.Add(field, fmt.Sprintf("%s harus berupa boolean.", name))
+			}
+		case entity.AttributeTypeEnum:
+			if v, ok := value.(string); !ok || !slices.Contains(attribute.Options, v) {
+				options := slices.Clone(attribute.Options)
+				if len(options) > 1 {
+					options[len(options)-1] = "atau " + options[len(options)-1]
+				}
+				errs.Add(field, fmt.Sprintf("%s harus salah satu dari %s.", name, strings.Join(options, ", ")))
+			}
+		}
+	}
+
+	for key := range attributes {
+		if !known[key] {
+			errs.Add("attributes."+key, fmt.Sprintf("%s tidak tersedia untuk kategori ini.", strings.ReplaceAll(key, "_", " ")))
+		}
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+
+	return nil
 }
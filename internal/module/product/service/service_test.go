@@ -1,15 +1,23 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"database/sql"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
+	"codebase-app/internal/infrastructure/config"
+	storageEntity "codebase-app/internal/integration/filestorage/entity"
 	"codebase-app/internal/module/product/entity"
 	"codebase-app/internal/module/product/ports"
+	mockStorage "codebase-app/mock/integration/filestorage"
 	mockPort "codebase-app/mock/module/product/ports"
 	"codebase-app/pkg/errmsg"
 	"codebase-app/pkg/types"
+	"codebase-app/pkg/validator"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -22,6 +30,7 @@ type MockService struct {
 type ServiceList struct {
 	suite.Suite
 	mockProductRepo *mockPort.MockProductRepo
+	mockFileStorage *mockStorage.MockFileStorage
 	service         ports.ProductService
 
 	mockCreateProductReq          *entity.CreateProductRequest
@@ -33,7 +42,8 @@ type ServiceList struct {
 
 func (suite *ServiceList) SetupTest() {
 	suite.mockProductRepo = new(mockPort.MockProductRepo)
-	suite.service = NewProductService(suite.mockProductRepo)
+	suite.mockFileStorage = new(mockStorage.MockFileStorage)
+	suite.service = NewProductService(suite.mockProductRepo, validator.NewValidator(), suite.mockFileStorage)
 	suite.mockCreateProductReq = &entity.CreateProductRequest{
 		UserId:      "1",
 		ShopId:      "2",
@@ -107,6 +117,7 @@ func (u *ServiceList) TestCreateProduct_Success() {
 	ctx := context.Background()
 	req := u.mockCreateProductReq
 	u.mockProductRepo.Mock.On("IsShopOwner", ctx, req.UserId, req.ShopId).Return(true, nil)
+	u.mockProductRepo.Mock.On("GetAttributeSchema", ctx, req.CategoryId).Return(nil, nil)
 	u.mockProductRepo.Mock.On("CreateProduct", ctx, req).Return(mock.Anything, nil)
 	_, err := u.service.CreateProduct(ctx, req)
 
@@ -137,12 +148,53 @@ func (u *ServiceList) TestCreateProduct_Fail() {
 	req := u.mockCreateProductReq
 
 	u.mockProductRepo.Mock.On("IsShopOwner", ctx, req.UserId, req.ShopId).Return(true, nil)
+	u.mockProductRepo.Mock.On("GetAttributeSchema", ctx, req.CategoryId).Return(nil, nil)
 	u.mockProductRepo.Mock.On("CreateProduct", ctx, req).Return(mock.Anything, errors.New(mock.Anything))
 	_, err := u.service.CreateProduct(ctx, req)
 
 	u.Equal(errors.New(mock.Anything), err)
 }
 
+func (u *ServiceList) TestCreateProduct_InvalidAttributes() {
+	ctx := context.Background()
+	req := *u.mockCreateProductReq
+	req.Attributes = types.JSONMap{"storage": "128", "color": "purple", "weight": 200.0}
+	errBadRequest := errmsg.NewCustomErrors(400,
+		errmsg.WithErrors("attributes.ram", "ram harus diisi."),
+		errmsg.WithErrors("attributes.storage", "storage harus angka."),
+		errmsg.WithErrors("attributes.color", "color harus salah satu dari black, atau white."),
+		errmsg.WithErrors("attributes.weight", "weight tidak tersedia untuk kategori ini."),
+	)
+
+	u.mockProductRepo.Mock.On("IsShopOwner", ctx, req.UserId, req.ShopId).Return(true, nil)
+	u.mockProductRepo.Mock.On("GetAttributeSchema", ctx, req.CategoryId).Return([]entity.AttributeSchema{
+		{Key: "ram", Type: entity.AttributeTypeNumber, IsRequired: true},
+		{Key: "storage", Type: entity.AttributeTypeNumber},
+		{Key: "color", Type: entity.AttributeTypeEnum, Options: []string{"black", "white"}},
+	}, nil)
+	_, err := u.service.CreateProduct(ctx, &req)
+
+	u.Equal(errBadRequest, err)
+	u.mockProductRepo.AssertNotCalled(u.T(), "CreateProduct", ctx, &req)
+}
+
+func (u *ServiceList) TestCreateProduct_ValidAttributes() {
+	ctx := context.Background()
+	req := *u.mockCreateProductReq
+	req.Attributes = types.JSONMap{"ram": 8.0, "nfc": true, "color": "black"}
+
+	u.mockProductRepo.Mock.On("IsShopOwner", ctx, req.UserId, req.ShopId).Return(true, nil)
+	u.mockProductRepo.Mock.On("GetAttributeSchema", ctx, req.CategoryId).Return([]entity.AttributeSchema{
+		{Key: "ram", Type: entity.AttributeTypeNumber, IsRequired: true},
+		{Key: "nfc", Type: entity.AttributeTypeBoolean},
+		{Key: "color", Type: entity.AttributeTypeEnum, Options: []string{"black", "white"}},
+	}, nil)
+	u.mockProductRepo.Mock.On("CreateProduct", ctx, &req).Return(entity.CreateProductResponse{Id: "1"}, nil)
+	_, err := u.service.CreateProduct(ctx, &req)
+
+	u.Equal(nil, err)
+}
+
 // Testing GetProducts
 
 func (suite *ServiceList) TestGetProducts_Success() {
@@ -179,6 +231,104 @@ func (suite *ServiceList) TestGetProducts_ProductsEmpty() {
 	suite.Equal(errProductEmpty, err)
 }
 
+func (suite *ServiceList) TestGetProducts_RelevanceWithoutSearchQuery() {
+	ctx := context.Background()
+	req := &entity.ProductsRequest{
+		UserId: "1",
+		Sort:   entity.SortRelevance,
+	}
+	errSort := errmsg.NewCustomErrors(400, errmsg.WithErrors("sort", "sort relevance hanya dapat digunakan bersama search query."))
+
+	_, err := suite.service.GetProducts(ctx, req)
+
+	suite.Equal(errSort, err)
+	suite.mockProductRepo.AssertNotCalled(suite.T(), "GetProducts", ctx, req)
+}
+
+func (suite *ServiceList) TestGetProducts_CursorForAnotherSort() {
+	ctx := context.Background()
+	cursor := types.Cursor{Sort: entity.SortPriceAsc, Value: "1000.00", Id: "1"}
+	req := &entity.ProductsRequest{
+		UserId: "1",
+		Sort:   entity.SortNewest,
+		Cursor: cursor.Encode(),
+	}
+	errCursor := errmsg.NewCustomErrors(400, errmsg.WithErrors("cursor", "cursor tidak valid untuk sort ini."))
+
+	_, err := suite.service.GetProducts(ctx, req)
+
+	suite.Equal(errCursor, err)
+}
+
+// Testing GetCatalog
+
+func (suite *ServiceList) TestGetCatalog_Success() {
+	ctx := context.Background()
+	req := &entity.ProductsRequest{}
+	res := suite.mockGetProductRes
+
+	suite.mockProductRepo.On("GetCatalog", ctx, req).Return(res, nil)
+	_, err := suite.service.GetCatalog(ctx, req)
+
+	suite.Equal(nil, err)
+}
+
+func (suite *ServiceList) TestGetCatalog_ProductsEmpty() {
+	ctx := context.Background()
+	req := &entity.ProductsRequest{}
+	res := suite.mockGetProductEmptyProductRes
+	errProductEmpty := errmsg.NewCustomErrors(404, errmsg.WithMessage("Products not found"))
+
+	suite.mockProductRepo.On("GetCatalog", ctx, req).Return(res, nil)
+	_, err := suite.service.GetCatalog(ctx, req)
+
+	suite.Equal(errProductEmpty, err)
+}
+
+// Testing GetStorefront
+
+func (suite *ServiceList) TestGetStorefront_BySlug() {
+	ctx := context.Background()
+	req := &entity.StorefrontRequest{ShopSlug: "toko-lama", Filters: entity.ProductsRequest{Page: 1, Paginate: 10, Sort: entity.SortNewest}}
+	shop := &entity.StorefrontResponse{
+		Shop:  entity.StorefrontShop{Id: "1", Slug: "toko"},
+		Stats: entity.StorefrontStats{ProductCount: 2, AverageRating: 4.5, ReviewCount: 8},
+	}
+
+	suite.mockProductRepo.On("GetShopIdBySlug", ctx, "toko-lama").Return("1", nil)
+	suite.mockProductRepo.On("GetStorefront", ctx, "1").Return(shop, nil)
+	suite.mockProductRepo.On("GetShopCatalog", ctx, req).Return(suite.mockGetProductRes, nil)
+	res, err := suite.service.GetStorefront(ctx, req)
+
+	suite.Nil(err)
+	suite.Equal("1", req.ShopId)
+	suite.Equal("toko", res.Shop.Slug)
+	suite.Equal(len(suite.mockGetProductRes.Items), len(res.Products.Items))
+}
+
+func (suite *ServiceList) TestGetStorefront_ProductsEmpty() {
+	ctx := context.Background()
+	req := &entity.StorefrontRequest{ShopId: "1", Filters: entity.ProductsRequest{Page: 1, Paginate: 10, Sort: entity.SortNewest}}
+
+	suite.mockProductRepo.On("GetStorefront", ctx, "1").Return(&entity.StorefrontResponse{Shop: entity.StorefrontShop{Id: "1"}}, nil)
+	suite.mockProductRepo.On("GetShopCatalog", ctx, req).Return(suite.mockGetProductEmptyProductRes, nil)
+	res, err := suite.service.GetStorefront(ctx, req)
+
+	suite.Nil(err)
+	suite.Empty(res.Products.Items)
+}
+
+func (suite *ServiceList) TestGetStorefront_ShopNotFound() {
+	ctx := context.Background()
+	req := &entity.StorefrontRequest{ShopId: "1", Filters: entity.ProductsRequest{Page: 1, Paginate: 10, Sort: entity.SortNewest}}
+
+	suite.mockProductRepo.On("GetStorefront", ctx, "1").Return(nil, sql.ErrNoRows)
+	_, err := suite.service.GetStorefront(ctx, req)
+
+	suite.Equal(errmsg.NewCustomErrors(404, errmsg.WithMessage("Shop not found")), err)
+	suite.mockProductRepo.AssertNotCalled(suite.T(), "GetShopCatalog", mock.Anything, mock.Anything)
+}
+
 // Testing UpdateProduct
 
 func (suite *ServiceList) TestUpdateProduct_Success() {
@@ -193,10 +343,14 @@ func (suite *ServiceList) TestUpdateProduct_Success() {
 	}
 
 	suite.mockProductRepo.On("IsProductOwner", ctx, reqMock.UserId, reqMock.Id).Return(true, nil)
+	suite.mockProductRepo.On("IsShopOwner", ctx, reqMock.UserId, reqMock.ShopId).Return(true, nil)
+	suite.mockProductRepo.On("GetAttributeSchema", ctx, reqMock.CategoryId).Return(nil, nil)
 	suite.mockProductRepo.On("UpdateProduct", ctx, reqMock).Return(resMock, nil)
-	_, err := suite.service.UpdateProduct(ctx, reqMock)
+	suite.mockProductRepo.On("GetProduct", ctx, &entity.GetProductRequest{UserId: "1", Id: "1"}).Return(entity.GetProductResponse{Id: "1"}, nil)
+	res, err := suite.service.UpdateProduct(ctx, reqMock)
 
 	suite.Equal(nil, err)
+	suite.Equal("1", res.Id)
 }
 
 func (suite *ServiceList) TestUpdateProduct_IsProductOwnerError() {
@@ -235,12 +389,244 @@ func (suite *ServiceList) TestUpdateProduct_UpdateProductError() {
 	}
 
 	suite.mockProductRepo.On("IsProductOwner", ctx, reqMock.UserId, reqMock.Id).Return(true, nil)
+	suite.mockProductRepo.On("IsShopOwner", ctx, reqMock.UserId, reqMock.ShopId).Return(true, nil)
+	suite.mockProductRepo.On("GetAttributeSchema", ctx, reqMock.CategoryId).Return(nil, nil)
 	suite.mockProductRepo.On("UpdateProduct", ctx, reqMock).Return(mock.Anything, errors.New(mock.Anything))
 	_, err := suite.service.UpdateProduct(ctx, reqMock)
 
 	suite.Equal(errors.New(mock.Anything), err)
 }
 
+func (suite *ServiceList) TestUpdateProduct_UserIsNotTheShopOwner() {
+	ctx := context.Background()
+	reqMock := &entity.UpdateProductRequest{
+		UserId: "1",
+		Id:     "1",
+		ShopId: "2",
+	}
+
+	suite.mockProductRepo.On("IsProductOwner", ctx, reqMock.UserId, reqMock.Id).Return(true, nil)
+	suite.mockProductRepo.On("IsShopOwner", ctx, reqMock.UserId, reqMock.ShopId).Return(false, nil)
+	_, err := suite.service.UpdateProduct(ctx, reqMock)
+
+	suite.Equal(errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not shop owner")), err)
+}
+
+// Testing PatchProduct
+
+func (suite *ServiceList) TestPatchProduct_OnlyPresentFields() {
+	ctx := context.Background()
+	name := "Product 2"
+	reqMock := &entity.PatchProductRequest{
+		UserId: "1",
+		Id:     "1",
+		Name:   &name,
+		Fields: types.PatchFields{"name": false},
+	}
+
+	suite.mockProductRepo.On("IsProductOwner", ctx, reqMock.UserId, reqMock.Id).Return(true, nil)
+	suite.mockProductRepo.On("PatchProduct", ctx, reqMock).Return(&entity.UpdateProductResponse{Id: "1"}, nil)
+	suite.mockProductRepo.On("GetProduct", ctx, &entity.GetProductRequest{UserId: "1", Id: "1"}).Return(entity.GetProductResponse{Id: "1", Name: name}, nil)
+	res, err := suite.service.PatchProduct(ctx, reqMock)
+
+	suite.Nil(err)
+	suite.Equal(name, res.Name)
+	suite.mockProductRepo.AssertNotCalled(suite.T(), "GetAttributeSchema", mock.Anything, mock.Anything)
+	suite.mockProductRepo.AssertNotCalled(suite.T(), "IsShopOwner", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ServiceList) TestPatchProduct_NullOnNonNullableField() {
+	ctx := context.Background()
+	reqMock := &entity.PatchProductRequest{
+		UserId: "1",
+		Id:     "1",
+		Fields: types.PatchFields{"brand_id": true, "description": true},
+	}
+
+	suite.mockProductRepo.On("IsProductOwner", ctx, reqMock.UserId, reqMock.Id).Return(true, nil)
+	_, err := suite.service.PatchProduct(ctx, reqMock)
+
+	suite.Equal(errmsg.NewCustomErrors(400, errmsg.WithErrors("brand_id", "brand id tidak boleh null.")), err)
+}
+
+func (suite *ServiceList) TestPatchProduct_NullOnNonNullableFields() {
+	ctx := context.Background()
+	reqMock := &entity.PatchProductRequest{
+		UserId: "1",
+		Id:     "1",
+		Fields: types.PatchFields{"price": true, "brand_id": true, "name": true},
+	}
+
+	suite.mockProductRepo.On("IsProductOwner", ctx, reqMock.UserId, reqMock.Id).Return(true, nil)
+	_, err := suite.service.PatchProduct(ctx, reqMock)
+
+	suite.Equal(errmsg.NewCustomErrors(400,
+		errmsg.WithErrors("brand_id", "brand id tidak boleh null."),
+		errmsg.WithErrors("name", "name tidak boleh null."),
+		errmsg.WithErrors("price", "price tidak boleh null."),
+	), err)
+	suite.Equal([]string{"brand_id", "name", "price"}, reqMock.Fields.Nulls(entity.PatchNullable))
+}
+
+func (suite *ServiceList) TestPatchProduct_UnknownField() {
+	ctx := context.Background()
+	reqMock := &entity.PatchProductRequest{
+		UserId: "1",
+		Id:     "1",
+		Fields: types.PatchFields{"name": false, "sku": false},
+	}
+
+	suite.mockProductRepo.On("IsProductOwner", ctx, reqMock.UserId, reqMock.Id).Return(true, nil)
+	_, err := suite.service.PatchProduct(ctx, reqMock)
+
+	suite.Equal(errmsg.NewCustomErrors(400, errmsg.WithErrors("sku", "sku tidak dikenal.")), err)
+	suite.mockProductRepo.AssertNotCalled(suite.T(), "PatchProduct", ctx, reqMock)
+}
+
+func (suite *ServiceList) TestPatchProduct_AttributesMergeIntoStored() {
+	ctx := context.Background()
+	reqMock := &entity.PatchProductRequest{
+		UserId:     "1",
+		Id:         "1",
+		Attributes: types.JSONMap{"color": nil},
+		Fields:     types.PatchFields{"attributes": false},
+	}
+	current := entity.GetProductResponse{Id: "1", Attributes: types.JSONMap{"ram": "8GB", "color": "red"}}
+	current.Category.Id = "3"
+	schema := []entity.AttributeSchema{
+		{Key: "ram", Type: entity.AttributeTypeString, IsRequired: true},
+		{Key: "color", Type: entity.AttributeTypeString},
+	}
+
+	suite.mockProductRepo.On("IsProductOwner", ctx, reqMock.UserId, reqMock.Id).Return(true, nil)
+	suite.mockProductRepo.On("GetProduct", ctx, &entity.GetProductRequest{UserId: "1", Id: "1"}).Return(current, nil)
+	suite.mockProductRepo.On("GetAttributeSchema", ctx, "3").Return(schema, nil)
+	suite.mockProductRepo.On("PatchProduct", ctx, reqMock).Return(&entity.UpdateProductResponse{Id: "1"}, nil)
+	_, err := suite.service.PatchProduct(ctx, reqMock)
+
+	suite.Nil(err)
+	suite.mockProductRepo.AssertCalled(suite.T(), "PatchProduct", ctx, reqMock)
+}
+
+func (suite *ServiceList) TestPatchProduct_AttributeNullRemovesRequired() {
+	ctx := context.Background()
+	reqMock := &entity.PatchProductRequest{
+		UserId:     "1",
+		Id:         "1",
+		Attributes: types.JSONMap{"ram": nil},
+		Fields:     types.PatchFields{"attributes": false},
+	}
+	current := entity.GetProductResponse{Id: "1", Attributes: types.JSONMap{"ram": "8GB", "color": "red"}}
+	current.Category.Id = "3"
+	schema := []entity.AttributeSchema{
+		{Key: "ram", Type: entity.AttributeTypeString, IsRequired: true},
+		{Key: "color", Type: entity.AttributeTypeString},
+	}
+
+	suite.mockProductRepo.On("IsProductOwner", ctx, reqMock.UserId, reqMock.Id).Return(true, nil)
+	suite.mockProductRepo.On("GetProduct", ctx, &entity.GetProductRequest{UserId: "1", Id: "1"}).Return(current, nil)
+	suite.mockProductRepo.On("GetAttributeSchema", ctx, "3").Return(schema, nil)
+	_, err := suite.service.PatchProduct(ctx, reqMock)
+
+	suite.Equal(errmsg.NewCustomErrors(400, errmsg.WithErrors("attributes.ram", "ram harus diisi.")), err)
+	suite.mockProductRepo.AssertNotCalled(suite.T(), "PatchProduct", ctx, reqMock)
+}
+
+func (suite *ServiceList) TestPatchProduct_CategoryChangeValidatesStoredAttributes() {
+	ctx := context.Background()
+	categoryId := "5"
+	reqMock := &entity.PatchProductRequest{
+		UserId:     "1",
+		Id:         "1",
+		CategoryId: &categoryId,
+		Fields:     types.PatchFields{"category_id": false},
+	}
+	current := entity.GetProductResponse{Id: "1", Attributes: types.JSONMap{"ram": 8.0}}
+	current.Category.Id = "3"
+
+	suite.mockProductRepo.On("IsProductOwner", ctx, reqMock.UserId, reqMock.Id).Return(true, nil)
+	suite.mockProductRepo.On("GetProduct", ctx, &entity.GetProductRequest{UserId: "1", Id: "1"}).Return(current, nil)
+	suite.mockProductRepo.On("GetAttributeSchema", ctx, categoryId).Return(nil, nil)
+	_, err := suite.service.PatchProduct(ctx, reqMock)
+
+	suite.Equal(errmsg.NewCustomErrors(400, errmsg.WithErrors("attributes.ram", "ram tidak tersedia untuk kategori ini.")), err)
+	suite.mockProductRepo.AssertNotCalled(suite.T(), "PatchProduct", ctx, reqMock)
+}
+
+func (suite *ServiceList) TestPatchProduct_EmptyPatch() {
+	ctx := context.Background()
+	reqMock := &entity.PatchProductRequest{
+		UserId: "1",
+		Id:     "1",
+		Fields: types.PatchFields{},
+	}
+
+	suite.mockProductRepo.On("IsProductOwner", ctx, reqMock.UserId, reqMock.Id).Return(true, nil)
+	suite.mockProductRepo.On("GetProduct", ctx, &entity.GetProductRequest{UserId: "1", Id: "1"}).Return(entity.GetProductResponse{Id: "1"}, nil)
+	_, err := suite.service.PatchProduct(ctx, reqMock)
+
+	suite.Nil(err)
+	suite.mockProductRepo.AssertNotCalled(suite.T(), "PatchProduct", ctx, reqMock)
+}
+
+func (suite *ServiceList) TestUpdateProduct_StaleIfMatch() {
+	ctx := context.Background()
+	reqMock := &entity.UpdateProductRequest{
+		UserId:  "1",
+		Id:      "1",
+		ShopId:  "2",
+		IfMatch: types.ParseETags(`"3"`),
+	}
+
+	suite.mockProductRepo.On("IsProductOwner", ctx, reqMock.UserId, reqMock.Id).Return(true, nil)
+	suite.mockProductRepo.On("IsShopOwner", ctx, reqMock.UserId, reqMock.ShopId).Return(true, nil)
+	suite.mockProductRepo.On("GetAttributeSchema", ctx, reqMock.CategoryId).Return(nil, nil)
+	suite.mockProductRepo.On("GetProductVersion", ctx, reqMock.Id).Return(4, nil)
+	_, err := suite.service.UpdateProduct(ctx, reqMock)
+
+	suite.Equal(errmsg.NewCustomErrors(412, errmsg.WithMessage("Product has been modified, reload it and try again")), err)
+	suite.mockProductRepo.AssertNotCalled(suite.T(), "UpdateProduct", ctx, reqMock)
+}
+
+func (suite *ServiceList) TestPatchProduct_WeakIfMatch() {
+	ctx := context.Background()
+	name := "Product 2"
+	reqMock := &entity.PatchProductRequest{
+		UserId:  "1",
+		Id:      "1",
+		Name:    &name,
+		Fields:  types.PatchFields{"name": false},
+		IfMatch: types.ParseETags(`W/"4"`),
+	}
+
+	suite.mockProductRepo.On("IsProductOwner", ctx, reqMock.UserId, reqMock.Id).Return(true, nil)
+	suite.mockProductRepo.On("GetProductVersion", ctx, reqMock.Id).Return(4, nil)
+	_, err := suite.service.PatchProduct(ctx, reqMock)
+
+	suite.Equal(errmsg.NewCustomErrors(412, errmsg.WithMessage("Product has been modified, reload it and try again")), err)
+	suite.mockProductRepo.AssertNotCalled(suite.T(), "PatchProduct", ctx, reqMock)
+}
+
+func (suite *ServiceList) TestPatchProduct_ChangedAfterIfMatch() {
+	ctx := context.Background()
+	name := "Product 2"
+	reqMock := &entity.PatchProductRequest{
+		UserId:  "1",
+		Id:      "1",
+		Name:    &name,
+		Fields:  types.PatchFields{"name": false},
+		IfMatch: types.ParseETags(`"4-9f86d081884c7d65"`),
+	}
+
+	suite.mockProductRepo.On("IsProductOwner", ctx, reqMock.UserId, reqMock.Id).Return(true, nil)
+	suite.mockProductRepo.On("GetProductVersion", ctx, reqMock.Id).Return(4, nil)
+	suite.mockProductRepo.On("PatchProduct", ctx, reqMock).Return(nil, sql.ErrNoRows)
+	_, err := suite.service.PatchProduct(ctx, reqMock)
+
+	suite.Equal(errmsg.NewCustomErrors(412, errmsg.WithMessage("Product has been modified, reload it and try again")), err)
+	suite.Equal(4, *reqMock.Version)
+}
+
 // Testing UpdateProductStock
 
 // func (suite *ServiceList) TestUpdateProductStock_Success() {
@@ -298,6 +684,524 @@ func (suite *ServiceList) TestDeleteProduct_UserIsNotTheProductOwner() {
 	suite.Equal(errForbidden, err)
 }
 
+// Testing GetProduct
+func (suite *ServiceList) TestGetProduct_DraftHiddenFromOthers() {
+	ctx := context.Background()
+	reqMock := &entity.GetProductRequest{UserId: "2", Id: "1"}
+
+	suite.mockProductRepo.On("GetProduct", ctx, reqMock).Return(entity.GetProductResponse{Id: "1", UserId: "1", Status: entity.StatusDraft}, nil)
+	_, err := suite.service.GetProduct(ctx, reqMock)
+
+	suite.Equal(errmsg.NewCustomErrors(404, errmsg.WithMessage("Product not found")), err)
+}
+
+func (suite *ServiceList) TestGetProduct_DraftVisibleToOwner() {
+	ctx := context.Background()
+	reqMock := &entity.GetProductRequest{UserId: "1", Id: "1"}
+
+	suite.mockProductRepo.On("GetProduct", ctx, reqMock).Return(entity.GetProductResponse{Id: "1", UserId: "1", Status: entity.StatusDraft}, nil)
+	res, err := suite.service.GetProduct(ctx, reqMock)
+
+	suite.Nil(err)
+	suite.Equal("1", res.Id)
+}
+
+func (suite *ServiceList) TestGetProduct_BySlug() {
+	ctx := context.Background()
+	reqMock := &entity.GetProductRequest{UserId: "2", Slug: "laptop-lama"}
+
+	suite.mockProductRepo.On("GetProductIdBySlug", ctx, "laptop-lama").Return("1", nil)
+	suite.mockProductRepo.On("GetProduct", ctx, reqMock).Return(entity.GetProductResponse{Id: "1", Slug: "laptop", Status: entity.StatusPublished}, nil)
+	res, err := suite.service.GetProduct(ctx, reqMock)
+
+	suite.Nil(err)
+	suite.Equal("1", reqMock.Id)
+	suite.Equal("laptop", res.Slug)
+}
+
+func (suite *ServiceList) TestGetProduct_SlugNotFound() {
+	ctx := context.Background()
+	reqMock := &entity.GetProductRequest{UserId: "2", Slug: "laptop"}
+
+	suite.mockProductRepo.On("GetProductIdBySlug", ctx, "laptop").Return("", sql.ErrNoRows)
+	_, err := suite.service.GetProduct(ctx, reqMock)
+
+	suite.Equal(errmsg.NewCustomErrors(404, errmsg.WithMessage("Product not found")), err)
+	suite.mockProductRepo.AssertNotCalled(suite.T(), "GetProduct", mock.Anything, mock.Anything)
+}
+
+// Testing TransitionProduct
+func (suite *ServiceList) TestTransitionProduct_Success() {
+	ctx := context.Background()
+	reqMock := &entity.TransitionProductRequest{UserId: "1", Id: "1", Status: entity.StatusPublished}
+	resMock := &entity.TransitionProductResponse{Id: "1", Status: entity.StatusPublished}
+
+	suite.mockProductRepo.On("IsProductOwner", ctx, reqMock.UserId, reqMock.Id).Return(true, nil)
+	suite.mockProductRepo.On("GetProductStatus", ctx, reqMock.Id).Return(entity.StatusDraft, nil)
+	suite.mockProductRepo.On("UpdateProductStatus", ctx, reqMock, entity.StatusDraft).Return(resMock, nil)
+	res, err := suite.service.TransitionProduct(ctx, reqMock)
+
+	suite.Nil(err)
+	suite.Equal(resMock, res)
+}
+
+func (suite *ServiceList) TestTransitionProduct_NotAllowed() {
+	ctx := context.Background()
+	reqMock := &entity.TransitionProductRequest{UserId: "1", Id: "1", Status: entity.StatusPublished}
+
+	suite.mockProductRepo.On("IsProductOwner", ctx, reqMock.UserId, reqMock.Id).Return(true, nil)
+	suite.mockProductRepo.On("GetProductStatus", ctx, reqMock.Id).Return(entity.StatusArchived, nil)
+	_, err := suite.service.TransitionProduct(ctx, reqMock)
+
+	suite.Equal(errmsg.NewCustomErrors(409, errmsg.WithErrors("status", "status archived tidak dapat diubah menjadi published.")), err)
+	suite.mockProductRepo.AssertNotCalled(suite.T(), "UpdateProductStatus", ctx, reqMock, entity.StatusArchived)
+}
+
+func (suite *ServiceList) TestTransitionProduct_ChangedConcurrently() {
+	ctx := context.Background()
+	reqMock := &entity.TransitionProductRequest{UserId: "1", Id: "1", Status: entity.StatusArchived}
+
+	suite.mockProductRepo.On("IsProductOwner", ctx, reqMock.UserId, reqMock.Id).Return(true, nil)
+	suite.mockProductRepo.On("GetProductStatus", ctx, reqMock.Id).Return(entity.StatusPublished, nil)
+	suite.mockProductRepo.On("UpdateProductStatus", ctx, reqMock, entity.StatusPublished).Return(nil, sql.ErrNoRows)
+	_, err := suite.service.TransitionProduct(ctx, reqMock)
+
+	suite.Equal(errmsg.NewCustomErrors(409, errmsg.WithMessage("Product status has changed, please retry")), err)
+}
+
+// Testing ScheduleProduct
+func (suite *ServiceList) TestScheduleProduct_UnpublishBeforePublish() {
+	ctx := context.Background()
+	publishAt := time.Now().Add(2 * time.Hour)
+	unpublishAt := time.Now().Add(time.Hour)
+	reqMock := &entity.ScheduleProductRequest{UserId: "1", Id: "1", PublishAt: &publishAt, UnpublishAt: &unpublishAt}
+
+	suite.mockProductRepo.On("IsProductOwner", ctx, reqMock.UserId, reqMock.Id).Return(true, nil)
+	_, err := suite.service.ScheduleProduct(ctx, reqMock)
+
+	suite.Equal(errmsg.NewCustomErrors(400, errmsg.WithErrors("unpublish_at", "unpublish at harus setelah publish at.")), err)
+}
+
+func (suite *ServiceList) TestScheduleProduct_Archived() {
+	ctx := context.Background()
+	publishAt := time.Now().Add(time.Hour)
+	reqMock := &entity.ScheduleProductRequest{UserId: "1", Id: "1", PublishAt: &publishAt}
+
+	suite.mockProductRepo.On("IsProductOwner", ctx, reqMock.UserId, reqMock.Id).Return(true, nil)
+	suite.mockProductRepo.On("ScheduleProduct", ctx, reqMock).Return(nil, sql.ErrNoRows)
+	_, err := suite.service.ScheduleProduct(ctx, reqMock)
+
+	suite.Equal(errmsg.NewCustomErrors(409, errmsg.WithErrors("status", "produk yang diarsipkan tidak dapat dijadwalkan.")), err)
+}
+
+// Testing RestoreProduct
+func (suite *ServiceList) TestRestoreProduct_Success() {
+	ctx := context.Background()
+	reqMock := &entity.RestoreProductRequest{UserId: "1", Id: "2"}
+	resMock := &entity.RestoreProductResponse{Id: "2"}
+
+	suite.mockProductRepo.On("GetTrashedProduct", ctx, reqMock.Id).Return(&entity.TrashedProduct{Id: "2", UserId: "1"}, nil)
+	suite.mockProductRepo.On("RestoreProduct", ctx, reqMock).Return(resMock, nil)
+	res, err := suite.service.RestoreProduct(ctx, reqMock)
+
+	suite.Nil(err)
+	suite.Equal(resMock, res)
+}
+
+func (suite *ServiceList) TestRestoreProduct_NotInTrash() {
+	ctx := context.Background()
+	reqMock := &entity.RestoreProductRequest{UserId: "1", Id: "2"}
+
+	suite.mockProductRepo.On("GetTrashedProduct", ctx, reqMock.Id).Return(nil, sql.ErrNoRows)
+	_, err := suite.service.RestoreProduct(ctx, reqMock)
+
+	suite.Equal(errmsg.NewCustomErrors(404, errmsg.WithMessage("Product not found in trash")), err)
+}
+
+func (suite *ServiceList) TestRestoreProduct_UserIsNotTheProductOwner() {
+	ctx := context.Background()
+	reqMock := &entity.RestoreProductRequest{UserId: "1", Id: "2"}
+
+	suite.mockProductRepo.On("GetTrashedProduct", ctx, reqMock.Id).Return(&entity.TrashedProduct{Id: "2", UserId: "3"}, nil)
+	_, err := suite.service.RestoreProduct(ctx, reqMock)
+
+	suite.Equal(errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not product owner")), err)
+}
+
+func (suite *ServiceList) TestRestoreProduct_ShopDeleted() {
+	ctx := context.Background()
+	reqMock := &entity.RestoreProductRequest{UserId: "1", Id: "2"}
+
+	suite.mockProductRepo.On("GetTrashedProduct", ctx, reqMock.Id).Return(&entity.TrashedProduct{Id: "2", UserId: "1", ShopDeleted: true}, nil)
+	_, err := suite.service.RestoreProduct(ctx, reqMock)
+
+	suite.Equal(errmsg.NewCustomErrors(409, errmsg.WithMessage("Shop is deleted, restore the shop first")), err)
+	suite.mockProductRepo.AssertNotCalled(suite.T(), "RestoreProduct", ctx, reqMock)
+}
+
+const (
+	importUserId     = "8b5b4a8e-3f1c-4c59-9d7e-0a1f2b3c4d5e"
+	importShopId     = "1d2e3f40-5a6b-4c7d-8e9f-a0b1c2d3e4f5"
+	importCategoryId = "6f1e2d3c-4b5a-4978-8695-a4b3c2d1e0f9"
+	importBrandId    = "0a9b8c7d-6e5f-4a3b-9c1d-e2f3a4b5c6d7"
+)
+
+func (suite *ServiceList) TestImportProducts_DryRunReportsInvalidRows() {
+	ctx := context.Background()
+	file := "category,brand,name,description,price,stock,attributes\n" +
+		"Laptop,Acme,Product 1,Description,1500,10,{}\n" +
+		"Laptop,Acme,Product 2,Description,abc,10,{}\n" +
+		"Laptop,Unknown,Product 3,Description,1500,10,{}\n"
+	req := &entity.ImportProductsRequest{UserId: importUserId, ShopId: importShopId, Format: types.FormatCSV, DryRun: true, File: strings.NewReader(file)}
+
+	suite.mockProductRepo.On("IsShopOwner", ctx, req.UserId, req.ShopId).Return(true, nil)
+	suite.mockProductRepo.On("GetCategoryRefs", ctx, []string{"Laptop"}).Return([]entity.ImportRef{{Id: importCategoryId, Name: "Laptop"}}, nil)
+	suite.mockProductRepo.On("GetBrandRefs", ctx, []string{"Acme", "Unknown"}).Return([]entity.ImportRef{{Id: importBrandId, Name: "Acme"}}, nil)
+	suite.mockProductRepo.On("GetAttributeSchema", ctx, importCategoryId).Return([]entity.AttributeSchema{}, nil)
+	res, err := suite.service.ImportProducts(ctx, req)
+
+	suite.Nil(err)
+	suite.Equal(3, res.Total)
+	suite.Equal(1, res.Valid)
+	suite.Equal([]entity.ImportRowError{
+		{Row: 3, Errors: map[string][]string{"price": {"price tidak valid."}}},
+		{Row: 4, Errors: map[string][]string{"brand": {"brand Unknown tidak ditemukan."}}},
+	}, res.Rows)
+	suite.mockProductRepo.AssertNotCalled(suite.T(), "ImportProducts", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ServiceList) TestImportProducts_InvalidRowsRejectTheFile() {
+	ctx := context.Background()
+	file := `{"category_id":"` + importCategoryId + `","brand_id":"` + importBrandId + `","name":"Product 1","description":"Description","price":1500,"stock":10}` + "\n" +
+		`{"category_id":"` + importCategoryId + `","brand_id":"` + importBrandId + `","description":"Description","price":1500,"stock":10}` + "\n"
+	req := &entity.ImportProductsRequest{UserId: importUserId, ShopId: importShopId, Format: types.FormatJSONL, File: strings.NewReader(file)}
+	errBadRequest := errmsg.NewCustomErrors(400, errmsg.WithMessage("Import file contains invalid rows"))
+
+	suite.mockProductRepo.On("IsShopOwner", ctx, req.UserId, req.ShopId).Return(true, nil)
+	suite.mockProductRepo.On("GetCategoryRefs", ctx, []string{importCategoryId}).Return([]entity.ImportRef{{Id: importCategoryId, Name: "Laptop"}}, nil)
+	suite.mockProductRepo.On("GetBrandRefs", ctx, []string{importBrandId}).Return([]entity.ImportRef{{Id: importBrandId, Name: "Acme"}}, nil)
+	suite.mockProductRepo.On("GetAttributeSchema", ctx, importCategoryId).Return([]entity.AttributeSchema{}, nil)
+	res, err := suite.service.ImportProducts(ctx, req)
+
+	suite.Equal(errBadRequest, err)
+	suite.Equal([]entity.ImportRowError{{Row: 2, Errors: map[string][]string{"name": {"name harus diisi."}}}}, res.Rows)
+	suite.mockProductRepo.AssertNotCalled(suite.T(), "ImportProducts", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ServiceList) TestImportProducts_Success() {
+	ctx := context.Background()
+	file := `{"category":"laptop","brand":"ACME","name":"Product 1","description":"Description","price":1500,"stock":10,"status":"published","attributes":{"ram":"8GB"}}` + "\n"
+	req := &entity.ImportProductsRequest{UserId: importUserId, ShopId: importShopId, Format: types.FormatJSONL, BatchSize: 100, File: strings.NewReader(file)}
+	product := &entity.CreateProductRequest{
+		ShopId:      importShopId,
+		CategoryId:  importCategoryId,
+		BrandId:     importBrandId,
+		Name:        "Product 1",
+		Description: "Description",
+		Price:       1500,
+		Stock:       10,
+		UserId:      importUserId,
+		Status:      entity.StatusPublished,
+		Attributes:  types.JSONMap{"ram": "8GB"},
+	}
+
+	suite.mockProductRepo.On("IsShopOwner", ctx, req.UserId, req.ShopId).Return(true, nil)
+	suite.mockProductRepo.On("GetCategoryRefs", ctx, []string{"laptop"}).Return([]entity.ImportRef{{Id: importCategoryId, Name: "Laptop"}}, nil)
+	suite.mockProductRepo.On("GetBrandRefs", ctx, []string{"ACME"}).Return([]entity.ImportRef{{Id: importBrandId, Name: "Acme"}}, nil)
+	suite.mockProductRepo.On("GetAttributeSchema", ctx, importCategoryId).Return([]entity.AttributeSchema{{Key: "ram", Type: entity.AttributeTypeString}}, nil)
+	suite.mockProductRepo.On("ImportProducts", ctx, []*entity.CreateProductRequest{product}, req.BatchSize).Return(1, nil)
+	res, err := suite.service.ImportProducts(ctx, req)
+
+	suite.Nil(err)
+	suite.Equal(1, res.Created)
+	suite.Empty(res.Rows)
+}
+
+func (suite *ServiceList) TestImportProducts_AmbiguousName() {
+	ctx := context.Background()
+	file := "category,brand,name,description,price,stock,attributes\n" +
+		"Aksesoris,Acme,Product 1,Description,1500,0,{}\n"
+	req := &entity.ImportProductsRequest{UserId: importUserId, ShopId: importShopId, Format: types.FormatCSV, DryRun: true, File: strings.NewReader(file)}
+
+	suite.mockProductRepo.On("IsShopOwner", ctx, req.UserId, req.ShopId).Return(true, nil)
+	suite.mockProductRepo.On("GetCategoryRefs", ctx, []string{"Aksesoris"}).Return([]entity.ImportRef{
+		{Id: importCategoryId, Name: "Aksesoris"},
+		{Id: "3c2b1a09-8f7e-4d6c-9b5a-4e3d2c1b0a98", Name: "aksesoris"},
+	}, nil)
+	suite.mockProductRepo.On("GetBrandRefs", ctx, []string{"Acme"}).Return([]entity.ImportRef{{Id: importBrandId, Name: "Acme"}}, nil)
+	res, err := suite.service.ImportProducts(ctx, req)
+
+	suite.Nil(err)
+	suite.Equal(0, res.Valid)
+	suite.Equal([]entity.ImportRowError{
+		{Row: 2, Errors: map[string][]string{"category": {"category Aksesoris ambigu, gunakan category id."}}},
+	}, res.Rows)
+}
+
+func (suite *ServiceList) TestImportProducts_ZeroStock() {
+	ctx := context.Background()
+	file := "category,brand,name,description,price,stock,attributes\n" +
+		"Laptop,Acme,Product 1,Description,1500,0,{}\n"
+	req := &entity.ImportProductsRequest{UserId: importUserId, ShopId: importShopId, Format: types.FormatCSV, DryRun: true, File: strings.NewReader(file)}
+
+	suite.mockProductRepo.On("IsShopOwner", ctx, req.UserId, req.ShopId).Return(true, nil)
+	suite.mockProductRepo.On("GetCategoryRefs", ctx, []string{"Laptop"}).Return([]entity.ImportRef{{Id: importCategoryId, Name: "Laptop"}}, nil)
+	suite.mockProductRepo.On("GetBrandRefs", ctx, []string{"Acme"}).Return([]entity.ImportRef{{Id: importBrandId, Name: "Acme"}}, nil)
+	suite.mockProductRepo.On("GetAttributeSchema", ctx, importCategoryId).Return([]entity.AttributeSchema{}, nil)
+	res, err := suite.service.ImportProducts(ctx, req)
+
+	suite.Nil(err)
+	suite.Equal(1, res.Valid)
+	suite.Empty(res.Rows)
+}
+
+func (suite *ServiceList) TestImportProducts_NegativePrice() {
+	ctx := context.Background()
+	file := "category,brand,name,description,price,stock,attributes\n" +
+		"Laptop,Acme,Product 1,Description,-1500,10,{}\n"
+	req := &entity.ImportProductsRequest{UserId: importUserId, ShopId: importShopId, Format: types.FormatCSV, DryRun: true, File: strings.NewReader(file)}
+
+	suite.mockProductRepo.On("IsShopOwner", ctx, req.UserId, req.ShopId).Return(true, nil)
+	suite.mockProductRepo.On("GetCategoryRefs", ctx, []string{"Laptop"}).Return([]entity.ImportRef{{Id: importCategoryId, Name: "Laptop"}}, nil)
+	suite.mockProductRepo.On("GetBrandRefs", ctx, []string{"Acme"}).Return([]entity.ImportRef{{Id: importBrandId, Name: "Acme"}}, nil)
+	res, err := suite.service.ImportProducts(ctx, req)
+
+	suite.Nil(err)
+	suite.Equal(0, res.Valid)
+	suite.Equal([]entity.ImportRowError{{Row: 2, Errors: map[string][]string{"price": {"price harus lebih dari 0."}}}}, res.Rows)
+}
+
+func (suite *ServiceList) TestImportProducts_UserIsNotShopOwner() {
+	ctx := context.Background()
+	req := &entity.ImportProductsRequest{UserId: importUserId, ShopId: importShopId, Format: types.FormatCSV, File: strings.NewReader("")}
+
+	suite.mockProductRepo.On("IsShopOwner", ctx, req.UserId, req.ShopId).Return(false, nil)
+	_, err := suite.service.ImportProducts(ctx, req)
+
+	suite.Equal(errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not shop owner")), err)
+}
+
+func (suite *ServiceList) TestExportProducts_StreamsSmallExports() {
+	ctx := context.Background()
+	req := &entity.ExportProductsRequest{UserId: "1", Format: types.FormatCSV, SyncLimit: 100, Filters: entity.ProductsRequest{Sort: entity.SortNewest}}
+
+	suite.mockProductRepo.On("CountExportProducts", ctx, &req.Filters).Return(100, nil)
+	res, err := suite.service.ExportProducts(ctx, req)
+
+	suite.Nil(err)
+	suite.Nil(res)
+	suite.Equal("1", req.Filters.UserId)
+	suite.mockProductRepo.AssertNotCalled(suite.T(), "CreateExport", mock.Anything, mock.Anything)
+}
+
+func (suite *ServiceList) TestExportProducts_QueuesLargeExports() {
+	ctx := context.Background()
+	req := &entity.ExportProductsRequest{UserId: "1", Format: types.FormatJSONL, SyncLimit: 100, Filters: entity.ProductsRequest{Sort: entity.SortNewest}}
+
+	suite.mockProductRepo.On("CountExportProducts", ctx, &req.Filters).Return(101, nil)
+	suite.mockProductRepo.On("CreateExport", ctx, mock.MatchedBy(func(export *entity.ProductExport) bool {
+		return export.UserId == "1" && export.Format == types.FormatJSONL
+	})).Return(&entity.ProductExport{Id: "5", Status: entity.ExportPending}, nil)
+	res, err := suite.service.ExportProducts(ctx, req)
+
+	suite.Nil(err)
+	suite.Equal("5", res.Id)
+}
+
+func (suite *ServiceList) TestWriteExport_CSV() {
+	ctx := context.Background()
+	filters := &entity.ProductsRequest{UserId: "1"}
+	row := &entity.ExportRow{
+		Id:          "2",
+		Name:        "Product, 1",
+		Price:       1500.5,
+		Stock:       10,
+		Category:    "Laptop",
+		Brand:       "Acme",
+		Shop:        "Shop",
+		Rating:      4.5,
+		ReviewCount: 2,
+		Attributes:  types.JSONMap{"ram": "8GB"},
+		CreatedAt:   time.Date(2024, 9, 25, 8, 0, 0, 0, time.UTC),
+		UpdatedAt:   time.Date(2024, 9, 25, 8, 0, 0, 0, time.UTC),
+	}
+	var buf bytes.Buffer
+
+	suite.mockProductRepo.On("StreamExportProducts", ctx, filters, mock.Anything).Run(func(args mock.Arguments) {
+		fn := args.Get(2).(func(*entity.ExportRow) error)
+		suite.Nil(fn(row))
+	}).Return(nil)
+	total, err := suite.service.WriteExport(ctx, types.FormatCSV, filters, &buf)
+
+	suite.Nil(err)
+	suite.Equal(1, total)
+	suite.Equal("id,name,description,status,price,effective_price,stock,available_stock,category_id,category,brand_id,brand,shop_id,shop,rating,review_count,attributes,published_at,created_at,updated_at\n"+
+		`2,"Product, 1",,,1500.5,0,10,0,,Laptop,,Acme,,Shop,4.5,2,"{""ram"":""8GB""}",,2024-09-25T08:00:00Z,2024-09-25T08:00:00Z`+"\n", buf.String())
+}
+
+func (suite *ServiceList) TestGetExport_Completed() {
+	ctx := context.Background()
+	req := &entity.GetExportRequest{UserId: "1", Id: "5", UrlTtl: 60}
+	fileName := "exports/1/5.csv"
+	config.Envs = new(config.Config) // signed urls read the base url and key
+
+	suite.mockProductRepo.On("GetExport", ctx, req.Id).Return(&entity.ProductExport{Id: "5", UserId: "1", Status: entity.ExportCompleted, FileName: &fileName}, nil)
+	res, err := suite.service.GetExport(ctx, req)
+
+	suite.Nil(err)
+	suite.Contains(*res.Url, "/api/storage/private/exports/1/5.csv?")
+	suite.NotNil(res.UrlExpiresAt)
+}
+
+func (suite *ServiceList) TestGetExport_UserIsNotExportOwner() {
+	ctx := context.Background()
+	req := &entity.GetExportRequest{UserId: "1", Id: "5", UrlTtl: 60}
+
+	suite.mockProductRepo.On("GetExport", ctx, req.Id).Return(&entity.ProductExport{Id: "5", UserId: "2", Status: entity.ExportPending}, nil)
+	_, err := suite.service.GetExport(ctx, req)
+
+	suite.Equal(errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not export owner")), err)
+}
+
+func (suite *ServiceList) TestRunExports_StoresTheFile() {
+	ctx := context.Background()
+	export := entity.ProductExport{Id: "5", UserId: "1", Format: types.FormatJSONL, Filters: []byte(`{"UserId":"1","Sort":"newest"}`)}
+
+	suite.mockProductRepo.On("ClaimExports", ctx, exportClaimLimit).Return([]entity.ProductExport{export}, nil)
+	suite.mockProductRepo.On("StreamExportProducts", ctx, &entity.ProductsRequest{UserId: "1", Sort: entity.SortNewest}, mock.Anything).Return(nil)
+	suite.mockFileStorage.On("PutPrivate", ctx, mock.MatchedBy(func(req *storageEntity.PutPrivateFileRequest) bool {
+		return req.FileName == "exports/1/5.jsonl" && req.ContentType == types.ContentTypes[types.FormatJSONL]
+	})).Return(storageEntity.PutFileResponse{FileName: "exports/1/5.jsonl"}, nil)
+	suite.mockProductRepo.On("CompleteExport", ctx, "5", "exports/1/5.jsonl", 0).Return(nil)
+	res, err := suite.service.RunExports(ctx)
+
+	suite.Nil(err)
+	suite.Equal(&entity.ExportResult{Completed: 1}, res)
+}
+
+func (suite *ServiceList) TestRunExports_FailedExportIsRecorded() {
+	ctx := context.Background()
+	export := entity.ProductExport{Id: "5", UserId: "1", Format: types.FormatCSV, Filters: []byte(`{"UserId":"1"}`)}
+
+	suite.mockProductRepo.On("ClaimExports", ctx, exportClaimLimit).Return([]entity.ProductExport{export}, nil)
+	suite.mockProductRepo.On("StreamExportProducts", ctx, &entity.ProductsRequest{UserId: "1"}, mock.Anything).Return(errors.New("connection reset"))
+	suite.mockProductRepo.On("FailExport", ctx, "5", "Export gagal diproses, silakan coba lagi.").Return(nil)
+	res, err := suite.service.RunExports(ctx)
+
+	suite.Nil(err)
+	suite.Equal(&entity.ExportResult{Failed: 1}, res)
+	suite.mockFileStorage.AssertNotCalled(suite.T(), "PutPrivate", mock.Anything, mock.Anything)
+}
+
+func (suite *ServiceList) TestGetFeed_ShopNotFound() {
+	ctx := context.Background()
+	req := &entity.FeedRequest{ShopId: "2", Format: entity.FeedFormatXML, LinkURL: "https://shop.test"}
+	errNotFound := errmsg.NewCustomErrors(404, errmsg.WithMessage("Shop not found"))
+
+	suite.mockProductRepo.On("GetFeedShop", ctx, req.ShopId).Return(nil, sql.ErrNoRows)
+	_, err := suite.service.GetFeed(ctx, req)
+
+	suite.Equal(errNotFound, err)
+	suite.mockProductRepo.AssertNotCalled(suite.T(), "GetFeedStats", mock.Anything, mock.Anything)
+}
+
+func (suite *ServiceList) TestGetFeed_Shop() {
+	ctx := context.Background()
+	req := &entity.FeedRequest{ShopId: "2", Format: entity.FeedFormatTSV, LinkURL: "https://shop.test"}
+	modified := time.Date(2024, 9, 26, 8, 0, 0, 0, time.UTC)
+
+	suite.mockProductRepo.On("GetFeedShop", ctx, req.ShopId).Return(&entity.Feed{Slug: "toko", Title: "Toko", Version: 3}, nil)
+	suite.mockProductRepo.On("GetFeedStats", ctx, req.ShopId).Return(&entity.FeedStats{ItemCount: 5, LastModified: &modified}, nil)
+	res, err := suite.service.GetFeed(ctx, req)
+
+	suite.Nil(err)
+	suite.Equal("https://shop.test/shops/toko", res.Link)
+	suite.Equal("Katalog produk Toko", res.Description)
+	suite.Equal(`W/"tsv-5-1727337600000000-3"`, res.ETag(req.Format))
+}
+
+func (suite *ServiceList) TestWriteFeed_XML() {
+	ctx := context.Background()
+	req := &entity.FeedRequest{Format: entity.FeedFormatXML, LinkURL: "https://shop.test", Currency: "IDR"}
+	feed := &entity.Feed{Title: "Shop & Co", Link: "https://shop.test", Description: "Katalog produk Shop & Co"}
+	var (
+		salePrice = 1200.0
+		startsAt  = time.Date(2024, 9, 26, 0, 0, 0, 0, time.UTC)
+		endsAt    = time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+		buf       bytes.Buffer
+	)
+
+	suite.mockProductRepo.On("StreamFeedItems", ctx, "", mock.Anything).Run(func(args mock.Arguments) {
+		fn := args.Get(2).(func(*entity.FeedItem) error)
+		suite.Nil(fn(&entity.FeedItem{
+			ProductId:    "7",
+			Slug:         "laptop-14",
+			Title:        "Laptop <14\">",
+			Description:  "Ringan",
+			Price:        1500,
+			SalePrice:    &salePrice,
+			SaleStartsAt: &startsAt,
+			SaleEndsAt:   &endsAt,
+			Availability: "in_stock",
+			Brand:        "Acme",
+			ProductType:  "Elektronik > Laptop",
+		}))
+	}).Return(nil)
+	total, err := suite.service.WriteFeed(ctx, req, feed, &buf)
+
+	suite.Nil(err)
+	suite.Equal(1, total)
+	suite.Equal(`<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:g="http://base.google.com/ns/1.0">
+  <channel>
+    <title>Shop &amp; Co</title>
+    <link>https://shop.test</link>
+    <description>Katalog produk Shop &amp; Co</description>
+    <item>
+      <g:id>7</g:id>
+      <title>Laptop &lt;14&#34;&gt;</title>
+      <description>Ringan</description>
+      <link>https://shop.test/products/laptop-14</link>
+      <g:price>1500.00 IDR</g:price>
+      <g:sale_price>1200.00 IDR</g:sale_price>
+      <g:sale_price_effective_date>2024-09-26T00:00:00Z/2024-10-01T00:00:00Z</g:sale_price_effective_date>
+      <g:availability>in_stock</g:availability>
+      <g:brand>Acme</g:brand>
+      <g:product_type>Elektronik &gt; Laptop</g:product_type>
+    </item>
+  </channel>
+</rss>
+`, buf.String())
+}
+
+func (suite *ServiceList) TestWriteFeed_TSV() {
+	ctx := context.Background()
+	req := &entity.FeedRequest{ShopId: "2", Format: entity.FeedFormatTSV, LinkURL: "https://shop.test", Currency: "IDR"}
+	imageLink := "https://cdn.test/7.jpg"
+	var buf bytes.Buffer
+
+	suite.mockProductRepo.On("StreamFeedItems", ctx, "2", mock.Anything).Run(func(args mock.Arguments) {
+		fn := args.Get(2).(func(*entity.FeedItem) error)
+		suite.Nil(fn(&entity.FeedItem{
+			ProductId:    "7",
+			Slug:         "laptop",
+			Title:        "Laptop",
+			Description:  "Ringan\tdan\r\ncepat",
+			ImageLink:    &imageLink,
+			Price:        1500.5,
+			Availability: "out_of_stock",
+			Brand:        "Acme",
+			ProductType:  "Laptop",
+		}))
+	}).Return(nil)
+	total, err := suite.service.WriteFeed(ctx, req, &entity.Feed{}, &buf)
+
+	suite.Nil(err)
+	suite.Equal(1, total)
+	suite.Equal("id\ttitle\tdescription\tlink\timage_link\tprice\tsale_price\tsale_price_effective_date\tavailability\tbrand\tproduct_type\n"+
+		"7\tLaptop\tRingan dan cepat\thttps://shop.test/products/laptop\thttps://cdn.test/7.jpg\t1500.50 IDR\t\t\tout_of_stock\tAcme\tLaptop\n", buf.String())
+}
+
 func TestService(t *testing.T) {
 	suite.Run(t, new(ServiceList))
 }
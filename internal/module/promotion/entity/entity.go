@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"codebase-app/pkg/types"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	ScopeShop     = "shop"
+	ScopeCategory = "category" // includes the subcategories
+	ScopeBrand    = "brand"
+	ScopeProducts = "products"
+)
+
+const (
+	DiscountPercent = "percent"
+	DiscountFixed   = "fixed"
+)
+
+// promotion states derived from the date window
+const (
+	StatusUpcoming = "upcoming"
+	StatusActive   = "active"
+	StatusEnded    = "ended"
+)
+
+// PromotionItem is a discount rule of a shop. When several active
+// promotions match a product, the one with the highest Priority applies,
+// then the one giving the lowest price, then the newest. Promotions don't
+// stack.
+type PromotionItem struct {
+	Id            string         `json:"id" db:"id"`
+	ShopId        string         `json:"shop_id" db:"shop_id"`
+	Name          string         `json:"name" db:"name"`
+	Scope         string         `json:"scope" db:"scope"`
+	CategoryId    *string        `json:"category_id" db:"category_id"`
+	BrandId       *string        `json:"brand_id" db:"brand_id"`
+	ProductIds    pq.StringArray `json:"product_ids" db:"product_ids"`
+	DiscountType  string         `json:"discount_type" db:"discount_type"`
+	DiscountValue float64        `json:"discount_value" db:"discount_value"`
+	Priority      int            `json:"priority" db:"priority"`
+	StartsAt      time.Time      `json:"starts_at" db:"starts_at"`
+	EndsAt        time.Time      `json:"ends_at" db:"ends_at"`
+	Status        string         `json:"status" db:"status"`
+	CreatedAt     time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+type PromotionsRequest struct {
+	UserId   string `prop:"user_id" validate:"uuid"`
+	ShopId   string `params:"id" validate:"uuid"`
+	Page     int    `query:"page" validate:"required"`
+	Paginate int    `query:"paginate" validate:"required"`
+	Status   string `query:"status" validate:"omitempty,oneof=upcoming active ended"`
+}
+
+func (r *PromotionsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type PromotionsResponse struct {
+	Items []PromotionItem `json:"items"`
+	Meta  types.Meta      `json:"meta"`
+}
+
+type CreatePromotionRequest struct {
+	UserId string `prop:"user_id" validate:"uuid" db:"created_by"`
+	ShopId string `params:"id" validate:"uuid" db:"shop_id"`
+
+	Name          string    `json:"name" validate:"required,max=255" db:"name"`
+	Scope         string    `json:"scope" validate:"required,oneof=shop category brand products" db:"scope"`
+	CategoryId    *string   `json:"category_id" validate:"omitnil,uuid" db:"category_id"`      // scope category
+	BrandId       *string   `json:"brand_id" validate:"omitnil,uuid" db:"brand_id"`            // scope brand
+	ProductIds    []string  `json:"product_ids" validate:"omitempty,max=500,dive,uuid" db:"-"` // scope products
+	DiscountType  string    `json:"discount_type" validate:"required,oneof=percent fixed" db:"discount_type"`
+	DiscountValue float64   `json:"discount_value" validate:"required,gt=0" db:"discount_value"` // percent or an amount off
+	Priority      int       `json:"priority" validate:"min=0,max=1000" db:"priority"`
+	StartsAt      time.Time `json:"starts_at" validate:"required" db:"starts_at"`
+	EndsAt        time.Time `json:"ends_at" validate:"required" db:"ends_at"`
+}
+
+type DeletePromotionRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+	ShopId string `params:"id" validate:"uuid"`
+	Id     string `params:"promotion_id" validate:"uuid"`
+}
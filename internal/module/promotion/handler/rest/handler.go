@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"codebase-app/internal/adapter"
+	"codebase-app/internal/middleware"
+	"codebase-app/internal/module/promotion/entity"
+	"codebase-app/internal/module/promotion/ports"
+	"codebase-app/internal/module/promotion/repository"
+	"codebase-app/internal/module/promotion/service"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+type promotionHandler struct {
+	service ports.PromotionService
+}
+
+func NewPromotionHandler() *promotionHandler {
+	var (
+		handler = new(promotionHandler)
+		repo    = repository.NewPromotionRepository(adapter.Adapters.ShopeefunPostgres)
+		service = service.NewPromotionService(repo)
+	)
+	handler.service = service
+
+	return handler
+}
+
+func (h *promotionHandler) Register(router fiber.Router) {
+	router.Get("/shops/:id/promotions", middleware.UserIdHeader, h.GetPromotions)
+	router.Post("/shops/:id/promotions", middleware.UserIdHeader, h.CreatePromotion)
+	router.Delete("/shops/:id/promotions/:promotion_id", middleware.UserIdHeader, h.DeletePromotion)
+}
+
+func (h *promotionHandler) GetPromotions(c *fiber.Ctx) error {
+	var (
+		req = new(entity.PromotionsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetPromotions - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.ShopId = c.Params("id")
+	req.SetDefault()
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetPromotions - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetPromotions(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *promotionHandler) CreatePromotion(c *fiber.Ctx) error {
+	var (
+		req = new(entity.CreatePromotionRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::CreatePromotion - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.ShopId = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::CreatePromotion - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.CreatePromotion(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Success(resp, ""))
+}
+
+func (h *promotionHandler) DeletePromotion(c *fiber.Ctx) error {
+	var (
+		req = new(entity.DeletePromotionRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	req.UserId = l.UserId
+	req.ShopId = c.Params("id")
+	req.Id = c.Params("promotion_id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::DeletePromotion - Validate request")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := h.service.DeletePromotion(ctx, req); err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, ""))
+}
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"codebase-app/internal/module/promotion/entity"
+	"context"
+)
+
+type PromotionRepository interface {
+	GetPromotions(ctx context.Context, req *entity.PromotionsRequest) (*entity.PromotionsResponse, error)
+	CreatePromotion(ctx context.Context, req *entity.CreatePromotionRequest) (*entity.PromotionItem, error)
+	DeletePromotion(ctx context.Context, req *entity.DeletePromotionRequest) error
+
+	IsShopOwner(ctx context.Context, userId, shopId string) (bool, error)
+}
+
+type PromotionService interface {
+	GetPromotions(ctx context.Context, req *entity.PromotionsRequest) (*entity.PromotionsResponse, error)
+	CreatePromotion(ctx context.Context, req *entity.CreatePromotionRequest) (*entity.PromotionItem, error)
+	DeletePromotion(ctx context.Context, req *entity.DeletePromotionRequest) error
+}
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"codebase-app/internal/module/promotion/entity"
+	"codebase-app/internal/module/promotion/ports"
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.PromotionRepository = &promotionRepository{}
+
+type promotionRepository struct {
+	db *sqlx.DB
+}
+
+func NewPromotionRepository(db *sqlx.DB) *promotionRepository {
+	return &promotionRepository{
+		db: db,
+	}
+}
+
+const (
+	promotionStatusExpr = `CASE WHEN NOW() < pr.starts_at THEN 'upcoming' WHEN NOW() >= pr.ends_at THEN 'ended' ELSE 'active' END`
+
+	promotionColumns = `
+			pr.id,
+			pr.shop_id,
+			pr.name,
+			pr.scope,
+			pr.category_id,
+			pr.brand_id,
+			ARRAY(SELECT pp.product_id::TEXT FROM promotion_products pp WHERE pp.promotion_id = pr.id ORDER BY pp.product_id) AS product_ids,
+			pr.discount_type,
+			pr.discount_value,
+			pr.priority,
+			pr.starts_at,
+			pr.ends_at,
+			` + promotionStatusExpr + ` AS status,
+			pr.created_at,
+			pr.updated_at`
+)
+
+func (r *promotionRepository) GetPromotions(ctx context.Context, req *entity.PromotionsRequest) (*entity.PromotionsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.PromotionItem
+	}
+
+	var (
+		resp = new(entity.PromotionsResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.PromotionItem, 0, req.Paginate)
+
+	query := `
+		SELECT
+			COUNT(pr.id) OVER() as total_data,` + promotionColumns + `
+		FROM promotions pr
+		WHERE
+			pr.shop_id = ?
+			AND (?::TEXT = '' OR ` + promotionStatusExpr + ` = ?)
+		ORDER BY pr.starts_at DESC, pr.id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query),
+		req.ShopId,
+		req.Status,
+		req.Status,
+		req.Paginate,
+		req.Paginate*(req.Page-1))
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetPromotions - Failed to get promotions")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.PromotionItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+// CreatePromotion returns sql.ErrNoRows when one of the products isn't a
+// live product of the shop.
+func (r *promotionRepository) CreatePromotion(ctx context.Context, req *entity.CreatePromotionRequest) (*entity.PromotionItem, error) {
+	var (
+		resp = new(entity.PromotionItem)
+		id   string
+	)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreatePromotion - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO promotions (shop_id, name, scope, category_id, brand_id, discount_type, discount_value, priority, starts_at, ends_at, created_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`
+
+	err = tx.QueryRowxContext(ctx, tx.Rebind(query),
+		req.ShopId,
+		req.Name,
+		req.Scope,
+		req.CategoryId,
+		req.BrandId,
+		req.DiscountType,
+		req.DiscountValue,
+		req.Priority,
+		req.StartsAt,
+		req.EndsAt,
+		req.UserId).Scan(&id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreatePromotion - Failed to create promotion")
+		return nil, err
+	}
+
+	if len(req.ProductIds) > 0 {
+		query = `
+			INSERT INTO promotion_products (promotion_id, product_id)
+			SELECT ?, p.id
+			FROM products p
+			WHERE p.id = ANY(?::UUID[]) AND p.shop_id = ? AND p.deleted_at IS NULL
+		`
+
+		res, err := tx.ExecContext(ctx, tx.Rebind(query), id, pq.Array(req.ProductIds), req.ShopId)
+		if err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::CreatePromotion - Failed to add promotion products")
+			return nil, err
+		}
+
+		if n, _ := res.RowsAffected(); int(n) != len(req.ProductIds) {
+			log.Warn().Any("payload", req).Msg("repository::CreatePromotion - Products not found in shop")
+			return nil, sql.ErrNoRows
+		}
+	}
+
+	query = `SELECT ` + promotionColumns + ` FROM promotions pr WHERE pr.id = ?`
+
+	if err := tx.QueryRowxContext(ctx, tx.Rebind(query), id).StructScan(resp); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreatePromotion - Failed to get promotion")
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreatePromotion - Failed to commit transaction")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// DeletePromotion returns sql.ErrNoRows when the shop has no such promotion.
+func (r *promotionRepository) DeletePromotion(ctx context.Context, req *entity.DeletePromotionRequest) error {
+	query := `DELETE FROM promotions WHERE id = ? AND shop_id = ?`
+
+	res, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.Id, req.ShopId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeletePromotion - Failed to delete promotion")
+		return err
+	}
+
+	if n, _ := res.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *promotionRepository) IsShopOwner(ctx context.Context, userId, shopId string) (bool, error) {
+	var (
+		isOwner bool
+		payload = struct {
+			UserId string `json:"user_id"`
+			ShopId string `json:"shop_id"`
+		}{userId, shopId}
+	)
+
+	query := `
+		SELECT
+			EXISTS (
+				SELECT 1
+				FROM
+					shops
+				WHERE
+					user_id = $1
+					AND id = $2
+					AND deleted_at IS NULL
+			)
+	`
+
+	err := r.db.GetContext(ctx, &isOwner, query, userId, shopId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", payload).Msg("repository: IsShopOwner failed")
+		return isOwner, err
+	}
+
+	return isOwner, nil
+}
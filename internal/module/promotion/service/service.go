@@ -0,0 +1,156 @@
+package service
+
+import (
+	"codebase-app/internal/module/promotion/entity"
+	"codebase-app/internal/module/promotion/ports"
+	"codebase-app/pkg/errmsg"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.PromotionService = &promotionService{}
+
+type promotionService struct {
+	repo ports.PromotionRepository
+}
+
+func NewPromotionService(repo ports.PromotionRepository) *promotionService {
+	return &promotionService{
+		repo: repo,
+	}
+}
+
+func (s *promotionService) GetPromotions(ctx context.Context, req *entity.PromotionsRequest) (*entity.PromotionsResponse, error) {
+	if err := s.checkShopOwner(ctx, req.UserId, req.ShopId); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetPromotions(ctx, req)
+}
+
+func (s *promotionService) CreatePromotion(ctx context.Context, req *entity.CreatePromotionRequest) (*entity.PromotionItem, error) {
+	if err := s.checkShopOwner(ctx, req.UserId, req.ShopId); err != nil {
+		return nil, err
+	}
+
+	req.ProductIds = unique(req.ProductIds)
+
+	if errs := validatePromotion(req); errs.HasErrors() {
+		log.Warn().Any("payload", req).Msg("service: Invalid promotion")
+		return nil, errs
+	}
+
+	res, err := s.repo.CreatePromotion(ctx, req)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("product_ids", "product id tidak ditemukan di toko ini."))
+		}
+		if isForeignKeyViolation(err, "promotions_category_id_fkey") {
+			return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("category_id", "category id tidak ditemukan."))
+		}
+		if isForeignKeyViolation(err, "promotions_brand_id_fkey") {
+			return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("brand_id", "brand id tidak ditemukan."))
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *promotionService) DeletePromotion(ctx context.Context, req *entity.DeletePromotionRequest) error {
+	if err := s.checkShopOwner(ctx, req.UserId, req.ShopId); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeletePromotion(ctx, req); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errmsg.NewCustomErrors(404, errmsg.WithMessage("Promotion not found"))
+		}
+		return err
+	}
+
+	return nil
+}
+
+// validatePromotion checks the fields that depend on each other, the
+// target of the scope must be set and nothing else.
+func validatePromotion(req *entity.CreatePromotionRequest) *errmsg.CustomError {
+	errs := errmsg.NewCustomErrors(400)
+
+	switch {
+	case req.Scope == entity.ScopeCategory && req.CategoryId == nil:
+		errs.Add("category_id", "category id harus diisi untuk scope category.")
+	case req.Scope != entity.ScopeCategory && req.CategoryId != nil:
+		errs.Add("category_id", "category id hanya untuk scope category.")
+	}
+
+	switch {
+	case req.Scope == entity.ScopeBrand && req.BrandId == nil:
+		errs.Add("brand_id", "brand id harus diisi untuk scope brand.")
+	case req.Scope != entity.ScopeBrand && req.BrandId != nil:
+		errs.Add("brand_id", "brand id hanya untuk scope brand.")
+	}
+
+	switch {
+	case req.Scope == entity.ScopeProducts && len(req.ProductIds) == 0:
+		errs.Add("product_ids", "product ids harus diisi untuk scope products.")
+	case req.Scope != entity.ScopeProducts && len(req.ProductIds) > 0:
+		errs.Add("product_ids", "product ids hanya untuk scope products.")
+	}
+
+	if req.DiscountType == entity.DiscountPercent && req.DiscountValue > 100 {
+		errs.Add("discount_value", "discount value tidak boleh lebih dari 100 untuk tipe percent.")
+	}
+
+	if !req.EndsAt.After(req.StartsAt) {
+		errs.Add("ends_at", "ends at harus setelah starts at.")
+	} else if !req.EndsAt.After(time.Now()) {
+		errs.Add("ends_at", "ends at harus di masa depan.")
+	}
+
+	return errs
+}
+
+func (s *promotionService) checkShopOwner(ctx context.Context, userId, shopId string) error {
+	isShopOwner, err := s.repo.IsShopOwner(ctx, userId, shopId)
+	if err != nil {
+		return err
+	}
+
+	if !isShopOwner {
+		log.Warn().Str("user_id", userId).Str("shop_id", shopId).Msg("service: User is not shop owner")
+		return errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not shop owner"))
+	}
+
+	return nil
+}
+
+func isForeignKeyViolation(err error, constraint string) bool {
+	var errPq *pq.Error
+	if !errors.As(err, &errPq) || errPq.Code.Name() != "foreign_key_violation" {
+		return false
+	}
+
+	return errPq.Constraint == constraint
+}
+
+func unique(ids []string) []string {
+	var (
+		seen   = make(map[string]bool, len(ids))
+		result = make([]string, 0, len(ids))
+	)
+
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
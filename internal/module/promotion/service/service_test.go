@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"codebase-app/internal/module/promotion/entity"
+	"codebase-app/internal/module/promotion/ports"
+	mockPort "codebase-app/mock/module/promotion/ports"
+	"codebase-app/pkg/errmsg"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServiceList struct {
+	suite.Suite
+	mockPromotionRepo *mockPort.MockPromotionRepo
+	service           ports.PromotionService
+
+	mockCreatePromotionReq *entity.CreatePromotionRequest
+}
+
+func (suite *ServiceList) SetupTest() {
+	categoryId := "3"
+
+	suite.mockPromotionRepo = new(mockPort.MockPromotionRepo)
+	suite.service = NewPromotionService(suite.mockPromotionRepo)
+	suite.mockCreatePromotionReq = &entity.CreatePromotionRequest{
+		UserId:        "1",
+		ShopId:        "2",
+		Name:          "Weekend sale",
+		Scope:         entity.ScopeCategory,
+		CategoryId:    &categoryId,
+		DiscountType:  entity.DiscountPercent,
+		DiscountValue: 20,
+		StartsAt:      time.Now(),
+		EndsAt:        time.Now().Add(48 * time.Hour),
+	}
+}
+
+func (suite *ServiceList) TestCreatePromotion_Success() {
+	ctx := context.Background()
+	req := suite.mockCreatePromotionReq
+
+	suite.mockPromotionRepo.On("IsShopOwner", ctx, req.UserId, req.ShopId).Return(true, nil)
+	suite.mockPromotionRepo.On("CreatePromotion", ctx, req).Return(&entity.PromotionItem{Id: "4", Status: entity.StatusActive}, nil)
+	res, err := suite.service.CreatePromotion(ctx, req)
+
+	suite.Equal(nil, err)
+	suite.Equal("4", res.Id)
+}
+
+func (suite *ServiceList) TestCreatePromotion_UserIsNotShopOwner() {
+	ctx := context.Background()
+	req := suite.mockCreatePromotionReq
+	errForbidden := errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not shop owner"))
+
+	suite.mockPromotionRepo.On("IsShopOwner", ctx, req.UserId, req.ShopId).Return(false, nil)
+	_, err := suite.service.CreatePromotion(ctx, req)
+
+	suite.Equal(errForbidden, err)
+	suite.mockPromotionRepo.AssertNotCalled(suite.T(), "CreatePromotion", ctx, req)
+}
+
+func (suite *ServiceList) TestCreatePromotion_ScopeTargetMismatch() {
+	ctx := context.Background()
+	req := suite.mockCreatePromotionReq
+	req.Scope = entity.ScopeBrand
+	errBadRequest := errmsg.NewCustomErrors(400)
+	errBadRequest.Add("category_id", "category id hanya untuk scope category.")
+	errBadRequest.Add("brand_id", "brand id harus diisi untuk scope brand.")
+
+	suite.mockPromotionRepo.On("IsShopOwner", ctx, req.UserId, req.ShopId).Return(true, nil)
+	_, err := suite.service.CreatePromotion(ctx, req)
+
+	suite.Equal(errBadRequest, err)
+	suite.mockPromotionRepo.AssertNotCalled(suite.T(), "CreatePromotion", mock.Anything, mock.Anything)
+}
+
+func (suite *ServiceList) TestCreatePromotion_PercentOverHundred() {
+	ctx := context.Background()
+	req := suite.mockCreatePromotionReq
+	req.DiscountValue = 120
+	errBadRequest := errmsg.NewCustomErrors(400, errmsg.WithErrors("discount_value", "discount value tidak boleh lebih dari 100 untuk tipe percent."))
+
+	suite.mockPromotionRepo.On("IsShopOwner", ctx, req.UserId, req.ShopId).Return(true, nil)
+	_, err := suite.service.CreatePromotion(ctx, req)
+
+	suite.Equal(errBadRequest, err)
+}
+
+func (suite *ServiceList) TestCreatePromotion_ProductsNotInShop() {
+	ctx := context.Background()
+	req := suite.mockCreatePromotionReq
+	req.Scope = entity.ScopeProducts
+	req.CategoryId = nil
+	req.ProductIds = []string{"5", "6", "5"}
+	errBadRequest := errmsg.NewCustomErrors(400, errmsg.WithErrors("product_ids", "product id tidak ditemukan di toko ini."))
+
+	suite.mockPromotionRepo.On("IsShopOwner", ctx, req.UserId, req.ShopId).Return(true, nil)
+	suite.mockPromotionRepo.On("CreatePromotion", ctx, req).Return(nil, sql.ErrNoRows)
+	_, err := suite.service.CreatePromotion(ctx, req)
+
+	suite.Equal(errBadRequest, err)
+	suite.Equal([]string{"5", "6"}, req.ProductIds)
+}
+
+func (suite *ServiceList) TestCreatePromotion_CategoryNotFound() {
+	ctx := context.Background()
+	req := suite.mockCreatePromotionReq
+	errBadRequest := errmsg.NewCustomErrors(400, errmsg.WithErrors("category_id", "category id tidak ditemukan."))
+	errPq := &pq.Error{Code: "23503", Constraint: "promotions_category_id_fkey"}
+
+	suite.mockPromotionRepo.On("IsShopOwner", ctx, req.UserId, req.ShopId).Return(true, nil)
+	suite.mockPromotionRepo.On("CreatePromotion", ctx, req).Return(nil, errPq)
+	_, err := suite.service.CreatePromotion(ctx, req)
+
+	suite.Equal(errBadRequest, err)
+}
+
+func (suite *ServiceList) TestDeletePromotion_NotFound() {
+	ctx := context.Background()
+	req := &entity.DeletePromotionRequest{UserId: "1", ShopId: "2", Id: "4"}
+	errNotFound := errmsg.NewCustomErrors(404, errmsg.WithMessage("Promotion not found"))
+
+	suite.mockPromotionRepo.On("IsShopOwner", ctx, req.UserId, req.ShopId).Return(true, nil)
+	suite.mockPromotionRepo.On("DeletePromotion", ctx, req).Return(sql.ErrNoRows)
+	err := suite.service.DeletePromotion(ctx, req)
+
+	suite.Equal(errNotFound, err)
+}
+
+func TestService(t *testing.T) {
+	suite.Run(t, new(ServiceList))
+}
@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"codebase-app/pkg/types"
+	"time"
+)
+
+type ReviewItem struct {
+	Id        string    `json:"id" db:"id"`
+	ProductId string    `json:"product_id" db:"product_id"`
+	UserId    string    `json:"user_id" db:"user_id"`
+	Rating    int       `json:"rating" db:"rating"`
+	Review    string    `json:"review" db:"review"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+const (
+	SortNewest     = "newest"
+	SortRatingDesc = "rating_desc"
+	SortRatingAsc  = "rating_asc"
+)
+
+type ReviewsRequest struct {
+	ProductId string `params:"id" validate:"uuid"`
+	Page      int    `query:"page" validate:"required"`
+	Paginate  int    `query:"paginate" validate:"required"`
+	Sort      string `query:"sort" validate:"omitempty,oneof=newest rating_desc rating_asc"`
+}
+
+func (r *ReviewsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+
+	if r.Sort == "" {
+		r.Sort = SortNewest
+	}
+}
+
+type UserReviewsRequest struct {
+	UserId   string `prop:"user_id" validate:"uuid"`
+	Page     int    `query:"page" validate:"required"`
+	Paginate int    `query:"paginate" validate:"required"`
+}
+
+func (r *UserReviewsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type ReviewsResponse struct {
+	Items []ReviewItem `json:"items"`
+	Meta  types.Meta   `json:"meta"`
+}
+
+type CreateReviewRequest struct {
+	UserId    string `prop:"user_id" validate:"uuid" db:"user_id"`
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+
+	Rating int    `json:"rating" validate:"required,min=1,max=5" db:"rating"`
+	Review string `json:"review" validate:"required,max=2000" db:"review"`
+}
+
+type UpdateReviewRequest struct {
+	UserId string `prop:"user_id" validate:"uuid" db:"user_id"`
+	Id     string `params:"review_id" validate:"uuid" db:"id"`
+
+	Rating int    `json:"rating" validate:"required,min=1,max=5" db:"rating"`
+	Review string `json:"review" validate:"required,max=2000" db:"review"`
+}
+
+type DeleteReviewRequest struct {
+	UserId string `prop:"user_id" validate:"uuid" db:"user_id"`
+	Id     string `params:"review_id" validate:"uuid" db:"id"`
+}
@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"codebase-app/internal/module/review/entity"
+	"context"
+)
+
+type ReviewRepository interface {
+	GetReviews(ctx context.Context, req *entity.ReviewsRequest) (*entity.ReviewsResponse, error)
+	GetUserReviews(ctx context.Context, req *entity.UserReviewsRequest) (*entity.ReviewsResponse, error)
+	CreateReview(ctx context.Context, req *entity.CreateReviewRequest) (*entity.ReviewItem, error)
+	UpdateReview(ctx context.Context, req *entity.UpdateReviewRequest) (*entity.ReviewItem, error)
+	DeleteReview(ctx context.Context, req *entity.DeleteReviewRequest) error
+
+	IsReviewAuthor(ctx context.Context, userId, reviewId string) (bool, error)
+}
+
+type ReviewService interface {
+	GetReviews(ctx context.Context, req *entity.ReviewsRequest) (*entity.ReviewsResponse, error)
+	GetUserReviews(ctx context.Context, req *entity.UserReviewsRequest) (*entity.ReviewsResponse, error)
+	CreateReview(ctx context.Context, req *entity.CreateReviewRequest) (*entity.ReviewItem, error)
+	UpdateReview(ctx context.Context, req *entity.UpdateReviewRequest) (*entity.ReviewItem, error)
+	DeleteReview(ctx context.Context, req *entity.DeleteReviewRequest) error
+}
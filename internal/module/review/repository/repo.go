@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"codebase-app/internal/module/review/entity"
+	"codebase-app/internal/module/review/ports"
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.ReviewRepository = &reviewRepository{}
+
+type reviewRepository struct {
+	db *sqlx.DB
+}
+
+func NewReviewRepository(db *sqlx.DB) *reviewRepository {
+	return &reviewRepository{
+		db: db,
+	}
+}
+
+type reviewDao struct {
+	TotalData int `db:"total_data"`
+	entity.ReviewItem
+}
+
+var reviewSorts = map[string]string{
+	entity.SortNewest:     "r.created_at DESC, r.id DESC",
+	entity.SortRatingDesc: "r.rating DESC, r.created_at DESC, r.id DESC",
+	entity.SortRatingAsc:  "r.rating ASC, r.created_at DESC, r.id DESC",
+}
+
+func (r *reviewRepository) GetReviews(ctx context.Context, req *entity.ReviewsRequest) (*entity.ReviewsResponse, error) {
+	var (
+		resp = new(entity.ReviewsResponse)
+		data = make([]reviewDao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.ReviewItem, 0, req.Paginate)
+
+	orderBy, ok := reviewSorts[req.Sort]
+	if !ok {
+		orderBy = reviewSorts[entity.SortNewest]
+	}
+
+	query := `
+		SELECT
+			COUNT(r.id) OVER() as total_data,
+			r.id,
+			r.product_id,
+			r.user_id,
+			r.rating,
+			r.review,
+			r.created_at,
+			r.updated_at
+		FROM reviews r
+		INNER JOIN products p ON r.product_id = p.id
+		WHERE
+			r.product_id = ?
+			AND p.deleted_at IS NULL
+		ORDER BY ` + orderBy + `
+		LIMIT ? OFFSET ?
+	`
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query),
+		req.ProductId,
+		req.Paginate,
+		req.Paginate*(req.Page-1))
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetReviews - Failed to get reviews")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.ReviewItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+func (r *reviewRepository) GetUserReviews(ctx context.Context, req *entity.UserReviewsRequest) (*entity.ReviewsResponse, error) {
+	var (
+		resp = new(entity.ReviewsResponse)
+		data = make([]reviewDao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.ReviewItem, 0, req.Paginate)
+
+	query := `
+		SELECT
+			COUNT(r.id) OVER() as total_data,
+			r.id,
+			r.product_id,
+			r.user_id,
+			r.rating,
+			r.review,
+			r.created_at,
+			r.updated_at
+		FROM reviews r
+		WHERE r.user_id = ?
+		ORDER BY r.created_at DESC, r.id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query),
+		req.UserId,
+		req.Paginate,
+		req.Paginate*(req.Page-1))
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetUserReviews - Failed to get user reviews")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.ReviewItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+func (r *reviewRepository) CreateReview(ctx context.Context, req *entity.CreateReviewRequest) (*entity.ReviewItem, error) {
+	var resp = new(entity.ReviewItem)
+
+	// selecting from products makes a deleted or unknown product return no rows
+	query := `
+		INSERT INTO reviews (product_id, user_id, rating, review)
+		SELECT p.id, ?, ?, ?
+		FROM products p
+		WHERE p.id = ? AND p.deleted_at IS NULL
+		RETURNING id, product_id, user_id, rating, review, created_at, updated_at
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query),
+		req.UserId,
+		req.Rating,
+		req.Review,
+		req.ProductId).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateReview - Failed to create review")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *reviewRepository) UpdateReview(ctx context.Context, req *entity.UpdateReviewRequest) (*entity.ReviewItem, error) {
+	var resp = new(entity.ReviewItem)
+
+	query := `
+		UPDATE reviews
+		SET
+			rating = ?,
+			review = ?,
+			updated_at = NOW()
+		WHERE id = ? AND user_id = ?
+		RETURNING id, product_id, user_id, rating, review, created_at, updated_at
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query),
+		req.Rating,
+		req.Review,
+		req.Id,
+		req.UserId).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateReview - Failed to update review")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *reviewRepository) DeleteReview(ctx context.Context, req *entity.DeleteReviewRequest) error {
+	query := `
+		DELETE FROM reviews
+		WHERE id = ? AND user_id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.Id, req.UserId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteReview - Failed to delete review")
+		return err
+	}
+
+	return nil
+}
+
+func (r *reviewRepository) IsReviewAuthor(ctx context.Context, userId, reviewId string) (bool, error) {
+	var (
+		isAuthor bool
+		payload  = struct {
+			UserId   string `json:"user_id"`
+			ReviewId string `json:"review_id"`
+		}{userId, reviewId}
+	)
+
+	query := `
+		SELECT
+			EXISTS (
+				SELECT 1
+				FROM reviews
+				WHERE
+					user_id = $1
+					AND id = $2
+			)
+	`
+
+	err := r.db.GetContext(ctx, &isAuthor, query, userId, reviewId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", payload).Msg("repository: IsReviewAuthor failed")
+		return isAuthor, err
+	}
+
+	return isAuthor, nil
+}
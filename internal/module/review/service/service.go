@@ -0,0 +1,82 @@
+package service
+
+import (
+	"codebase-app/internal/module/review/entity"
+	"codebase-app/internal/module/review/ports"
+	"codebase-app/pkg/errmsg"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.ReviewService = &reviewService{}
+
+type reviewService struct {
+	repo ports.ReviewRepository
+}
+
+func NewReviewService(repo ports.ReviewRepository) *reviewService {
+	return &reviewService{
+		repo: repo,
+	}
+}
+
+func (s *reviewService) GetReviews(ctx context.Context, req *entity.ReviewsRequest) (*entity.ReviewsResponse, error) {
+	return s.repo.GetReviews(ctx, req)
+}
+
+func (s *reviewService) GetUserReviews(ctx context.Context, req *entity.UserReviewsRequest) (*entity.ReviewsResponse, error) {
+	return s.repo.GetUserReviews(ctx, req)
+}
+
+func (s *reviewService) CreateReview(ctx context.Context, req *entity.CreateReviewRequest) (*entity.ReviewItem, error) {
+	res, err := s.repo.CreateReview(ctx, req)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Product not found"))
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *reviewService) UpdateReview(ctx context.Context, req *entity.UpdateReviewRequest) (*entity.ReviewItem, error) {
+	if err := s.checkReviewAuthor(ctx, req.UserId, req.Id); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.UpdateReview(ctx, req)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Review not found"))
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *reviewService) DeleteReview(ctx context.Context, req *entity.DeleteReviewRequest) error {
+	if err := s.checkReviewAuthor(ctx, req.UserId, req.Id); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteReview(ctx, req)
+}
+
+func (s *reviewService) checkReviewAuthor(ctx context.Context, userId, reviewId string) error {
+	isAuthor, err := s.repo.IsReviewAuthor(ctx, userId, reviewId)
+	if err != nil {
+		return err
+	}
+
+	if !isAuthor {
+		log.Warn().Str("user_id", userId).Str("review_id", reviewId).Msg("service: User is not review author")
+		return errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not review author"))
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"codebase-app/internal/module/review/entity"
+	"codebase-app/internal/module/review/ports"
+	mockPort "codebase-app/mock/module/review/ports"
+	"codebase-app/pkg/errmsg"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServiceList struct {
+	suite.Suite
+	mockReviewRepo *mockPort.MockReviewRepo
+	service        ports.ReviewService
+
+	mockCreateReviewReq *entity.CreateReviewRequest
+	mockUpdateReviewReq *entity.UpdateReviewRequest
+}
+
+func (suite *ServiceList) SetupTest() {
+	suite.mockReviewRepo = new(mockPort.MockReviewRepo)
+	suite.service = NewReviewService(suite.mockReviewRepo)
+	suite.mockCreateReviewReq = &entity.CreateReviewRequest{
+		UserId:    "1",
+		ProductId: "2",
+		Rating:    5,
+		Review:    "Great product",
+	}
+	suite.mockUpdateReviewReq = &entity.UpdateReviewRequest{
+		UserId: "1",
+		Id:     "3",
+		Rating: 4,
+		Review: "Good product",
+	}
+}
+
+func (suite *ServiceList) TestCreateReview_Success() {
+	ctx := context.Background()
+	req := suite.mockCreateReviewReq
+
+	suite.mockReviewRepo.On("CreateReview", ctx, req).Return(entity.ReviewItem{Id: "3", Rating: 5}, nil)
+	res, err := suite.service.CreateReview(ctx, req)
+
+	suite.Equal(nil, err)
+	suite.Equal("3", res.Id)
+}
+
+func (suite *ServiceList) TestCreateReview_ProductNotFound() {
+	ctx := context.Background()
+	req := suite.mockCreateReviewReq
+	errNotFound := errmsg.NewCustomErrors(404, errmsg.WithMessage("Product not found"))
+
+	suite.mockReviewRepo.On("CreateReview", ctx, req).Return(nil, sql.ErrNoRows)
+	_, err := suite.service.CreateReview(ctx, req)
+
+	suite.Equal(errNotFound, err)
+}
+
+func (suite *ServiceList) TestUpdateReview_Success() {
+	ctx := context.Background()
+	req := suite.mockUpdateReviewReq
+
+	suite.mockReviewRepo.On("IsReviewAuthor", ctx, req.UserId, req.Id).Return(true, nil)
+	suite.mockReviewRepo.On("UpdateReview", ctx, req).Return(entity.ReviewItem{Id: "3", Rating: 4}, nil)
+	res, err := suite.service.UpdateReview(ctx, req)
+
+	suite.Equal(nil, err)
+	suite.Equal(4, res.Rating)
+}
+
+func (suite *ServiceList) TestUpdateReview_UserIsNotTheAuthor() {
+	ctx := context.Background()
+	req := suite.mockUpdateReviewReq
+	errForbidden := errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not review author"))
+
+	suite.mockReviewRepo.On("IsReviewAuthor", ctx, req.UserId, req.Id).Return(false, nil)
+	_, err := suite.service.UpdateReview(ctx, req)
+
+	suite.Equal(errForbidden, err)
+	suite.mockReviewRepo.AssertNotCalled(suite.T(), "UpdateReview", ctx, req)
+}
+
+func (suite *ServiceList) TestUpdateReview_IsReviewAuthorError() {
+	ctx := context.Background()
+	req := suite.mockUpdateReviewReq
+
+	suite.mockReviewRepo.On("IsReviewAuthor", ctx, req.UserId, req.Id).Return(false, errors.New(mock.Anything))
+	_, err := suite.service.UpdateReview(ctx, req)
+
+	suite.Equal(errors.New(mock.Anything), err)
+}
+
+func (suite *ServiceList) TestDeleteReview_Success() {
+	ctx := context.Background()
+	req := &entity.DeleteReviewRequest{
+		UserId: "1",
+		Id:     "3",
+	}
+
+	suite.mockReviewRepo.On("IsReviewAuthor", ctx, req.UserId, req.Id).Return(true, nil)
+	suite.mockReviewRepo.On("DeleteReview", ctx, req).Return(nil)
+	err := suite.service.DeleteReview(ctx, req)
+
+	suite.Equal(nil, err)
+}
+
+func (suite *ServiceList) TestDeleteReview_UserIsNotTheAuthor() {
+	ctx := context.Background()
+	req := &entity.DeleteReviewRequest{
+		UserId: "1",
+		Id:     "3",
+	}
+	errForbidden := errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not review author"))
+
+	suite.mockReviewRepo.On("IsReviewAuthor", ctx, req.UserId, req.Id).Return(false, nil)
+	err := suite.service.DeleteReview(ctx, req)
+
+	suite.Equal(errForbidden, err)
+	suite.mockReviewRepo.AssertNotCalled(suite.T(), "DeleteReview", ctx, req)
+}
+
+func TestService(t *testing.T) {
+	suite.Run(t, new(ServiceList))
+}
@@ -1,6 +1,9 @@
 package entity
 
-import "codebase-app/pkg/types"
+import (
+	"codebase-app/pkg/types"
+	"time"
+)
 
 type CreateShopRequest struct {
 	UserId string `validate:"uuid" db:"user_id"`
@@ -11,23 +14,31 @@ type CreateShopRequest struct {
 }
 
 type CreateShopResponse struct {
-	Id string `json:"id" db:"id"`
+	Id   string `json:"id" db:"id"`
+	Slug string `json:"slug" db:"slug"`
 }
 
 type GetShopRequest struct {
-	Id string `validate:"uuid" db:"id"`
+	Id   string `validate:"required_without=Slug,omitempty,uuid" db:"id"`
+	Slug string `validate:"omitempty,max=80" db:"-"` // set instead of Id when fetched by slug
 }
 
 type GetShopResponse struct {
+	Id          string `json:"id" db:"id"`
+	Slug        string `json:"slug" db:"slug"` // an older slug redirects here
 	Name        string `json:"name" db:"name"`
 	Description string `json:"description" db:"description"`
 	Terms       string `json:"terms" db:"terms"`
+	Version     int    `json:"version" db:"version"` // sent as the ETag header
 }
 
 type DeleteShopRequest struct {
 	UserId string `prop:"user_id" validate:"uuid" db:"user_id"`
 
 	Id string `validate:"uuid" db:"id"`
+
+	IfMatch types.ETags `json:"-" validate:"-"` // parsed If-Match header, nil when absent
+	Version *int        `json:"-" validate:"-"` // version the write must hit, resolved from IfMatch
 }
 
 type UpdateShopRequest struct {
@@ -37,18 +48,36 @@ type UpdateShopRequest struct {
 	Name        string `json:"name" validate:"required" db:"name"`
 	Description string `json:"description" validate:"required" db:"description"`
 	Terms       string `json:"terms" validate:"required" db:"terms"`
+
+	IfMatch types.ETags `json:"-" validate:"-"` // parsed If-Match header, nil when absent
+	Version *int        `json:"-" validate:"-"` // version the write must hit, resolved from IfMatch
 }
 
 type UpdateShopResponse struct {
-	Id string `json:"id" db:"id"`
+	Id      string `json:"id" db:"id"`
+	Slug    string `json:"slug" db:"slug"`
+	Version int    `json:"version" db:"version"`
 }
 
 type ShopsRequest struct {
-	UserId   string `prop:"user_id" validate:"uuid"`
-	Page     int    `query:"page" validate:"required"`
-	Paginate int    `query:"paginate" validate:"required"`
+	UserId     string `prop:"user_id" validate:"uuid"`
+	Page       int    `query:"page" validate:"required"`
+	Paginate   int    `query:"paginate" validate:"required"`
+	Pagination string `query:"pagination" validate:"omitempty,oneof=offset cursor"`
+	Cursor     string `query:"cursor" validate:"omitempty,base64rawurl"`
+	WithTotal  bool   `query:"with_total" validate:"omitempty"`
+
+	After *types.Cursor `query:"-" validate:"-"` // decoded Cursor
 }
 
+const (
+	PaginationOffset = "offset"
+	PaginationCursor = "cursor"
+)
+
+// ShopsSort is the only order shops are listed in, cursors carry it as their sort key.
+const ShopsSort = "newest"
+
 func (r *ShopsRequest) SetDefault() {
 	if r.Page < 1 {
 		r.Page = 1
@@ -57,10 +86,23 @@ func (r *ShopsRequest) SetDefault() {
 	if r.Paginate < 1 {
 		r.Paginate = 10
 	}
+
+	if r.Cursor != "" {
+		r.Pagination = PaginationCursor
+	}
+
+	if r.Pagination == "" {
+		r.Pagination = PaginationOffset
+	}
+}
+
+func (r *ShopsRequest) IsCursor() bool {
+	return r.Pagination == PaginationCursor
 }
 
 type ShopItem struct {
 	Id   string `json:"id" db:"id"`
+	Slug string `json:"slug" db:"slug"`
 	Name string `json:"name" db:"name"`
 }
 
@@ -68,3 +110,46 @@ type ShopsResponse struct {
 	Items []ShopItem `json:"items"`
 	Meta  types.Meta `json:"meta"`
 }
+
+type TrashedShopsRequest struct {
+	UserId   string `prop:"user_id" validate:"uuid"`
+	Page     int    `query:"page" validate:"required"`
+	Paginate int    `query:"paginate" validate:"required"`
+
+	RetentionDays int `query:"-" validate:"-"` // used to compute purge_at
+}
+
+func (r *TrashedShopsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type TrashedShopItem struct {
+	Id           string    `json:"id" db:"id"`
+	Name         string    `json:"name" db:"name"`
+	ProductCount int       `json:"product_count" db:"product_count"` // products restored along with the shop
+	DeletedAt    time.Time `json:"deleted_at" db:"deleted_at"`
+	PurgeAt      time.Time `json:"purge_at" db:"purge_at"`
+}
+
+type TrashedShopsResponse struct {
+	Items []TrashedShopItem `json:"items"`
+	Meta  types.Meta        `json:"meta"`
+}
+
+type RestoreShopRequest struct {
+	UserId string `prop:"user_id" validate:"uuid" db:"user_id"`
+
+	Id           string `params:"id" validate:"uuid" db:"id"`
+	WithProducts bool   `query:"with_products"` // also restore the trashed products of the shop
+}
+
+type RestoreShopResponse struct {
+	Id               string `json:"id" db:"id"`
+	RestoredProducts int64  `json:"restored_products"`
+}
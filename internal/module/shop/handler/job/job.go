@@ -0,0 +1,42 @@
+package job
+
+import (
+	"codebase-app/internal/adapter"
+	"codebase-app/internal/infrastructure/config"
+	"codebase-app/internal/module/shop/ports"
+	"codebase-app/internal/module/shop/repository"
+	"codebase-app/internal/module/shop/service"
+	"codebase-app/pkg/scheduler"
+	"context"
+	"time"
+)
+
+type shopJob struct {
+	service ports.ShopService
+}
+
+func NewShopJob() *shopJob {
+	var (
+		job     = new(shopJob)
+		repo    = repository.NewShopRepository(adapter.Adapters.ShopeefunPostgres)
+		service = service.NewShopService(repo)
+	)
+	job.service = service
+
+	return job
+}
+
+func (j *shopJob) Register(s *scheduler.Scheduler) {
+	s.Register(scheduler.Job{
+		Name:     "shop.purge_trash",
+		Interval: time.Duration(config.Envs.Trash.PurgeInterval) * time.Second,
+		Run:      j.PurgeTrash,
+	})
+}
+
+// PurgeTrash hard deletes the shops kept in the trash longer than the retention period.
+func (j *shopJob) PurgeTrash(ctx context.Context) error {
+	before := time.Now().AddDate(0, 0, -config.Envs.Trash.RetentionDays)
+	_, err := j.service.PurgeShops(ctx, before)
+	return err
+}
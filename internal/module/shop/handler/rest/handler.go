@@ -2,6 +2,7 @@ package handler
 
 import (
 	"codebase-app/internal/adapter"
+	"codebase-app/internal/infrastructure/config"
 	"codebase-app/internal/middleware"
 	"codebase-app/internal/module/shop/entity"
 	"codebase-app/internal/module/shop/ports"
@@ -9,6 +10,9 @@ import (
 	"codebase-app/internal/module/shop/service"
 	"codebase-app/pkg/errmsg"
 	"codebase-app/pkg/response"
+	"codebase-app/pkg/slug"
+	"codebase-app/pkg/types"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/rs/zerolog/log"
@@ -32,9 +36,11 @@ func NewShopHandler() *shopHandler {
 func (h *shopHandler) Register(router fiber.Router) {
 	router.Get("/shops", middleware.UserIdHeader, h.GetShops)
 	router.Post("/shops", middleware.UserIdHeader, h.CreateShop)
+	router.Get("/shops/trash", middleware.UserIdHeader, h.GetTrashedShops)
 	router.Get("/shops/:id", h.GetShop)
 	router.Delete("/shops/:id", middleware.UserIdHeader, h.DeleteShop)
 	router.Patch("/shops/:id", middleware.UserIdHeader, h.UpdateShop)
+	router.Post("/shops/:id/restore", middleware.UserIdHeader, h.RestoreShop)
 }
 
 func (h *shopHandler) CreateShop(c *fiber.Ctx) error {
@@ -75,7 +81,7 @@ func (h *shopHandler) GetShop(c *fiber.Ctx) error {
 		v   = adapter.Adapters.Validator
 	)
 
-	req.Id = c.Params("id")
+	req.Id, req.Slug = slug.Parse(c.Params("id"))
 
 	if err := v.Validate(req); err != nil {
 		log.Warn().Err(err).Any("payload", req).Msg("handler::GetShop - Validate request body")
@@ -89,6 +95,21 @@ func (h *shopHandler) GetShop(c *fiber.Ctx) error {
 		return c.Status(code).JSON(response.Error(errs))
 	}
 
+	// an old slug moves clients to the current one
+	if req.Slug != "" && req.Slug != resp.Slug {
+		location := strings.TrimSuffix(c.Path(), req.Slug) + resp.Slug
+		if query := c.Context().QueryArgs().String(); query != "" {
+			location += "?" + query
+		}
+		return c.Redirect(location, fiber.StatusMovedPermanently)
+	}
+
+	etag := types.ContentETag(resp.Version, resp)
+	c.Set(fiber.HeaderETag, etag)
+	if types.ParseETags(c.Get(fiber.HeaderIfNoneMatch)).Contains(etag) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
 	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
 }
 
@@ -101,6 +122,7 @@ func (h *shopHandler) DeleteShop(c *fiber.Ctx) error {
 	)
 	req.UserId = l.UserId
 	req.Id = c.Params("id")
+	req.IfMatch = types.ParseETags(c.Get(fiber.HeaderIfMatch))
 
 	if err := v.Validate(req); err != nil {
 		log.Warn().Err(err).Any("payload", req).Msg("handler::DeleteShop - Validate request body")
@@ -132,6 +154,7 @@ func (h *shopHandler) UpdateShop(c *fiber.Ctx) error {
 
 	req.UserId = l.UserId
 	req.Id = c.Params("id")
+	req.IfMatch = types.ParseETags(c.Get(fiber.HeaderIfMatch))
 
 	if err := v.Validate(req); err != nil {
 		log.Warn().Err(err).Any("payload", req).Msg("handler::UpdateShop - Validate request body")
@@ -145,6 +168,7 @@ func (h *shopHandler) UpdateShop(c *fiber.Ctx) error {
 		return c.Status(code).JSON(response.Error(errs))
 	}
 
+	c.Set(fiber.HeaderETag, types.ETag(resp.Version))
 	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
 }
 
@@ -179,3 +203,66 @@ func (h *shopHandler) GetShops(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
 
 }
+
+func (h *shopHandler) GetTrashedShops(c *fiber.Ctx) error {
+	var (
+		req = new(entity.TrashedShopsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetTrashedShops - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.RetentionDays = config.Envs.Trash.RetentionDays
+	req.SetDefault()
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetTrashedShops - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetTrashedShops(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *shopHandler) RestoreShop(c *fiber.Ctx) error {
+	var (
+		req = new(entity.RestoreShopRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::RestoreShop - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::RestoreShop - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.RestoreShop(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
@@ -3,6 +3,7 @@ package ports
 import (
 	"codebase-app/internal/module/shop/entity"
 	"context"
+	"time"
 )
 
 type ShopRepository interface {
@@ -10,7 +11,12 @@ type ShopRepository interface {
 	GetShop(ctx context.Context, req *entity.GetShopRequest) (*entity.GetShopResponse, error)
 	DeleteShop(ctx context.Context, req *entity.DeleteShopRequest) error
 	UpdateShop(ctx context.Context, req *entity.UpdateShopRequest) (*entity.UpdateShopResponse, error)
+	GetShopVersion(ctx context.Context, id string) (int, error)
+	GetShopIdBySlug(ctx context.Context, slug string) (string, error)
 	GetShops(ctx context.Context, req *entity.ShopsRequest) (*entity.ShopsResponse, error)
+	GetTrashedShops(ctx context.Context, req *entity.TrashedShopsRequest) (*entity.TrashedShopsResponse, error)
+	RestoreShop(ctx context.Context, req *entity.RestoreShopRequest) (*entity.RestoreShopResponse, error)
+	PurgeShops(ctx context.Context, before time.Time) (int64, error)
 }
 
 type ShopService interface {
@@ -19,4 +25,7 @@ type ShopService interface {
 	DeleteShop(ctx context.Context, req *entity.DeleteShopRequest) error
 	UpdateShop(ctx context.Context, req *entity.UpdateShopRequest) (*entity.UpdateShopResponse, error)
 	GetShops(ctx context.Context, req *entity.ShopsRequest) (*entity.ShopsResponse, error)
+	GetTrashedShops(ctx context.Context, req *entity.TrashedShopsRequest) (*entity.TrashedShopsResponse, error)
+	RestoreShop(ctx context.Context, req *entity.RestoreShopRequest) (*entity.RestoreShopResponse, error)
+	PurgeShops(ctx context.Context, before time.Time) (int64, error)
 }
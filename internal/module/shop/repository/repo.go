@@ -3,7 +3,10 @@ package repository
 import (
 	"codebase-app/internal/module/shop/entity"
 	"codebase-app/internal/module/shop/ports"
+	"codebase-app/pkg/slug"
+	"codebase-app/pkg/types"
 	"context"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog/log"
@@ -23,32 +26,63 @@ func NewShopRepository(db *sqlx.DB) *shopRepository {
 
 func (r *shopRepository) CreateShop(ctx context.Context, req *entity.CreateShopRequest) (*entity.CreateShopResponse, error) {
 	var resp = new(entity.CreateShopResponse)
-	// Your code here
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateShop - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	picked, err := slug.Pick(ctx, tx, slug.Shops, req.Name, "")
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateShop - Failed to pick slug")
+		return nil, err
+	}
+
 	query := `
-		INSERT INTO shops (user_id, name, description, terms)
-		VALUES (?, ?, ?, ?) RETURNING id
+		INSERT INTO shops (user_id, name, description, terms, slug)
+		VALUES (?, ?, ?, ?, ?) RETURNING id, slug
 	`
 
-	err := r.db.QueryRowContext(ctx, r.db.Rebind(query),
+	err = tx.QueryRowxContext(ctx, tx.Rebind(query),
 		req.UserId,
 		req.Name,
 		req.Description,
-		req.Terms).Scan(&resp.Id)
+		req.Terms,
+		picked).StructScan(resp)
 	if err != nil {
 		log.Error().Err(err).Any("payload", req).Msg("repository::CreateShop - Failed to create shop")
 		return nil, err
 	}
 
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateShop - Failed to commit transaction")
+		return nil, err
+	}
+
 	return resp, nil
 }
 
+// GetShopIdBySlug finds a shop by its current slug or by one it had before
+// a rename.
+func (r *shopRepository) GetShopIdBySlug(ctx context.Context, value string) (string, error) {
+	id, err := slug.FindId(ctx, r.db, slug.Shops, value)
+	if err != nil {
+		log.Error().Err(err).Str("slug", value).Msg("repository::GetShopIdBySlug - Failed to get shop")
+		return "", err
+	}
+
+	return id, nil
+}
+
 func (r *shopRepository) GetShop(ctx context.Context, req *entity.GetShopRequest) (*entity.GetShopResponse, error) {
 	var resp = new(entity.GetShopResponse)
 	// Your code here
 	query := `
-		SELECT name, description, terms
+		SELECT id, slug, name, description, terms, version
 		FROM shops
-		WHERE id = ?
+		WHERE id = ? AND deleted_at IS NULL
 	`
 
 	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.Id).StructScan(resp)
@@ -60,77 +94,251 @@ func (r *shopRepository) GetShop(ctx context.Context, req *entity.GetShopRequest
 	return resp, nil
 }
 
+// DeleteShop moves the shop and its live products to the trash. It returns
+// sql.ErrNoRows when nothing was deleted, e.g. on a stale version.
 func (r *shopRepository) DeleteShop(ctx context.Context, req *entity.DeleteShopRequest) error {
+	var deletedAt time.Time
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteShop - Failed to begin transaction")
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `
 		UPDATE shops
-		SET deleted_at = NOW()
-		WHERE id = ? AND user_id = ?
-	`
+		SET deleted_at = NOW(), version = version + 1
+		WHERE id = ? AND user_id = ? AND deleted_at IS NULL` + types.VersionCondition + `
+		RETURNING deleted_at`
 
-	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.Id, req.UserId)
+	err = tx.QueryRowxContext(ctx, tx.Rebind(query), req.Id, req.UserId, req.Version, req.Version).Scan(&deletedAt)
 	if err != nil {
 		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteShop - Failed to delete shop")
 		return err
 	}
 
+	// the live products go to the trash with the shop, sharing its deleted_at
+	// so a restore can tell them from the ones trashed before
+	query = `
+		UPDATE products
+		SET deleted_at = ?, updated_at = NOW(), version = version + 1
+		WHERE shop_id = ? AND deleted_at IS NULL
+	`
+
+	if _, err := tx.ExecContext(ctx, tx.Rebind(query), deletedAt, req.Id); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteShop - Failed to delete shop products")
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteShop - Failed to commit transaction")
+		return err
+	}
+
 	return nil
 }
 
+func (r *shopRepository) GetShopVersion(ctx context.Context, id string) (int, error) {
+	var version int
+
+	query := `SELECT version FROM shops WHERE id = ? AND deleted_at IS NULL`
+
+	err := r.db.GetContext(ctx, &version, r.db.Rebind(query), id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::GetShopVersion - Failed to get shop version")
+		return 0, err
+	}
+
+	return version, nil
+}
+
 func (r *shopRepository) UpdateShop(ctx context.Context, req *entity.UpdateShopRequest) (*entity.UpdateShopResponse, error) {
 	var resp = new(entity.UpdateShopResponse)
 
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateShop - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
 	query := `
 		UPDATE shops
-		SET name = ?, description = ?, terms = ?, updated_at = NOW()
-		WHERE id = ? AND user_id = ?
-		RETURNING id
+		SET name = ?, description = ?, terms = ?, updated_at = NOW(), version = version + 1
+		WHERE id = ? AND user_id = ? AND deleted_at IS NULL` + types.VersionCondition + `
+		RETURNING id, version
 	`
 
-	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query),
+	err = tx.QueryRowxContext(ctx, tx.Rebind(query),
 		req.Name,
 		req.Description,
 		req.Terms,
 		req.Id,
-		req.UserId).Scan(&resp.Id)
+		req.UserId,
+		req.Version,
+		req.Version).StructScan(resp)
 	if err != nil {
 		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateShop - Failed to update shop")
 		return nil, err
 	}
 
+	resp.Slug, err = slug.Rename(ctx, tx, slug.Shops, req.Id, req.Name)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateShop - Failed to rename slug")
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateShop - Failed to commit transaction")
+		return nil, err
+	}
+
 	return resp, nil
 }
 
 func (r *shopRepository) GetShops(ctx context.Context, req *entity.ShopsRequest) (*entity.ShopsResponse, error) {
 	type dao struct {
-		TotalData int `db:"total_data"`
+		TotalData int       `db:"total_data"`
+		CreatedAt time.Time `db:"created_at"`
 		entity.ShopItem
 	}
 
 	var (
-		resp = new(entity.ShopsResponse)
-		data = make([]dao, 0, req.Paginate)
+		resp       = new(entity.ShopsResponse)
+		data       = make([]dao, 0, req.Paginate+1)
+		isCursor   = req.IsCursor()
+		isBackward = req.After != nil && req.After.Backward
+		totalData  = "COUNT(id) OVER()"
+		args       = []any{req.UserId}
 	)
 	resp.Items = make([]entity.ShopItem, 0, req.Paginate)
 
+	if isCursor {
+		totalData = "0"
+	}
+
 	query := `
 		SELECT
-			COUNT(id) OVER() as total_data,
+			` + totalData + ` as total_data,
+			created_at,
 			id,
+			slug,
 			name
 		FROM shops
 		WHERE
 			deleted_at IS NULL
 			AND user_id = ?
+	`
+
+	if isCursor {
+		if req.After != nil {
+			if isBackward {
+				query += " AND (created_at, id) > (?, ?)"
+			} else {
+				query += " AND (created_at, id) < (?, ?)"
+			}
+			args = append(args, req.After.Value, req.After.Id)
+		}
+
+		if isBackward {
+			query += " ORDER BY created_at ASC, id ASC LIMIT ?"
+		} else {
+			query += " ORDER BY created_at DESC, id DESC LIMIT ?"
+		}
+		args = append(args, req.Paginate+1)
+	} else {
+		query += " ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?"
+		args = append(args, req.Paginate, req.Paginate*(req.Page-1))
+	}
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query), args...)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetShops - Failed to get shops")
+		return nil, err
+	}
+
+	if isCursor {
+		hasMore := len(data) > req.Paginate
+		if hasMore {
+			data = data[:req.Paginate]
+		}
+
+		if isBackward {
+			for i, j := 0, len(data)-1; i < j; i, j = i+1, j-1 {
+				data[i], data[j] = data[j], data[i]
+			}
+		}
+
+		var next, prev *types.Cursor
+		if len(data) > 0 {
+			first, last := data[0], data[len(data)-1]
+			if hasMore || isBackward {
+				next = &types.Cursor{Sort: entity.ShopsSort, Value: last.CreatedAt, Id: last.Id}
+			}
+			if req.After != nil && (hasMore || !isBackward) {
+				prev = &types.Cursor{Sort: entity.ShopsSort, Value: first.CreatedAt, Id: first.Id, Backward: true}
+			}
+		}
+
+		resp.Meta.SetCursors(req.Paginate, next, prev)
+
+		if req.WithTotal {
+			query = "SELECT COUNT(id) FROM shops WHERE deleted_at IS NULL AND user_id = ?"
+			err = r.db.GetContext(ctx, &resp.Meta.TotalData, r.db.Rebind(query), req.UserId)
+			if err != nil {
+				log.Error().Err(err).Any("payload", req).Msg("repository::GetShops - Failed to count shops")
+				return nil, err
+			}
+		}
+	} else {
+		if len(data) > 0 {
+			resp.Meta.TotalData = data[0].TotalData
+		}
+
+		resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.ShopItem)
+	}
+
+	return resp, nil
+}
+
+func (r *shopRepository) GetTrashedShops(ctx context.Context, req *entity.TrashedShopsRequest) (*entity.TrashedShopsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.TrashedShopItem
+	}
+
+	var (
+		resp = new(entity.TrashedShopsResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.TrashedShopItem, 0, req.Paginate)
+
+	query := `
+		SELECT
+			COUNT(s.id) OVER() as total_data,
+			s.id,
+			s.name,
+			(SELECT COUNT(p.id) FROM products p WHERE p.shop_id = s.id AND p.deleted_at >= s.deleted_at) AS product_count,
+			s.deleted_at,
+			s.deleted_at + make_interval(days => ?) AS purge_at
+		FROM shops s
+		WHERE s.user_id = ? AND s.deleted_at IS NOT NULL
+		ORDER BY s.deleted_at DESC, s.id DESC
 		LIMIT ? OFFSET ?
 	`
 
 	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query),
+		req.RetentionDays,
 		req.UserId,
 		req.Paginate,
-		req.Paginate*(req.Page-1),
-	)
+		req.Paginate*(req.Page-1))
 	if err != nil {
-		log.Error().Err(err).Any("payload", req).Msg("repository::GetShops - Failed to get shops")
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetTrashedShops - Failed to get trashed shops")
 		return nil, err
 	}
 
@@ -139,10 +347,86 @@ func (r *shopRepository) GetShops(ctx context.Context, req *entity.ShopsRequest)
 	}
 
 	for _, d := range data {
-		resp.Items = append(resp.Items, d.ShopItem)
+		resp.Items = append(resp.Items, d.TrashedShopItem)
 	}
 
 	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
 
 	return resp, nil
 }
+
+// RestoreShop brings a shop back from the trash, with WithProducts its
+// trashed products are restored in the same transaction.
+func (r *shopRepository) RestoreShop(ctx context.Context, req *entity.RestoreShopRequest) (*entity.RestoreShopResponse, error) {
+	var resp = new(entity.RestoreShopResponse)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::RestoreShop - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var deletedAt time.Time
+
+	query := `
+		UPDATE shops s
+		SET deleted_at = NULL, updated_at = NOW(), version = s.version + 1
+		FROM (SELECT id, deleted_at FROM shops WHERE id = ? FOR UPDATE) trashed
+		WHERE s.id = trashed.id AND s.user_id = ? AND s.deleted_at IS NOT NULL
+		RETURNING s.id, trashed.deleted_at
+	`
+
+	err = tx.QueryRowxContext(ctx, r.db.Rebind(query), req.Id, req.UserId).Scan(&resp.Id, &deletedAt)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::RestoreShop - Failed to restore shop")
+		return nil, err
+	}
+
+	if req.WithProducts {
+		// products the seller trashed before deleting the shop stay trashed
+		query = `
+			UPDATE products
+			SET deleted_at = NULL, updated_at = NOW(), version = version + 1
+			WHERE shop_id = ? AND deleted_at >= ?
+		`
+
+		res, err := tx.ExecContext(ctx, r.db.Rebind(query), req.Id, deletedAt)
+		if err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::RestoreShop - Failed to restore shop products")
+			return nil, err
+		}
+		resp.RestoredProducts, _ = res.RowsAffected()
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::RestoreShop - Failed to commit transaction")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// PurgeShops hard deletes the shops soft-deleted before the given time. A shop
+// whose products are still live or not yet expired is kept, since deleting it
+// would cascade to them.
+func (r *shopRepository) PurgeShops(ctx context.Context, before time.Time) (int64, error) {
+	query := `
+		DELETE FROM shops s
+		WHERE
+			s.deleted_at < ?
+			AND NOT EXISTS (
+				SELECT 1
+				FROM products p
+				WHERE p.shop_id = s.id AND (p.deleted_at IS NULL OR p.deleted_at >= ?)
+			)
+	`
+
+	res, err := r.db.ExecContext(ctx, r.db.Rebind(query), before, before)
+	if err != nil {
+		log.Error().Err(err).Time("before", before).Msg("repository::PurgeShops - Failed to purge shops")
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
@@ -3,7 +3,14 @@ package service
 import (
 	"codebase-app/internal/module/shop/entity"
 	"codebase-app/internal/module/shop/ports"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/types"
 	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
 )
 
 var _ ports.ShopService = &shopService{}
@@ -22,18 +29,104 @@ func (s *shopService) CreateShop(ctx context.Context, req *entity.CreateShopRequ
 	return s.repo.CreateShop(ctx, req)
 }
 
+// GetShop finds the shop by id or by slug, a slug the shop had before a
+// rename finds it as well.
 func (s *shopService) GetShop(ctx context.Context, req *entity.GetShopRequest) (*entity.GetShopResponse, error) {
-	return s.repo.GetShop(ctx, req)
+	if req.Slug != "" {
+		id, err := s.repo.GetShopIdBySlug(ctx, req.Slug)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Shop not found"))
+			}
+			return nil, err
+		}
+		req.Id = id
+	}
+
+	res, err := s.repo.GetShop(ctx, req)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Warn().Any("payload", req).Msg("service: Shop not found")
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Shop not found"))
+		}
+		return nil, err
+	}
+
+	return res, nil
 }
 
 func (s *shopService) DeleteShop(ctx context.Context, req *entity.DeleteShopRequest) error {
-	return s.repo.DeleteShop(ctx, req)
+	var err error
+
+	req.Version, err = types.CheckVersion(ctx, "Shop", req.Id, req.IfMatch, s.repo.GetShopVersion)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteShop(ctx, req); err != nil {
+		return types.WriteError("Shop", err, req.Version)
+	}
+
+	return nil
 }
 
 func (s *shopService) UpdateShop(ctx context.Context, req *entity.UpdateShopRequest) (*entity.UpdateShopResponse, error) {
-	return s.repo.UpdateShop(ctx, req)
+	var err error
+
+	req.Version, err = types.CheckVersion(ctx, "Shop", req.Id, req.IfMatch, s.repo.GetShopVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.UpdateShop(ctx, req)
+	if err != nil {
+		return nil, types.WriteError("Shop", err, req.Version)
+	}
+
+	return res, nil
 }
 
 func (s *shopService) GetShops(ctx context.Context, req *entity.ShopsRequest) (*entity.ShopsResponse, error) {
+	if req.Cursor != "" {
+		cursor, err := types.DecodeCursor(req.Cursor)
+		if err != nil || cursor.Sort != entity.ShopsSort {
+			log.Warn().Err(err).Any("payload", req).Msg("service: Invalid shops cursor")
+			return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("cursor", "cursor tidak valid."))
+		}
+
+		req.After = cursor
+	}
+
 	return s.repo.GetShops(ctx, req)
 }
+
+func (s *shopService) GetTrashedShops(ctx context.Context, req *entity.TrashedShopsRequest) (*entity.TrashedShopsResponse, error) {
+	return s.repo.GetTrashedShops(ctx, req)
+}
+
+func (s *shopService) RestoreShop(ctx context.Context, req *entity.RestoreShopRequest) (*entity.RestoreShopResponse, error) {
+	res, err := s.repo.RestoreShop(ctx, req)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Warn().Any("payload", req).Msg("service: Shop not found in trash")
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Shop not found in trash"))
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// PurgeShops hard deletes the shops that sit in the trash since before the given time.
+func (s *shopService) PurgeShops(ctx context.Context, before time.Time) (int64, error) {
+	total, err := s.repo.PurgeShops(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+
+	if total > 0 {
+		log.Info().Int64("shops", total).Time("before", before).Msg("service: Trashed shops purged")
+	}
+
+	return total, nil
+}
@@ -1,12 +1,14 @@
 package service
 
 import (
+	"database/sql"
 	"errors"
 	"testing"
 
 	"codebase-app/internal/module/shop/entity"
 	"codebase-app/internal/module/shop/ports"
 	mockPort "codebase-app/mock/module/shop/ports"
+	"codebase-app/pkg/errmsg"
 	"codebase-app/pkg/types"
 
 	"github.com/stretchr/testify/mock"
@@ -110,6 +112,43 @@ func (suite *ServiceList) TestGetShop_GetShopRepoError() {
 	suite.Equal(errors.New(mock.Anything), err)
 }
 
+func (suite *ServiceList) TestGetShop_Deleted() {
+	ctx := context.Background()
+	req := &entity.GetShopRequest{
+		Id: "1",
+	}
+	suite.mockShopRepo.On("GetShop", ctx, req).Return(nil, sql.ErrNoRows)
+	_, err := suite.service.GetShop(ctx, req)
+
+	suite.Equal(errmsg.NewCustomErrors(404, errmsg.WithMessage("Shop not found")), err)
+}
+
+func (suite *ServiceList) TestGetShop_BySlug() {
+	ctx := context.Background()
+	req := &entity.GetShopRequest{
+		Slug: "toko-lama",
+	}
+	suite.mockShopRepo.On("GetShopIdBySlug", ctx, "toko-lama").Return("1", nil)
+	suite.mockShopRepo.On("GetShop", ctx, req).Return(entity.GetShopResponse{Id: "1", Slug: "toko"}, nil)
+	res, err := suite.service.GetShop(ctx, req)
+
+	suite.Equal(nil, err)
+	suite.Equal("1", req.Id)
+	suite.Equal("toko", res.Slug)
+}
+
+func (suite *ServiceList) TestGetShop_SlugNotFound() {
+	ctx := context.Background()
+	req := &entity.GetShopRequest{
+		Slug: "toko",
+	}
+	suite.mockShopRepo.On("GetShopIdBySlug", ctx, "toko").Return("", sql.ErrNoRows)
+	_, err := suite.service.GetShop(ctx, req)
+
+	suite.Equal(errmsg.NewCustomErrors(404, errmsg.WithMessage("Shop not found")), err)
+	suite.mockShopRepo.AssertNotCalled(suite.T(), "GetShop", mock.Anything, mock.Anything)
+}
+
 func (suite *ServiceList) TestDeleteShop_Success() {
 	ctx := context.Background()
 	req := &entity.DeleteShopRequest{
@@ -168,6 +207,77 @@ func (suite *ServiceList) TestGetShops_EmptyShop() {
 	suite.Equal(nil, err)
 }
 
+func (suite *ServiceList) TestGetShops_InvalidCursor() {
+	ctx := context.Background()
+	req := &entity.ShopsRequest{
+		UserId: "1",
+		Cursor: "not-a-cursor",
+	}
+	errCursor := errmsg.NewCustomErrors(400, errmsg.WithErrors("cursor", "cursor tidak valid."))
+
+	_, err := suite.service.GetShops(ctx, req)
+
+	suite.Equal(errCursor, err)
+}
+
+func (suite *ServiceList) TestRestoreShop_Success() {
+	ctx := context.Background()
+	req := &entity.RestoreShopRequest{
+		UserId:       "1",
+		Id:           "2",
+		WithProducts: true,
+	}
+	res := &entity.RestoreShopResponse{Id: "2", RestoredProducts: 3}
+	suite.mockShopRepo.On("RestoreShop", ctx, req).Return(res, nil)
+	resp, err := suite.service.RestoreShop(ctx, req)
+
+	suite.Equal(nil, err)
+	suite.Equal(res, resp)
+}
+
+func (suite *ServiceList) TestRestoreShop_NotInTrash() {
+	ctx := context.Background()
+	req := &entity.RestoreShopRequest{
+		UserId: "1",
+		Id:     "2",
+	}
+	errNotFound := errmsg.NewCustomErrors(404, errmsg.WithMessage("Shop not found in trash"))
+
+	suite.mockShopRepo.On("RestoreShop", ctx, req).Return(nil, sql.ErrNoRows)
+	_, err := suite.service.RestoreShop(ctx, req)
+
+	suite.Equal(errNotFound, err)
+}
+
+func (suite *ServiceList) TestDeleteShop_StaleIfMatch() {
+	ctx := context.Background()
+	req := &entity.DeleteShopRequest{
+		Id:      "1",
+		IfMatch: types.ParseETags(`"1"`),
+	}
+	errStale := errmsg.NewCustomErrors(412, errmsg.WithMessage("Shop has been modified, reload it and try again"))
+
+	suite.mockShopRepo.On("GetShopVersion", ctx, req.Id).Return(2, nil)
+	err := suite.service.DeleteShop(ctx, req)
+
+	suite.Equal(errStale, err)
+	suite.mockShopRepo.AssertNotCalled(suite.T(), "DeleteShop", ctx, req)
+}
+
+func (suite *ServiceList) TestUpdateShop_IfMatchAny() {
+	ctx := context.Background()
+	req := &entity.UpdateShopRequest{
+		Id:      "1",
+		IfMatch: types.ParseETags("*"),
+	}
+	suite.mockShopRepo.On("GetShopVersion", ctx, req.Id).Return(2, nil)
+	suite.mockShopRepo.On("UpdateShop", ctx, req).Return(entity.UpdateShopResponse{Id: "1"}, nil)
+	_, err := suite.service.UpdateShop(ctx, req)
+
+	suite.Equal(nil, err)
+	suite.Equal(2, *req.Version)
+}
+
 func TestService(t *testing.T) {
 	suite.Run(t, new(ServiceList))
 }
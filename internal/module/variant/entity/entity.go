@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"codebase-app/pkg/types"
+	"time"
+)
+
+type VariantItem struct {
+	Id            string          `json:"id" db:"id"`
+	ProductId     string          `json:"product_id" db:"product_id"`
+	Sku           string          `json:"sku" db:"sku"`
+	Options       types.StringMap `json:"options" db:"options"`
+	Price         float64         `json:"price" db:"price"`
+	PriceOverride *float64        `json:"price_override" db:"price_override"`
+	Stock         int             `json:"stock" db:"stock"`
+	Barcode       *string         `json:"barcode" db:"barcode"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+type VariantsRequest struct {
+	ProductId string `params:"id" validate:"uuid"`
+}
+
+type VariantsResponse struct {
+	Items []VariantItem `json:"items"`
+}
+
+type CreateVariantRequest struct {
+	UserId    string `prop:"user_id" validate:"uuid"`
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+
+	Sku     string          `json:"sku" validate:"required,max=64" db:"sku"`
+	Options types.StringMap `json:"options" validate:"required,min=1" db:"options"`
+	Price   *float64        `json:"price" validate:"omitempty,min=0" db:"price"`
+	Stock   int             `json:"stock" validate:"min=0" db:"stock"`
+	Barcode *string         `json:"barcode" validate:"omitempty,max=64" db:"barcode"`
+}
+
+type CreateVariantResponse struct {
+	Id string `json:"id" db:"id"`
+}
+
+type GetVariantRequest struct {
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+	Id        string `params:"variant_id" validate:"uuid" db:"id"`
+}
+
+type UpdateVariantRequest struct {
+	UserId    string `prop:"user_id" validate:"uuid"`
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+	Id        string `params:"variant_id" validate:"uuid" db:"id"`
+
+	Sku     string          `json:"sku" validate:"required,max=64" db:"sku"`
+	Options types.StringMap `json:"options" validate:"required,min=1" db:"options"`
+	Price   *float64        `json:"price" validate:"omitempty,min=0" db:"price"`
+	Stock   int             `json:"stock" validate:"min=0" db:"stock"`
+	Barcode *string         `json:"barcode" validate:"omitempty,max=64" db:"barcode"`
+}
+
+type UpdateVariantResponse struct {
+	Id string `json:"id" db:"id"`
+}
+
+type DeleteVariantRequest struct {
+	UserId    string `prop:"user_id" validate:"uuid"`
+	ProductId string `params:"id" validate:"uuid" db:"product_id"`
+	Id        string `params:"variant_id" validate:"uuid" db:"id"`
+}
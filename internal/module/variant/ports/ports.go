@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"codebase-app/internal/module/variant/entity"
+	"context"
+)
+
+type VariantRepository interface {
+	GetVariants(ctx context.Context, req *entity.VariantsRequest) (*entity.VariantsResponse, error)
+	GetVariant(ctx context.Context, req *entity.GetVariantRequest) (*entity.VariantItem, error)
+	CreateVariant(ctx context.Context, req *entity.CreateVariantRequest) (*entity.CreateVariantResponse, error)
+	UpdateVariant(ctx context.Context, req *entity.UpdateVariantRequest) (*entity.UpdateVariantResponse, error)
+	DeleteVariant(ctx context.Context, req *entity.DeleteVariantRequest) error
+
+	IsProductOwner(ctx context.Context, userId, productId string) (bool, error)
+}
+
+type VariantService interface {
+	GetVariants(ctx context.Context, req *entity.VariantsRequest) (*entity.VariantsResponse, error)
+	GetVariant(ctx context.Context, req *entity.GetVariantRequest) (*entity.VariantItem, error)
+	CreateVariant(ctx context.Context, req *entity.CreateVariantRequest) (*entity.CreateVariantResponse, error)
+	UpdateVariant(ctx context.Context, req *entity.UpdateVariantRequest) (*entity.UpdateVariantResponse, error)
+	DeleteVariant(ctx context.Context, req *entity.DeleteVariantRequest) error
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"codebase-app/internal/module/variant/entity"
+	"codebase-app/internal/module/variant/ports"
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.VariantRepository = &variantRepository{}
+
+type variantRepository struct {
+	db *sqlx.DB
+}
+
+func NewVariantRepository(db *sqlx.DB) *variantRepository {
+	return &variantRepository{
+		db: db,
+	}
+}
+
+func (r *variantRepository) GetVariants(ctx context.Context, req *entity.VariantsRequest) (*entity.VariantsResponse, error) {
+	var resp = new(entity.VariantsResponse)
+	resp.Items = make([]entity.VariantItem, 0)
+
+	query := `
+		SELECT
+			v.id,
+			v.product_id,
+			v.sku,
+			v.options,
+			COALESCE(v.price, p.price) AS price,
+			v.price AS price_override,
+			v.stock,
+			v.barcode,
+			v.created_at,
+			v.updated_at
+		FROM product_variants v
+		INNER JOIN products p ON v.product_id = p.id
+		WHERE
+			v.product_id = ?
+			AND v.deleted_at IS NULL
+			AND p.deleted_at IS NULL
+		ORDER BY v.created_at ASC, v.id ASC
+	`
+
+	err := r.db.SelectContext(ctx, &resp.Items, r.db.Rebind(query), req.ProductId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetVariants - Failed to get variants")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *variantRepository) GetVariant(ctx context.Context, req *entity.GetVariantRequest) (*entity.VariantItem, error) {
+	var resp = new(entity.VariantItem)
+
+	query := `
+		SELECT
+			v.id,
+			v.product_id,
+			v.sku,
+			v.options,
+			COALESCE(v.price, p.price) AS price,
+			v.price AS price_override,
+			v.stock,
+			v.barcode,
+			v.created_at,
+			v.updated_at
+		FROM product_variants v
+		INNER JOIN products p ON v.product_id = p.id
+		WHERE
+			v.id = ?
+			AND v.product_id = ?
+			AND v.deleted_at IS NULL
+			AND p.deleted_at IS NULL
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.Id, req.ProductId).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetVariant - Failed to get variant")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *variantRepository) CreateVariant(ctx context.Context, req *entity.CreateVariantRequest) (*entity.CreateVariantResponse, error) {
+	var resp = new(entity.CreateVariantResponse)
+
+	query := `
+		INSERT INTO product_variants (product_id, sku, options, price, stock, barcode)
+		VALUES (?, ?, ?, ?, ?, ?) RETURNING id
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query),
+		req.ProductId,
+		req.Sku,
+		req.Options,
+		req.Price,
+		req.Stock,
+		req.Barcode).Scan(&resp.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateVariant - Failed to create variant")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *variantRepository) UpdateVariant(ctx context.Context, req *entity.UpdateVariantRequest) (*entity.UpdateVariantResponse, error) {
+	var resp = new(entity.UpdateVariantResponse)
+
+	query := `
+		UPDATE product_variants
+		SET sku = ?, options = ?, price = ?, stock = ?, barcode = ?, updated_at = NOW()
+		WHERE id = ? AND product_id = ? AND deleted_at IS NULL
+		RETURNING id
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query),
+		req.Sku,
+		req.Options,
+		req.Price,
+		req.Stock,
+		req.Barcode,
+		req.Id,
+		req.ProductId).Scan(&resp.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateVariant - Failed to update variant")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *variantRepository) DeleteVariant(ctx context.Context, req *entity.DeleteVariantRequest) error {
+	query := `
+		UPDATE product_variants
+		SET deleted_at = NOW()
+		WHERE id = ? AND product_id = ? AND deleted_at IS NULL
+	`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.Id, req.ProductId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteVariant - Failed to delete variant")
+		return err
+	}
+
+	return nil
+}
+
+func (r *variantRepository) IsProductOwner(ctx context.Context, userId, productId string) (bool, error) {
+	var (
+		isOwner bool
+		payload = struct {
+			UserId    string `json:"user_id"`
+			ProductId string `json:"product_id"`
+		}{userId, productId}
+	)
+
+	query := `
+		SELECT
+			EXISTS (
+				SELECT 1
+				FROM
+					products
+				LEFT JOIN
+					shops ON products.shop_id = shops.id
+				WHERE
+					shops.user_id = $1
+					AND products.id = $2
+					AND products.deleted_at IS NULL
+			)
+	`
+
+	err := r.db.GetContext(ctx, &isOwner, query, userId, productId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", payload).Msg("repository: IsProductOwner failed")
+		return isOwner, err
+	}
+
+	return isOwner, nil
+}
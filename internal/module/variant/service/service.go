@@ -0,0 +1,86 @@
+package service
+
+import (
+	"codebase-app/internal/module/variant/entity"
+	"codebase-app/internal/module/variant/ports"
+	"codebase-app/pkg/errmsg"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.VariantService = &variantService{}
+
+type variantService struct {
+	repo ports.VariantRepository
+}
+
+func NewVariantService(repo ports.VariantRepository) *variantService {
+	return &variantService{
+		repo: repo,
+	}
+}
+
+func (s *variantService) GetVariants(ctx context.Context, req *entity.VariantsRequest) (*entity.VariantsResponse, error) {
+	return s.repo.GetVariants(ctx, req)
+}
+
+func (s *variantService) GetVariant(ctx context.Context, req *entity.GetVariantRequest) (*entity.VariantItem, error) {
+	res, err := s.repo.GetVariant(ctx, req)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Variant not found"))
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *variantService) CreateVariant(ctx context.Context, req *entity.CreateVariantRequest) (*entity.CreateVariantResponse, error) {
+	if err := s.checkProductOwner(ctx, req.UserId, req.ProductId); err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreateVariant(ctx, req)
+}
+
+func (s *variantService) UpdateVariant(ctx context.Context, req *entity.UpdateVariantRequest) (*entity.UpdateVariantResponse, error) {
+	if err := s.checkProductOwner(ctx, req.UserId, req.ProductId); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.UpdateVariant(ctx, req)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Variant not found"))
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *variantService) DeleteVariant(ctx context.Context, req *entity.DeleteVariantRequest) error {
+	if err := s.checkProductOwner(ctx, req.UserId, req.ProductId); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteVariant(ctx, req)
+}
+
+func (s *variantService) checkProductOwner(ctx context.Context, userId, productId string) error {
+	isProductOwner, err := s.repo.IsProductOwner(ctx, userId, productId)
+	if err != nil {
+		return err
+	}
+
+	if !isProductOwner {
+		log.Warn().Str("user_id", userId).Str("product_id", productId).Msg("service: User is not product owner")
+		return errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not product owner"))
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"codebase-app/internal/module/variant/entity"
+	"codebase-app/internal/module/variant/ports"
+	mockPort "codebase-app/mock/module/variant/ports"
+	"codebase-app/pkg/errmsg"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServiceList struct {
+	suite.Suite
+	mockVariantRepo *mockPort.MockVariantRepo
+	service         ports.VariantService
+
+	mockCreateVariantReq *entity.CreateVariantRequest
+	mockUpdateVariantReq *entity.UpdateVariantRequest
+}
+
+func (suite *ServiceList) SetupTest() {
+	suite.mockVariantRepo = new(mockPort.MockVariantRepo)
+	suite.service = NewVariantService(suite.mockVariantRepo)
+	suite.mockCreateVariantReq = &entity.CreateVariantRequest{
+		UserId:    "1",
+		ProductId: "2",
+		Sku:       "TSHIRT-M-RED",
+		Options:   map[string]string{"size": "M", "color": "red"},
+		Stock:     10,
+	}
+	suite.mockUpdateVariantReq = &entity.UpdateVariantRequest{
+		UserId:    "1",
+		ProductId: "2",
+		Id:        "3",
+		Sku:       "TSHIRT-M-RED",
+		Options:   map[string]string{"size": "M", "color": "red"},
+		Stock:     5,
+	}
+}
+
+func (suite *ServiceList) TestCreateVariant_Success() {
+	ctx := context.Background()
+	req := suite.mockCreateVariantReq
+
+	suite.mockVariantRepo.On("IsProductOwner", ctx, req.UserId, req.ProductId).Return(true, nil)
+	suite.mockVariantRepo.On("CreateVariant", ctx, req).Return(entity.CreateVariantResponse{Id: "3"}, nil)
+	res, err := suite.service.CreateVariant(ctx, req)
+
+	suite.Equal(nil, err)
+	suite.Equal("3", res.Id)
+}
+
+func (suite *ServiceList) TestCreateVariant_UserIsNotTheProductOwner() {
+	ctx := context.Background()
+	req := suite.mockCreateVariantReq
+	errForbidden := errmsg.NewCustomErrors(403, errmsg.WithMessage("User is not product owner"))
+
+	suite.mockVariantRepo.On("IsProductOwner", ctx, req.UserId, req.ProductId).Return(false, nil)
+	_, err := suite.service.CreateVariant(ctx, req)
+
+	suite.Equal(errForbidden, err)
+	suite.mockVariantRepo.AssertNotCalled(suite.T(), "CreateVariant", ctx, req)
+}
+
+func (suite *ServiceList) TestCreateVariant_IsProductOwnerError() {
+	ctx := context.Background()
+	req := suite.mockCreateVariantReq
+
+	suite.mockVariantRepo.On("IsProductOwner", ctx, req.UserId, req.ProductId).Return(false, errors.New(mock.Anything))
+	_, err := suite.service.CreateVariant(ctx, req)
+
+	suite.Equal(errors.New(mock.Anything), err)
+}
+
+func (suite *ServiceList) TestUpdateVariant_NotFound() {
+	ctx := context.Background()
+	req := suite.mockUpdateVariantReq
+	errNotFound := errmsg.NewCustomErrors(404, errmsg.WithMessage("Variant not found"))
+
+	suite.mockVariantRepo.On("IsProductOwner", ctx, req.UserId, req.ProductId).Return(true, nil)
+	suite.mockVariantRepo.On("UpdateVariant", ctx, req).Return(mock.Anything, sql.ErrNoRows)
+	_, err := suite.service.UpdateVariant(ctx, req)
+
+	suite.Equal(errNotFound, err)
+}
+
+func (suite *ServiceList) TestDeleteVariant_Success() {
+	ctx := context.Background()
+	req := &entity.DeleteVariantRequest{
+		UserId:    "1",
+		ProductId: "2",
+		Id:        "3",
+	}
+
+	suite.mockVariantRepo.On("IsProductOwner", ctx, req.UserId, req.ProductId).Return(true, nil)
+	suite.mockVariantRepo.On("DeleteVariant", ctx, req).Return(nil)
+	err := suite.service.DeleteVariant(ctx, req)
+
+	suite.Equal(nil, err)
+}
+
+func TestService(t *testing.T) {
+	suite.Run(t, new(ServiceList))
+}
@@ -1,8 +1,19 @@
 package route
 
 import (
+	"codebase-app/internal/infrastructure/config"
+	integration "codebase-app/internal/integration/filestorage"
+	"codebase-app/internal/middleware"
+	handlerBrand "codebase-app/internal/module/brand/handler/rest"
+	handlerCategory "codebase-app/internal/module/category/handler/rest"
+	handlerInventory "codebase-app/internal/module/inventory/handler/rest"
+	handlerMedia "codebase-app/internal/module/media/handler/rest"
+	handlerPrice "codebase-app/internal/module/price/handler/rest"
 	handlerProduct "codebase-app/internal/module/product/handler/rest"
+	handlerPromotion "codebase-app/internal/module/promotion/handler/rest"
+	handlerReview "codebase-app/internal/module/review/handler/rest"
 	handlerShop "codebase-app/internal/module/shop/handler/rest"
+	handlerVariant "codebase-app/internal/module/variant/handler/rest"
 	"codebase-app/pkg/response"
 
 	"github.com/gofiber/fiber/v2"
@@ -16,6 +27,20 @@ func SetupRoutes(app *fiber.App) {
 
 	handlerShop.NewShopHandler().Register(api)
 	handlerProduct.NewProductHandler().Register(api)
+	handlerVariant.NewVariantHandler().Register(api)
+	handlerMedia.NewMediaHandler().Register(api)
+	handlerReview.NewReviewHandler().Register(api)
+	handlerCategory.NewCategoryHandler().Register(api)
+	handlerBrand.NewBrandHandler().Register(api)
+	handlerInventory.NewInventoryHandler().Register(api)
+	handlerPrice.NewPriceHandler().Register(api)
+	handlerPromotion.NewPromotionHandler().Register(api)
+
+	// images stored by the local storage driver
+	api.Static("/storage/public", config.Envs.App.LocalStoragePublicPath)
+
+	// private files, e.g. product exports, handed out by storage.GenerateSignedURL
+	app.Get("/api/storage/private/*", middleware.ValidateSignedURL, privateFile(integration.NewFileStorageIntegration()))
 
 	// fallback route
 	app.Use(func(c *fiber.Ctx) error {
@@ -0,0 +1,17 @@
+package route
+
+import (
+	jobInventory "codebase-app/internal/module/inventory/handler/job"
+	jobPrice "codebase-app/internal/module/price/handler/job"
+	jobProduct "codebase-app/internal/module/product/handler/job"
+	jobShop "codebase-app/internal/module/shop/handler/job"
+	"codebase-app/pkg/scheduler"
+)
+
+// SetupJobs registers the background jobs run by the server process.
+func SetupJobs(s *scheduler.Scheduler) {
+	jobProduct.NewProductJob().Register(s)
+	jobShop.NewShopJob().Register(s)
+	jobInventory.NewInventoryJob().Register(s)
+	jobPrice.NewPriceJob().Register(s)
+}
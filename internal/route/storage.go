@@ -0,0 +1,31 @@
+package route
+
+import (
+	integration "codebase-app/internal/integration/filestorage"
+	"codebase-app/internal/integration/filestorage/entity"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/response"
+	"path"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// privateFile serves a file of the private storage as a download, the route
+// is guarded by middleware.ValidateSignedURL.
+func privateFile(storage integration.FileStorageContract) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		filename := c.Params("*")
+
+		file, err := storage.GetPrivate(c.Context(), &entity.GetPrivateFileRequest{FileName: filename})
+		if err != nil {
+			code, errs := errmsg.Errors[error](err)
+			return c.Status(code).JSON(response.Error(errs))
+		}
+
+		c.Type(path.Ext(filename))
+		c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+path.Base(filename)+`"`)
+
+		// fasthttp closes the file once it is sent
+		return c.SendStream(file)
+	}
+}
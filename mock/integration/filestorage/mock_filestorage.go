@@ -0,0 +1,92 @@
+package mock_filestorage
+
+import (
+	integration "codebase-app/internal/integration/filestorage"
+	"codebase-app/internal/integration/filestorage/entity"
+	"context"
+	"io"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFileStorage struct {
+	mock.Mock
+}
+
+func NewMockFileStorage() *MockFileStorage {
+	return &MockFileStorage{}
+}
+
+var _ integration.FileStorageContract = &MockFileStorage{}
+
+func (m *MockFileStorage) Put(ctx context.Context, req *entity.PutFileRequest) (entity.PutFileResponse, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp entity.PutFileResponse
+		err  error
+	)
+
+	if n, ok := args.Get(0).(entity.PutFileResponse); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockFileStorage) Delete(ctx context.Context, req *entity.DeleteFileRequest) error {
+	args := m.Called(ctx, req)
+	var err error
+
+	if n, ok := args.Get(0).(error); ok {
+
+		err = n
+	}
+
+	return err
+}
+
+func (m *MockFileStorage) PutPrivate(ctx context.Context, req *entity.PutPrivateFileRequest) (entity.PutFileResponse, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp entity.PutFileResponse
+		err  error
+	)
+
+	if n, ok := args.Get(0).(entity.PutFileResponse); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockFileStorage) GetPrivate(ctx context.Context, req *entity.GetPrivateFileRequest) (io.ReadCloser, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp io.ReadCloser
+		err  error
+	)
+
+	if n, ok := args.Get(0).(io.ReadCloser); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
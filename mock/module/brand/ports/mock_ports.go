@@ -0,0 +1,91 @@
+package mock_ports
+
+import (
+	"codebase-app/internal/module/brand/entity"
+	"codebase-app/internal/module/brand/ports"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBrandRepo struct {
+	mock.Mock
+}
+
+func NewMockBrandRepo() *MockBrandRepo {
+	return &MockBrandRepo{}
+}
+
+var _ ports.BrandRepository = &MockBrandRepo{}
+
+func (m *MockBrandRepo) GetBrands(ctx context.Context, req *entity.BrandsRequest) (*entity.BrandsResponse, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp entity.BrandsResponse
+		err  error
+	)
+
+	if n, ok := args.Get(0).(entity.BrandsResponse); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return &resp, err
+}
+
+func (m *MockBrandRepo) CreateBrand(ctx context.Context, req *entity.CreateBrandRequest) (*entity.BrandItem, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp entity.BrandItem
+		err  error
+	)
+
+	if n, ok := args.Get(0).(entity.BrandItem); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return &resp, err
+}
+
+func (m *MockBrandRepo) UpdateBrand(ctx context.Context, req *entity.UpdateBrandRequest) (*entity.BrandItem, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp entity.BrandItem
+		err  error
+	)
+
+	if n, ok := args.Get(0).(entity.BrandItem); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return &resp, err
+}
+
+func (m *MockBrandRepo) DeleteBrand(ctx context.Context, req *entity.DeleteBrandRequest) error {
+	args := m.Called(ctx, req)
+	var err error
+
+	if n, ok := args.Get(0).(error); ok {
+
+		err = n
+	}
+
+	return err
+}
@@ -0,0 +1,151 @@
+package mock_ports
+
+import (
+	"codebase-app/internal/module/category/entity"
+	"codebase-app/internal/module/category/ports"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCategoryRepo struct {
+	mock.Mock
+}
+
+func NewMockCategoryRepo() *MockCategoryRepo {
+	return &MockCategoryRepo{}
+}
+
+var _ ports.CategoryRepository = &MockCategoryRepo{}
+
+func (m *MockCategoryRepo) GetCategories(ctx context.Context, req *entity.CategoriesRequest) (*entity.CategoriesResponse, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp entity.CategoriesResponse
+		err  error
+	)
+
+	if n, ok := args.Get(0).(entity.CategoriesResponse); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return &resp, err
+}
+
+func (m *MockCategoryRepo) CreateCategory(ctx context.Context, req *entity.CreateCategoryRequest) (*entity.CategoryItem, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp entity.CategoryItem
+		err  error
+	)
+
+	if n, ok := args.Get(0).(entity.CategoryItem); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return &resp, err
+}
+
+func (m *MockCategoryRepo) UpdateCategory(ctx context.Context, req *entity.UpdateCategoryRequest) (*entity.CategoryItem, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp entity.CategoryItem
+		err  error
+	)
+
+	if n, ok := args.Get(0).(entity.CategoryItem); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return &resp, err
+}
+
+func (m *MockCategoryRepo) DeleteCategory(ctx context.Context, req *entity.DeleteCategoryRequest) error {
+	args := m.Called(ctx, req)
+	var err error
+
+	if n, ok := args.Get(0).(error); ok {
+
+		err = n
+	}
+
+	return err
+}
+
+func (m *MockCategoryRepo) GetAllCategories(ctx context.Context) ([]entity.CategoryItem, error) {
+	args := m.Called(ctx)
+	var (
+		resp []entity.CategoryItem
+		err  error
+	)
+
+	if n, ok := args.Get(0).([]entity.CategoryItem); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockCategoryRepo) GetAttributes(ctx context.Context, req *entity.AttributesRequest) (*entity.AttributesResponse, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp entity.AttributesResponse
+		err  error
+	)
+
+	if n, ok := args.Get(0).(entity.AttributesResponse); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return &resp, err
+}
+
+func (m *MockCategoryRepo) SetAttributes(ctx context.Context, req *entity.SetAttributesRequest) (*entity.AttributesResponse, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp entity.AttributesResponse
+		err  error
+	)
+
+	if n, ok := args.Get(0).(entity.AttributesResponse); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return &resp, err
+}
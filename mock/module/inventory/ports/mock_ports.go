@@ -0,0 +1,174 @@
+package mock_ports
+
+import (
+	"codebase-app/internal/module/inventory/entity"
+	"codebase-app/internal/module/inventory/ports"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockInventoryRepo struct {
+	mock.Mock
+}
+
+func NewMockInventoryRepo() *MockInventoryRepo {
+	return &MockInventoryRepo{}
+}
+
+var _ ports.InventoryRepository = &MockInventoryRepo{}
+
+func (m *MockInventoryRepo) GetMovements(ctx context.Context, req *entity.MovementsRequest) (*entity.MovementsResponse, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp *entity.MovementsResponse
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.MovementsResponse); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockInventoryRepo) AdjustStock(ctx context.Context, req *entity.AdjustStockRequest) (*entity.MovementItem, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp *entity.MovementItem
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.MovementItem); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockInventoryRepo) GetStock(ctx context.Context, productId string) (int, error) {
+	args := m.Called(ctx, productId)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockInventoryRepo) ReserveStock(ctx context.Context, req *entity.ReserveStockRequest) (*entity.Reservation, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp *entity.Reservation
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.Reservation); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockInventoryRepo) GetReservation(ctx context.Context, id string) (*entity.Reservation, error) {
+	args := m.Called(ctx, id)
+	var (
+		resp *entity.Reservation
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.Reservation); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockInventoryRepo) CommitReservation(ctx context.Context, req *entity.ReservationRequest) (*entity.Reservation, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp *entity.Reservation
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.Reservation); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockInventoryRepo) ReleaseReservation(ctx context.Context, req *entity.ReservationRequest) (*entity.Reservation, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp *entity.Reservation
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.Reservation); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockInventoryRepo) ExpireReservations(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockInventoryRepo) GetAvailableStock(ctx context.Context, productId string, variantId *string) (int, error) {
+	args := m.Called(ctx, productId, variantId)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockInventoryRepo) IsProductOwner(ctx context.Context, userId, productId string) (bool, error) {
+	args := m.Called(ctx, userId, productId)
+	var (
+		resp bool
+		err  error
+	)
+
+	if n, ok := args.Get(0).(bool); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
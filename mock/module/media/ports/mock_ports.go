@@ -0,0 +1,139 @@
+package mock_ports
+
+import (
+	"codebase-app/internal/module/media/entity"
+	"codebase-app/internal/module/media/ports"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMediaRepo struct {
+	mock.Mock
+}
+
+func NewMockMediaRepo() *MockMediaRepo {
+	return &MockMediaRepo{}
+}
+
+var _ ports.MediaRepository = &MockMediaRepo{}
+
+func (m *MockMediaRepo) GetImages(ctx context.Context, req *entity.ImagesRequest) (*entity.ImagesResponse, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp entity.ImagesResponse
+		err  error
+	)
+
+	if n, ok := args.Get(0).(entity.ImagesResponse); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return &resp, err
+}
+
+func (m *MockMediaRepo) CreateImage(ctx context.Context, req *entity.CreateImageRequest) (*entity.ImageItem, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp entity.ImageItem
+		err  error
+	)
+
+	if n, ok := args.Get(0).(entity.ImageItem); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return &resp, err
+}
+
+func (m *MockMediaRepo) UpdateImage(ctx context.Context, req *entity.UpdateImageRequest) (*entity.ImageItem, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp entity.ImageItem
+		err  error
+	)
+
+	if n, ok := args.Get(0).(entity.ImageItem); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return &resp, err
+}
+
+func (m *MockMediaRepo) ReorderImages(ctx context.Context, req *entity.ReorderImagesRequest) (*entity.ImagesResponse, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp entity.ImagesResponse
+		err  error
+	)
+
+	if n, ok := args.Get(0).(entity.ImagesResponse); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return &resp, err
+}
+
+func (m *MockMediaRepo) DeleteImage(ctx context.Context, req *entity.DeleteImageRequest) (string, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp string
+		err  error
+	)
+
+	if n, ok := args.Get(0).(string); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockMediaRepo) IsProductOwner(ctx context.Context, userId, productId string) (bool, error) {
+	args := m.Called(ctx, userId, productId)
+	var (
+		resp bool
+		err  error
+	)
+
+	if n, ok := args.Get(0).(bool); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
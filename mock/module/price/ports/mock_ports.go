@@ -0,0 +1,179 @@
+package mock_ports
+
+import (
+	"codebase-app/internal/module/price/entity"
+	"codebase-app/internal/module/price/ports"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPriceRepo struct {
+	mock.Mock
+}
+
+func NewMockPriceRepo() *MockPriceRepo {
+	return &MockPriceRepo{}
+}
+
+var _ ports.PriceRepository = &MockPriceRepo{}
+
+func (m *MockPriceRepo) GetProduct(ctx context.Context, productId string) (*entity.Product, error) {
+	args := m.Called(ctx, productId)
+	var (
+		resp *entity.Product
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.Product); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockPriceRepo) GetPriceHistory(ctx context.Context, req *entity.PriceHistoryRequest) (*entity.PriceHistoryResponse, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp *entity.PriceHistoryResponse
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.PriceHistoryResponse); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockPriceRepo) GetPriceSchedules(ctx context.Context, req *entity.PriceSchedulesRequest) (*entity.PriceSchedulesResponse, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp *entity.PriceSchedulesResponse
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.PriceSchedulesResponse); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockPriceRepo) GetPriceSchedule(ctx context.Context, productId, id string) (*entity.PriceScheduleItem, error) {
+	args := m.Called(ctx, productId, id)
+	var (
+		resp *entity.PriceScheduleItem
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.PriceScheduleItem); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockPriceRepo) CreatePriceSchedule(ctx context.Context, req *entity.CreatePriceScheduleRequest) (*entity.PriceScheduleItem, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp *entity.PriceScheduleItem
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.PriceScheduleItem); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockPriceRepo) CancelPriceSchedule(ctx context.Context, req *entity.CancelPriceScheduleRequest) (*entity.PriceScheduleItem, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp *entity.PriceScheduleItem
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.PriceScheduleItem); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockPriceRepo) ApplyPriceSchedules(ctx context.Context) (*entity.PriceScheduleResult, error) {
+	args := m.Called(ctx)
+	var (
+		resp *entity.PriceScheduleResult
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.PriceScheduleResult); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockPriceRepo) IsProductOwner(ctx context.Context, userId, productId string) (bool, error) {
+	args := m.Called(ctx, userId, productId)
+	var (
+		resp bool
+		err  error
+	)
+
+	if n, ok := args.Get(0).(bool); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
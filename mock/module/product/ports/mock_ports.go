@@ -4,6 +4,7 @@ import (
 	"codebase-app/internal/module/product/entity"
 	"codebase-app/internal/module/product/ports"
 	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -38,6 +39,46 @@ func (m *MockProductRepo) GetProducts(ctx context.Context, req *entity.ProductsR
 	return &resp, err
 }
 
+func (m *MockProductRepo) GetCatalog(ctx context.Context, req *entity.ProductsRequest) (*entity.ProductsResponse, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp entity.ProductsResponse
+		err  error
+	)
+
+	if n, ok := args.Get(0).(entity.ProductsResponse); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return &resp, err
+}
+
+func (m *MockProductRepo) GetShopCatalog(ctx context.Context, req *entity.StorefrontRequest) (*entity.ProductsResponse, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp entity.ProductsResponse
+		err  error
+	)
+
+	if n, ok := args.Get(0).(entity.ProductsResponse); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return &resp, err
+}
+
 func (m *MockProductRepo) CreateProduct(ctx context.Context, req *entity.CreateProductRequest) (*entity.CreateProductResponse, error) {
 	args := m.Called(ctx, req)
 	var (
@@ -165,3 +206,398 @@ func (m *MockProductRepo) IsProductOwner(ctx context.Context, userId, productId
 
 	return resp, err
 }
+
+func (m *MockProductRepo) GetAttributeSchema(ctx context.Context, categoryId string) ([]entity.AttributeSchema, error) {
+	args := m.Called(ctx, categoryId)
+	var (
+		resp []entity.AttributeSchema
+		err  error
+	)
+
+	if n, ok := args.Get(0).([]entity.AttributeSchema); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockProductRepo) GetProductStatus(ctx context.Context, id string) (string, error) {
+	args := m.Called(ctx, id)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockProductRepo) UpdateProductStatus(ctx context.Context, req *entity.TransitionProductRequest, from string) (*entity.TransitionProductResponse, error) {
+	args := m.Called(ctx, req, from)
+	var (
+		resp *entity.TransitionProductResponse
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.TransitionProductResponse); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockProductRepo) ScheduleProduct(ctx context.Context, req *entity.ScheduleProductRequest) (*entity.ScheduleProductResponse, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp *entity.ScheduleProductResponse
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.ScheduleProductResponse); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockProductRepo) ApplySchedules(ctx context.Context) (*entity.ScheduleResult, error) {
+	args := m.Called(ctx)
+	var (
+		resp *entity.ScheduleResult
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.ScheduleResult); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockProductRepo) GetTrashedProducts(ctx context.Context, req *entity.TrashedProductsRequest) (*entity.TrashedProductsResponse, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp *entity.TrashedProductsResponse
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.TrashedProductsResponse); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockProductRepo) GetTrashedProduct(ctx context.Context, id string) (*entity.TrashedProduct, error) {
+	args := m.Called(ctx, id)
+	var (
+		resp *entity.TrashedProduct
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.TrashedProduct); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockProductRepo) RestoreProduct(ctx context.Context, req *entity.RestoreProductRequest) (*entity.RestoreProductResponse, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp *entity.RestoreProductResponse
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.RestoreProductResponse); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockProductRepo) PurgeProducts(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockProductRepo) PatchProduct(ctx context.Context, req *entity.PatchProductRequest) (*entity.UpdateProductResponse, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp *entity.UpdateProductResponse
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.UpdateProductResponse); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockProductRepo) GetProductVersion(ctx context.Context, id string) (int, error) {
+	args := m.Called(ctx, id)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockProductRepo) ImportProducts(ctx context.Context, reqs []*entity.CreateProductRequest, batchSize int) (int, error) {
+	args := m.Called(ctx, reqs, batchSize)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockProductRepo) GetCategoryRefs(ctx context.Context, keys []string) ([]entity.ImportRef, error) {
+	args := m.Called(ctx, keys)
+	var (
+		resp []entity.ImportRef
+		err  error
+	)
+
+	if n, ok := args.Get(0).([]entity.ImportRef); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockProductRepo) GetBrandRefs(ctx context.Context, keys []string) ([]entity.ImportRef, error) {
+	args := m.Called(ctx, keys)
+	var (
+		resp []entity.ImportRef
+		err  error
+	)
+
+	if n, ok := args.Get(0).([]entity.ImportRef); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockProductRepo) CountExportProducts(ctx context.Context, req *entity.ProductsRequest) (int, error) {
+	args := m.Called(ctx, req)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockProductRepo) StreamExportProducts(ctx context.Context, req *entity.ProductsRequest, fn func(row *entity.ExportRow) error) error {
+	args := m.Called(ctx, req, fn)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) CreateExport(ctx context.Context, export *entity.ProductExport) (*entity.ProductExport, error) {
+	args := m.Called(ctx, export)
+	var (
+		resp *entity.ProductExport
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.ProductExport); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockProductRepo) GetExport(ctx context.Context, id string) (*entity.ProductExport, error) {
+	args := m.Called(ctx, id)
+	var (
+		resp *entity.ProductExport
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.ProductExport); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockProductRepo) ClaimExports(ctx context.Context, limit int) ([]entity.ProductExport, error) {
+	args := m.Called(ctx, limit)
+	var (
+		resp []entity.ProductExport
+		err  error
+	)
+
+	if n, ok := args.Get(0).([]entity.ProductExport); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockProductRepo) CompleteExport(ctx context.Context, id, fileName string, rowCount int) error {
+	args := m.Called(ctx, id, fileName, rowCount)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) FailExport(ctx context.Context, id, reason string) error {
+	args := m.Called(ctx, id, reason)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) SyncFeedItems(ctx context.Context) (*entity.FeedSyncResult, error) {
+	args := m.Called(ctx)
+	var (
+		resp *entity.FeedSyncResult
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.FeedSyncResult); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockProductRepo) GetFeedShop(ctx context.Context, shopId string) (*entity.Feed, error) {
+	args := m.Called(ctx, shopId)
+	var (
+		resp *entity.Feed
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.Feed); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockProductRepo) GetFeedStats(ctx context.Context, shopId string) (*entity.FeedStats, error) {
+	args := m.Called(ctx, shopId)
+	var (
+		resp *entity.FeedStats
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.FeedStats); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockProductRepo) GetProductIdBySlug(ctx context.Context, slug string) (string, error) {
+	args := m.Called(ctx, slug)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockProductRepo) GetShopIdBySlug(ctx context.Context, slug string) (string, error) {
+	args := m.Called(ctx, slug)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockProductRepo) GetStorefront(ctx context.Context, shopId string) (*entity.StorefrontResponse, error) {
+	args := m.Called(ctx, shopId)
+	var (
+		resp *entity.StorefrontResponse
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.StorefrontResponse); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockProductRepo) StreamFeedItems(ctx context.Context, shopId string, fn func(item *entity.FeedItem) error) error {
+	args := m.Called(ctx, shopId, fn)
+	return args.Error(0)
+}
@@ -0,0 +1,84 @@
+package mock_ports
+
+import (
+	"codebase-app/internal/module/promotion/entity"
+	"codebase-app/internal/module/promotion/ports"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPromotionRepo struct {
+	mock.Mock
+}
+
+func NewMockPromotionRepo() *MockPromotionRepo {
+	return &MockPromotionRepo{}
+}
+
+var _ ports.PromotionRepository = &MockPromotionRepo{}
+
+func (m *MockPromotionRepo) GetPromotions(ctx context.Context, req *entity.PromotionsRequest) (*entity.PromotionsResponse, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp *entity.PromotionsResponse
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.PromotionsResponse); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockPromotionRepo) CreatePromotion(ctx context.Context, req *entity.CreatePromotionRequest) (*entity.PromotionItem, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp *entity.PromotionItem
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.PromotionItem); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockPromotionRepo) DeletePromotion(ctx context.Context, req *entity.DeletePromotionRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockPromotionRepo) IsShopOwner(ctx context.Context, userId, shopId string) (bool, error) {
+	args := m.Called(ctx, userId, shopId)
+	var (
+		resp bool
+		err  error
+	)
+
+	if n, ok := args.Get(0).(bool); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
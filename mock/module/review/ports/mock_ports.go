@@ -0,0 +1,131 @@
+package mock_ports
+
+import (
+	"codebase-app/internal/module/review/entity"
+	"codebase-app/internal/module/review/ports"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReviewRepo struct {
+	mock.Mock
+}
+
+func NewMockReviewRepo() *MockReviewRepo {
+	return &MockReviewRepo{}
+}
+
+var _ ports.ReviewRepository = &MockReviewRepo{}
+
+func (m *MockReviewRepo) GetReviews(ctx context.Context, req *entity.ReviewsRequest) (*entity.ReviewsResponse, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp entity.ReviewsResponse
+		err  error
+	)
+
+	if n, ok := args.Get(0).(entity.ReviewsResponse); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return &resp, err
+}
+
+func (m *MockReviewRepo) GetUserReviews(ctx context.Context, req *entity.UserReviewsRequest) (*entity.ReviewsResponse, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp entity.ReviewsResponse
+		err  error
+	)
+
+	if n, ok := args.Get(0).(entity.ReviewsResponse); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return &resp, err
+}
+
+func (m *MockReviewRepo) CreateReview(ctx context.Context, req *entity.CreateReviewRequest) (*entity.ReviewItem, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp entity.ReviewItem
+		err  error
+	)
+
+	if n, ok := args.Get(0).(entity.ReviewItem); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return &resp, err
+}
+
+func (m *MockReviewRepo) UpdateReview(ctx context.Context, req *entity.UpdateReviewRequest) (*entity.ReviewItem, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp entity.ReviewItem
+		err  error
+	)
+
+	if n, ok := args.Get(0).(entity.ReviewItem); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return &resp, err
+}
+
+func (m *MockReviewRepo) DeleteReview(ctx context.Context, req *entity.DeleteReviewRequest) error {
+	args := m.Called(ctx, req)
+	var err error
+
+	if n, ok := args.Get(0).(error); ok {
+
+		err = n
+	}
+
+	return err
+}
+
+func (m *MockReviewRepo) IsReviewAuthor(ctx context.Context, userId, reviewId string) (bool, error) {
+	args := m.Called(ctx, userId, reviewId)
+	var (
+		resp bool
+		err  error
+	)
+
+	if n, ok := args.Get(0).(bool); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
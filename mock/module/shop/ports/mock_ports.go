@@ -4,6 +4,7 @@ import (
 	"codebase-app/internal/module/shop/entity"
 	"codebase-app/internal/module/shop/ports"
 	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -109,3 +110,58 @@ func (m *MockShopRepo) GetShops(ctx context.Context, req *entity.ShopsRequest) (
 
 	return &resp, err
 }
+
+func (m *MockShopRepo) GetTrashedShops(ctx context.Context, req *entity.TrashedShopsRequest) (*entity.TrashedShopsResponse, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp *entity.TrashedShopsResponse
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.TrashedShopsResponse); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockShopRepo) RestoreShop(ctx context.Context, req *entity.RestoreShopRequest) (*entity.RestoreShopResponse, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp *entity.RestoreShopResponse
+		err  error
+	)
+
+	if n, ok := args.Get(0).(*entity.RestoreShopResponse); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
+
+func (m *MockShopRepo) PurgeShops(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockShopRepo) GetShopVersion(ctx context.Context, id string) (int, error) {
+	args := m.Called(ctx, id)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockShopRepo) GetShopIdBySlug(ctx context.Context, slug string) (string, error) {
+	args := m.Called(ctx, slug)
+	return args.String(0), args.Error(1)
+}
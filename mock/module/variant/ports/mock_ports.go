@@ -0,0 +1,133 @@
+package mock_ports
+
+import (
+	"codebase-app/internal/module/variant/entity"
+	"codebase-app/internal/module/variant/ports"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockVariantRepo struct {
+	mock.Mock
+}
+
+func NewMockVariantRepo() *MockVariantRepo {
+	return &MockVariantRepo{}
+}
+
+var _ ports.VariantRepository = &MockVariantRepo{}
+
+func (m *MockVariantRepo) GetVariants(ctx context.Context, req *entity.VariantsRequest) (*entity.VariantsResponse, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp entity.VariantsResponse
+		err  error
+	)
+
+	if n, ok := args.Get(0).(entity.VariantsResponse); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return &resp, err
+}
+
+func (m *MockVariantRepo) GetVariant(ctx context.Context, req *entity.GetVariantRequest) (*entity.VariantItem, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp entity.VariantItem
+		err  error
+	)
+
+	if n, ok := args.Get(0).(entity.VariantItem); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return &resp, err
+}
+
+func (m *MockVariantRepo) CreateVariant(ctx context.Context, req *entity.CreateVariantRequest) (*entity.CreateVariantResponse, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp entity.CreateVariantResponse
+		err  error
+	)
+
+	if n, ok := args.Get(0).(entity.CreateVariantResponse); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return &resp, err
+}
+
+func (m *MockVariantRepo) UpdateVariant(ctx context.Context, req *entity.UpdateVariantRequest) (*entity.UpdateVariantResponse, error) {
+	args := m.Called(ctx, req)
+	var (
+		resp entity.UpdateVariantResponse
+		err  error
+	)
+
+	if n, ok := args.Get(0).(entity.UpdateVariantResponse); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return &resp, err
+}
+
+func (m *MockVariantRepo) DeleteVariant(ctx context.Context, req *entity.DeleteVariantRequest) error {
+	args := m.Called(ctx, req)
+	var (
+		err error
+	)
+
+	if n, ok := args.Get(0).(error); ok {
+
+		err = n
+	}
+
+	return err
+}
+
+func (m *MockVariantRepo) IsProductOwner(ctx context.Context, userId, productId string) (bool, error) {
+	args := m.Called(ctx, userId, productId)
+	var (
+		resp bool
+		err  error
+	)
+
+	if n, ok := args.Get(0).(bool); ok {
+
+		resp = n
+	}
+
+	if n, ok := args.Get(1).(error); ok {
+
+		err = n
+	}
+
+	return resp, err
+}
@@ -0,0 +1,152 @@
+// Package slug turns names into the human readable, URL safe identifiers
+// products and shops can be fetched by.
+package slug
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxLength is the longest slug Make and Unique return.
+const MaxLength = 80
+
+// letters that don't decompose into an ASCII letter and a diacritic
+var transliterations = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ð': "d",
+	'ł': "l", 'þ': "th", 'ı': "i", 'ħ': "h", 'ŧ': "t", 'ŋ': "ng",
+}
+
+// Make lowercases name, transliterates accented latin letters to ASCII and
+// joins the remaining runs of letters and digits with dashes, e.g.
+// "Kaos Polos Café Ñ" becomes "kaos-polos-cafe-n". It returns an empty
+// string when nothing of name can be kept.
+func Make(name string) string {
+	var (
+		b    strings.Builder
+		dash bool
+	)
+
+	write := func(r rune) {
+		if ('a' <= r && r <= 'z') || ('0' <= r && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			dash = false
+			b.WriteRune(r)
+			return
+		}
+		dash = true
+	}
+
+	// NFKD splits accented letters into the letter and its marks, and
+	// compatibility forms like "ﬁ" or full width digits into plain ones
+	for _, r := range norm.NFKD.String(name) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+
+		r = unicode.ToLower(r)
+		if s, ok := transliterations[r]; ok {
+			for _, r := range s {
+				write(r)
+			}
+			continue
+		}
+
+		write(r)
+	}
+
+	return truncate(b.String(), MaxLength)
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Parse splits a path parameter that holds either an id or a slug, one of
+// the two results is empty.
+func Parse(param string) (id, slug string) {
+	if uuidPattern.MatchString(param) {
+		return param, ""
+	}
+
+	return "", param
+}
+
+// reserved are the static route segments next to the id of products and
+// shops, e.g. /products/trash, a slug equal to one of them can't be fetched.
+var reserved = map[string]bool{
+	"trash":   true,
+	"export":  true,
+	"exports": true,
+	"import":  true,
+}
+
+// IsReserved reports whether slug can't be used, since it is a static route
+// segment or would be taken for an id by Parse.
+func IsReserved(slug string) bool {
+	return reserved[slug] || uuidPattern.MatchString(slug)
+}
+
+// Unique returns base, or when it is taken or reserved base with the lowest
+// numeric suffix from 2 that isn't, e.g. "kaos-polos-2". base is shortened
+// to keep the suffixed slug within MaxLength.
+func Unique(base string, taken []string) string {
+	used := make(map[string]bool, len(taken))
+	for _, slug := range taken {
+		used[slug] = true
+	}
+
+	if !used[base] && !IsReserved(base) {
+		return base
+	}
+
+	for n := 2; ; n++ {
+		if slug := suffixed(base, n); !used[slug] {
+			return slug
+		}
+	}
+}
+
+// Fits reports whether slug is base or base with a numeric suffix, i.e. a
+// slug Unique could have picked for base.
+func Fits(slug, base string) bool {
+	if slug == base {
+		return !IsReserved(slug)
+	}
+
+	i := strings.LastIndexByte(slug, '-')
+	if i < 0 {
+		return false
+	}
+
+	n, err := strconv.Atoi(slug[i+1:])
+	return err == nil && n >= 2 && suffixed(base, n) == slug
+}
+
+// Prefix is the part of base every slug Unique can pick for it starts with,
+// suffixes up to 9999 included.
+func Prefix(base string) string {
+	return truncate(base, MaxLength-len("-9999"))
+}
+
+func suffixed(base string, n int) string {
+	suffix := "-" + strconv.Itoa(n)
+	return truncate(base, MaxLength-len(suffix)) + suffix
+}
+
+// truncate cuts s to at most max bytes, at a dash when one is close to the end.
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+
+	s = s[:max]
+	if i := strings.LastIndexByte(s, '-'); i > max/2 {
+		s = s[:i]
+	}
+
+	return strings.TrimRight(s, "-")
+}
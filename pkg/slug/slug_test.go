@@ -0,0 +1,60 @@
+package slug
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMake(t *testing.T) {
+	cases := map[string]string{
+		"Kaos Polos Café Ñ":            "kaos-polos-cafe-n",
+		"  Laptop ASUS (14\") – 2024 ": "laptop-asus-14-2024",
+		"Straße & Ærø":                 "strasse-aero",
+		"Łódź ﬁesta １２":                "lodz-fiesta-12",
+		"日本":                           "",
+	}
+
+	for name, want := range cases {
+		assert.Equal(t, want, Make(name), name)
+	}
+
+	long := Make(strings.Repeat("panjang ", 20))
+	assert.LessOrEqual(t, len(long), MaxLength)
+	assert.False(t, strings.HasSuffix(long, "-"))
+}
+
+func TestUnique(t *testing.T) {
+	assert.Equal(t, "kaos", Unique("kaos", nil))
+	assert.Equal(t, "kaos-2", Unique("kaos", []string{"kaos", "kaos-polos"}))
+	assert.Equal(t, "kaos-4", Unique("kaos", []string{"kaos", "kaos-2", "kaos-3"}))
+
+	base := strings.Repeat("a", MaxLength)
+	slug := Unique(base, []string{base})
+	assert.Equal(t, strings.Repeat("a", MaxLength-2)+"-2", slug)
+	assert.True(t, strings.HasPrefix(slug, Prefix(base)))
+}
+
+func TestUnique_Reserved(t *testing.T) {
+	assert.Equal(t, "trash-2", Unique(Make("Trash"), nil))
+	assert.Equal(t, "export-3", Unique(Make("EXPORT"), []string{"export-2"}))
+	assert.Equal(t, "trash-can", Unique(Make("Trash Can"), nil))
+
+	id := "c396f23e-a097-476d-aae5-cfc9973634f3"
+	slug := Unique(Make(id), nil)
+	assert.Equal(t, id+"-2", slug)
+	parsedId, parsedSlug := Parse(slug)
+	assert.Empty(t, parsedId)
+	assert.Equal(t, slug, parsedSlug)
+}
+
+func TestFits(t *testing.T) {
+	assert.True(t, Fits("kaos", "kaos"))
+	assert.True(t, Fits("kaos-3", "kaos"))
+	assert.False(t, Fits("kaos-1", "kaos"))
+	assert.False(t, Fits("kaos-polos", "kaos"))
+	assert.False(t, Fits("kaos", "kaos-polos"))
+	assert.False(t, Fits("trash", "trash"))
+	assert.True(t, Fits("trash-2", "trash"))
+}
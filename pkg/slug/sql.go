@@ -0,0 +1,99 @@
+package slug
+
+import (
+	"cmp"
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Owner names a table whose rows are fetched by slug and the history table
+// keeping the slugs its rows had before a rename.
+type Owner struct {
+	Table         string
+	HistoryTable  string
+	HistoryColumn string
+	Fallback      string // slug of names Make keeps nothing of, also names the slug lock
+}
+
+var (
+	Products = Owner{Table: "products", HistoryTable: "product_slug_history", HistoryColumn: "product_id", Fallback: "product"}
+	Shops    = Owner{Table: "shops", HistoryTable: "shop_slug_history", HistoryColumn: "shop_id", Fallback: "shop"}
+)
+
+// FindId returns the id of the row of owner with the given current slug or
+// with one it had before a rename, sql.ErrNoRows when no row had it.
+func FindId(ctx context.Context, db *sqlx.DB, owner Owner, slug string) (string, error) {
+	var id string
+
+	query := `
+		SELECT id FROM ` + owner.Table + ` WHERE slug = ?
+		UNION ALL
+		SELECT ` + owner.HistoryColumn + ` FROM ` + owner.HistoryTable + ` WHERE slug = ?
+		LIMIT 1
+	`
+
+	err := db.GetContext(ctx, &id, db.Rebind(query), slug, slug)
+	return id, err
+}
+
+// Pick picks a free slug for a row of owner named name, id is empty for a
+// new row. The current slugs and the old slugs of other rows are taken, the
+// lock keeps concurrent writers from picking the same slug until tx ends.
+func Pick(ctx context.Context, tx *sqlx.Tx, owner Owner, name, id string) (string, error) {
+	var (
+		base   = cmp.Or(Make(name), owner.Fallback)
+		prefix = Prefix(base) + "%"
+		taken  []string
+	)
+
+	if _, err := tx.ExecContext(ctx, tx.Rebind("SELECT pg_advisory_xact_lock(hashtext(?))"), owner.Fallback+"_slugs"); err != nil {
+		return "", err
+	}
+
+	query := `
+		SELECT slug FROM ` + owner.Table + ` WHERE slug LIKE ? AND id::TEXT <> ?
+		UNION ALL
+		SELECT slug FROM ` + owner.HistoryTable + ` WHERE slug LIKE ? AND ` + owner.HistoryColumn + `::TEXT <> ?
+	`
+
+	if err := tx.SelectContext(ctx, &taken, tx.Rebind(query), prefix, id, prefix, id); err != nil {
+		return "", err
+	}
+
+	return Unique(base, taken), nil
+}
+
+// Rename gives a renamed row of owner a slug for its new name and keeps the
+// old one in the history, so links to it redirect. A name that still fits
+// the current slug keeps it. It returns the slug the row ends up with.
+func Rename(ctx context.Context, tx *sqlx.Tx, owner Owner, id, name string) (string, error) {
+	var current string
+
+	err := tx.GetContext(ctx, &current, tx.Rebind(`SELECT slug FROM `+owner.Table+` WHERE id = ?`), id)
+	if err != nil {
+		return "", err
+	}
+
+	if Fits(current, cmp.Or(Make(name), owner.Fallback)) {
+		return current, nil
+	}
+
+	next, err := Pick(ctx, tx, owner, name, id)
+	if err != nil {
+		return "", err
+	}
+
+	query := `
+		WITH history AS (
+			INSERT INTO ` + owner.HistoryTable + ` (slug, ` + owner.HistoryColumn + `) VALUES (?, ?)
+			ON CONFLICT (slug) DO NOTHING
+		), reused AS (
+			DELETE FROM ` + owner.HistoryTable + ` WHERE slug = ? AND ` + owner.HistoryColumn + ` = ?
+		)
+		UPDATE ` + owner.Table + ` SET slug = ? WHERE id = ?
+	`
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(query), current, id, next, id, next, id)
+	return next, err
+}
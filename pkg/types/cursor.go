@@ -0,0 +1,48 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor is the decoded form of an opaque keyset pagination cursor. It keeps
+// the sort key it was issued for together with the sort value and id of the
+// row the next page starts after.
+type Cursor struct {
+	Sort     string `json:"s"`
+	Value    any    `json:"v"`
+	Id       string `json:"i"`
+	Backward bool   `json:"b,omitempty"`
+}
+
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func DecodeCursor(s string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(b, &c); err != nil || c.Id == "" || c.Value == nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &c, nil
+}
+
+// CursorValue converts a scanned sort value into something that survives a
+// JSON round trip and can be bound back as a query argument.
+func CursorValue(v any) any {
+	if b, ok := v.([]byte); ok {
+		return string(b)
+	}
+
+	return v
+}
@@ -1,10 +1,12 @@
 package types
 
 type Meta struct {
-	Page      int `json:"page"`
-	Paginate  int `json:"paginate"`
-	TotalData int `json:"total_data"`
-	TotalPage int `json:"total_page"`
+	Page       int    `json:"page"`
+	Paginate   int    `json:"paginate"`
+	TotalData  int    `json:"total_data"`
+	TotalPage  int    `json:"total_page"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
 func (r *Meta) CountTotalPage(page, paginate, totalData int) {
@@ -27,3 +29,17 @@ func (r *Meta) CountTotalPage(page, paginate, totalData int) {
 		r.TotalPage = 1
 	}
 }
+
+// SetCursors fills the meta of a keyset paginated page. Page numbers don't
+// apply in that mode and stay 0, the total is only set when it was requested.
+func (r *Meta) SetCursors(paginate int, next, prev *Cursor) {
+	r.Paginate = paginate
+
+	if next != nil {
+		r.NextCursor = next.Encode()
+	}
+
+	if prev != nil {
+		r.PrevCursor = prev.Encode()
+	}
+}
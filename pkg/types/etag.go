@@ -0,0 +1,134 @@
+package types
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+
+	"codebase-app/pkg/errmsg"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ETag formats a row version as a strong entity tag.
+func ETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// ContentETag formats a strong entity tag for a representation of a row,
+// the version followed by a hash of body. Unlike the version alone it
+// changes with the fields derived from other rows, e.g. the available stock
+// or the promotion price, so If-None-Match only hits an unchanged body.
+func ContentETag(version int, body any) string {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return ETag(version)
+	}
+
+	sum := sha256.Sum256(data)
+	return `"` + strconv.Itoa(version) + "-" + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// ETags is a parsed If-Match or If-None-Match header, nil when the header is
+// absent. Weak tags keep their W/ prefix.
+type ETags []string
+
+func ParseETags(header string) ETags {
+	if strings.TrimSpace(header) == "" {
+		return nil
+	}
+
+	tags := make(ETags, 0, 1)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// Matches reports whether one of the tags, or "*", is a tag of the given
+// version, as made by ETag or ContentETag. It is meant for If-Match, so weak
+// tags never match.
+func (e ETags) Matches(version int) bool {
+	for _, tag := range e {
+		if tag == "*" {
+			return true
+		}
+
+		if strings.HasPrefix(tag, "W/") {
+			continue
+		}
+
+		opaque := strings.Trim(tag, `"`)
+		opaque, _, _ = strings.Cut(opaque, "-")
+		if opaque == strconv.Itoa(version) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Contains reports whether one of the tags, or "*", is the given entity tag.
+// It is meant for If-None-Match, so tags are compared by their opaque part
+// whether they are weak or not.
+func (e ETags) Contains(etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, tag := range e {
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// VersionCondition makes a write hit only the version CheckVersion returned,
+// it takes the version twice and matches any version when it is NULL.
+const VersionCondition = " AND (?::INTEGER IS NULL OR version = ?)"
+
+// CheckVersion evaluates an If-Match precondition against the stored version
+// of a row and returns the version the write has to hit, nil without
+// precondition. resource names the row in errors, e.g. "Product".
+func CheckVersion(ctx context.Context, resource, id string, ifMatch ETags, getVersion func(ctx context.Context, id string) (int, error)) (*int, error) {
+	if ifMatch == nil {
+		return nil, nil
+	}
+
+	version, err := getVersion(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage(resource+" not found"))
+		}
+		return nil, err
+	}
+
+	if !ifMatch.Matches(version) {
+		log.Warn().Str("id", id).Int("version", version).Strs("if_match", ifMatch).Msgf("service: %s version is stale", resource)
+		return nil, errmsg.NewCustomErrors(412, errmsg.WithMessage(resource+" has been modified, reload it and try again"))
+	}
+
+	return &version, nil
+}
+
+// WriteError maps a write that matched no row, a concurrent change once the
+// version was checked by CheckVersion, otherwise a missing row.
+func WriteError(resource string, err error, version *int) error {
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	if version != nil {
+		return errmsg.NewCustomErrors(412, errmsg.WithMessage(resource+" has been modified, reload it and try again"))
+	}
+
+	return errmsg.NewCustomErrors(404, errmsg.WithMessage(resource+" not found"))
+}
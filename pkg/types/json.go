@@ -0,0 +1,59 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+// StringMap is a flat string map stored in a JSONB column.
+type StringMap map[string]string
+
+// Scan implements the sql.Scanner interface.
+func (m *StringMap) Scan(val interface{}) error {
+	b, ok := val.([]byte)
+	if !ok {
+		if val == nil {
+			*m = nil
+			return nil
+		}
+		return errors.New("types: StringMap expects []byte")
+	}
+
+	return json.Unmarshal(b, m)
+}
+
+// Value impl.
+func (m StringMap) Value() (driver.Value, error) {
+	if m == nil {
+		return []byte("{}"), nil
+	}
+
+	return json.Marshal(m)
+}
+
+// JSONMap is a JSON object with arbitrary values stored in a JSONB column.
+type JSONMap map[string]any
+
+// Scan implements the sql.Scanner interface.
+func (m *JSONMap) Scan(val interface{}) error {
+	b, ok := val.([]byte)
+	if !ok {
+		if val == nil {
+			*m = nil
+			return nil
+		}
+		return errors.New("types: JSONMap expects []byte")
+	}
+
+	return json.Unmarshal(b, m)
+}
+
+// Value impl.
+func (m JSONMap) Value() (driver.Value, error) {
+	if m == nil {
+		return []byte("{}"), nil
+	}
+
+	return json.Marshal(m)
+}
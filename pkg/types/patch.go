@@ -0,0 +1,91 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+)
+
+// PatchFields records which keys a JSON merge patch body carries. A key
+// mapped to true was sent as an explicit null.
+type PatchFields map[string]bool
+
+// ParsePatchFields reads the top level keys of a JSON object.
+func ParsePatchFields(body []byte) (PatchFields, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+
+	fields := make(PatchFields, len(raw))
+	for key, value := range raw {
+		fields[key] = bytes.Equal(bytes.TrimSpace(value), []byte("null"))
+	}
+
+	return fields, nil
+}
+
+// Has reports whether the key is present, null or not.
+func (f PatchFields) Has(key string) bool {
+	_, ok := f[key]
+	return ok
+}
+
+// IsNull reports whether the key is present with a null value.
+func (f PatchFields) IsNull(key string) bool {
+	return f[key]
+}
+
+// Unknown returns the keys that aren't in allowed, sorted.
+func (f PatchFields) Unknown(allowed []string) []string {
+	var unknown []string
+	for key := range f {
+		if !slices.Contains(allowed, key) {
+			unknown = append(unknown, key)
+		}
+	}
+
+	slices.Sort(unknown)
+	return unknown
+}
+
+// Nulls returns the keys sent as an explicit null that aren't in nullable,
+// sorted.
+func (f PatchFields) Nulls(nullable []string) []string {
+	var nulls []string
+	for key, isNull := range f {
+		if isNull && !slices.Contains(nullable, key) {
+			nulls = append(nulls, key)
+		}
+	}
+
+	slices.Sort(nulls)
+	return nulls
+}
+
+// MergePatch applies a JSON merge patch (RFC 7386) to target without
+// changing it. A null removes the key and objects are merged recursively,
+// any other value replaces the one of target.
+func MergePatch(target, patch map[string]any) map[string]any {
+	merged := make(map[string]any, len(target)+len(patch))
+	for key, value := range target {
+		merged[key] = value
+	}
+
+	for key, value := range patch {
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+
+		if object, ok := value.(map[string]any); ok {
+			current, _ := merged[key].(map[string]any)
+			merged[key] = MergePatch(current, object)
+			continue
+		}
+
+		merged[key] = value
+	}
+
+	return merged
+}
@@ -0,0 +1,226 @@
+package types
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+// ContentTypes are the content types files of each format are served with.
+var ContentTypes = map[string]string{
+	FormatCSV:   "text/csv; charset=utf-8",
+	FormatJSONL: "application/x-ndjson",
+}
+
+// Record is one decoded row of a CSV or JSON Lines file. Line is the line
+// the row starts on, counting the CSV header. A row that can't be decoded
+// carries a *FieldError instead of a value.
+type Record[T any] struct {
+	Line  int
+	Value T
+	Err   error
+}
+
+// FieldError reports the field of a row that failed to decode, Field is
+// empty when the row as a whole is malformed.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	if e.Field == "" {
+		return e.Err.Error()
+	}
+	return e.Field + ": " + e.Err.Error()
+}
+
+// DetectFormat guesses the format of a file from its name or content type,
+// it returns an empty string when neither gives it away.
+func DetectFormat(filename, contentType string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return FormatCSV
+	case ".jsonl", ".ndjson":
+		return FormatJSONL
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "text/csv":
+		return FormatCSV
+	case "application/jsonl", "application/x-ndjson", "application/x-jsonlines":
+		return FormatJSONL
+	}
+
+	return ""
+}
+
+// DecodeRecords reads every row of a CSV file with a header row or of a
+// JSON Lines file into T using its json tags. CSV cells are taken as text
+// for string fields and as JSON for the others, so a number column holds
+// "1500" and an object column holds {"ram":"8GB"}. Empty cells, unknown
+// columns and blank lines are skipped. The returned error is only set when
+// the file itself can't be read, e.g. a CSV with an unbalanced quote.
+func DecodeRecords[T any](r io.Reader, format string) ([]Record[T], error) {
+	switch format {
+	case FormatCSV:
+		return decodeCSV[T](r)
+	case FormatJSONL:
+		return decodeJSONL[T](r)
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+func decodeJSONL[T any](r io.Reader) ([]Record[T], error) {
+	var (
+		records = make([]Record[T], 0)
+		scanner = bufio.NewScanner(r)
+		line    int
+	)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line++
+
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		record := Record[T]{Line: line}
+		record.Err = decodeRecord(data, &record.Value)
+		records = append(records, record)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func decodeCSV[T any](r io.Reader) ([]Record[T], error) {
+	var (
+		records = make([]Record[T], 0)
+		reader  = csv.NewReader(r)
+		text    = stringFields(reflect.TypeOf((*T)(nil)).Elem())
+	)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		return nil, err
+	}
+
+	// spreadsheet exports tend to start with a byte order mark
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(header[i]))
+	}
+
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		record := Record[T]{Line: line}
+
+		if len(row) > len(header) {
+			record.Err = &FieldError{Err: fmt.Errorf("row has %d columns, header has %d", len(row), len(header))}
+			records = append(records, record)
+			continue
+		}
+
+		fields := make(map[string]json.RawMessage, len(row))
+		for i, cell := range row {
+			cell = strings.TrimSpace(cell)
+			isText, known := text[header[i]]
+			if cell == "" || !known {
+				continue
+			}
+
+			if isText {
+				fields[header[i]], _ = json.Marshal(cell)
+				continue
+			}
+
+			if !json.Valid([]byte(cell)) {
+				record.Err = &FieldError{Field: header[i], Err: fmt.Errorf("invalid value %q", cell)}
+				break
+			}
+			fields[header[i]] = json.RawMessage(cell)
+		}
+
+		if record.Err == nil {
+			data, _ := json.Marshal(fields)
+			record.Err = decodeRecord(data, &record.Value)
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// decodeRecord unmarshals a JSON object, type mismatches are reported on the field.
+func decodeRecord(data []byte, v any) error {
+	err := json.Unmarshal(data, v)
+	if err == nil {
+		return nil
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return &FieldError{Field: typeErr.Field, Err: fmt.Errorf("expected %s, got %s", typeErr.Type, typeErr.Value)}
+	}
+
+	return &FieldError{Err: err}
+}
+
+// stringFields maps the json names of the fields of a struct to whether
+// they hold text, columns without a field are ignored.
+func stringFields(t reflect.Type) map[string]bool {
+	fields := make(map[string]bool)
+	if t.Kind() != reflect.Struct {
+		return fields
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "" || name == "-" {
+			continue
+		}
+
+		kind := field.Type.Kind()
+		if kind == reflect.Pointer {
+			kind = field.Type.Elem().Kind()
+		}
+		fields[name] = kind == reflect.String
+	}
+
+	return fields
+}
@@ -0,0 +1,18 @@
+package types
+
+// ReservedStockExpr is the SQL sum of the quantity held by live stock
+// reservations on a product stock, or on a variant stock when variantColumn
+// is not NULL. Reservations past their expiry no longer hold stock even
+// before the sweeper marks them.
+func ReservedStockExpr(productColumn, variantColumn string) string {
+	return `COALESCE((
+		SELECT SUM(ri.quantity)
+		FROM stock_reservation_items ri
+		INNER JOIN stock_reservations sr ON ri.reservation_id = sr.id
+		WHERE
+			ri.product_id = ` + productColumn + `
+			AND ri.variant_id IS NOT DISTINCT FROM ` + variantColumn + `
+			AND sr.status = 'pending'
+			AND sr.expires_at > NOW()
+	), 0)`
+}